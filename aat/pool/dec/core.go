@@ -1,6 +1,7 @@
 package dec
 
 import (
+	"context"
 	"log/slog"
 
 	"orglang/orglang/avt/data"
@@ -10,7 +11,7 @@ import (
 
 // Port
 type API interface {
-	Create(PoolSpec) (PoolRef, error)
+	Create(context.Context, PoolSpec) (PoolRef, error)
 }
 
 // for compilation purposes
@@ -50,6 +51,6 @@ type service struct {
 	log      *slog.Logger
 }
 
-func (s *service) Create(spec PoolSpec) (PoolRef, error) {
+func (s *service) Create(ctx context.Context, spec PoolSpec) (PoolRef, error) {
 	return PoolRef{}, nil
 }
@@ -1,6 +1,7 @@
 package dec
 
 import (
+	"context"
 	"github.com/go-resty/resty/v2"
 )
 
@@ -18,6 +19,6 @@ func NewAPI() API {
 	return newClientResty()
 }
 
-func (cl *clientResty) Create(spec PoolSpec) (PoolRef, error) {
+func (cl *clientResty) Create(ctx context.Context, spec PoolSpec) (PoolRef, error) {
 	return PoolRef{}, nil
 }
@@ -0,0 +1,182 @@
+package dump
+
+import (
+	"context"
+	"log/slog"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+)
+
+// Port
+type API interface {
+	Export(context.Context, id.ADT) (Doc, error)
+	Import(context.Context, Doc) (DocRef, error)
+}
+
+const docVersion = 1
+
+// self-contained pool snapshot
+type Doc struct {
+	Version int
+	// root of the exported pool tree
+	PoolID id.ADT
+	// storage rows per table
+	Tables map[string][]Row
+}
+
+type Row map[string]any
+
+type DocRef struct {
+	// root of the imported pool tree
+	PoolID id.ADT
+}
+
+type service struct {
+	docs     Repo
+	operator data.Operator
+	log      *slog.Logger
+}
+
+// for compilation purposes
+func newAPI() API {
+	return &service{}
+}
+
+func newService(docs Repo, operator data.Operator, l *slog.Logger) *service {
+	name := slog.String("name", "dumpService")
+	return &service{docs, operator, l.With(name)}
+}
+
+func (s *service) Export(ctx context.Context, poolID id.ADT) (_ Doc, err error) {
+	idAttr := slog.Any("poolID", poolID)
+	s.log.Debug("export started", idAttr)
+	doc := Doc{Version: docVersion, PoolID: poolID}
+	// single snapshot across all tables
+	err = s.operator.Explicit(data.WithSnapshot(ctx), func(ds data.Source) error {
+		doc.Tables, err = s.docs.SelectTables(ds, poolID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("export failed", idAttr)
+		return Doc{}, err
+	}
+	if len(doc.Tables[poolRoots]) == 0 {
+		return Doc{}, errMissingPool(poolID)
+	}
+	s.log.Debug("export succeeded", idAttr)
+	return doc, nil
+}
+
+func (s *service) Import(ctx context.Context, doc Doc) (DocRef, error) {
+	idAttr := slog.Any("poolID", doc.PoolID)
+	s.log.Debug("import started", idAttr)
+	if doc.Version != docVersion {
+		return DocRef{}, errVersionUnexpected(doc.Version)
+	}
+	for table := range doc.Tables {
+		_, ok := exportScopes[table]
+		if !ok {
+			return DocRef{}, errTableUnexpected(table)
+		}
+	}
+	remapped := remap(doc)
+	err := s.operator.Explicit(ctx, func(ds data.Source) error {
+		return s.docs.InsertTables(ds, remapped.Tables)
+	})
+	if err != nil {
+		s.log.Error("import failed", idAttr)
+		return DocRef{}, err
+	}
+	s.log.Debug("import succeeded", idAttr, slog.Any("newID", remapped.PoolID))
+	return DocRef{PoolID: remapped.PoolID}, nil
+}
+
+// columns holding identifiers generated by the runtime
+var idColumns = map[string]bool{
+	"id":          true,
+	"from_id":     true,
+	"pool_id":     true,
+	"sup_pool_id": true,
+	"proc_id":     true,
+	"chnl_id":     true,
+	"state_id":    true,
+	"role_id":     true,
+	"sig_id":      true,
+}
+
+// issues fresh identifiers and rewrites every occurrence,
+// including the ones nested into json specs
+func remap(doc Doc) Doc {
+	ids := map[string]string{
+		doc.PoolID.String(): id.New().String(),
+	}
+	for _, rows := range doc.Tables {
+		for _, row := range rows {
+			for col, val := range row {
+				old, ok := val.(string)
+				if !ok || old == "" || !idColumns[col] {
+					continue
+				}
+				_, seen := ids[old]
+				if !seen {
+					ids[old] = id.New().String()
+				}
+			}
+		}
+	}
+	tables := make(map[string][]Row, len(doc.Tables))
+	for table, rows := range doc.Tables {
+		newRows := make([]Row, 0, len(rows))
+		for _, row := range rows {
+			newRows = append(newRows, replaceIDs(row, ids).(Row))
+		}
+		tables[table] = newRows
+	}
+	newID, _ := id.ConvertFromString(ids[doc.PoolID.String()])
+	return Doc{Version: doc.Version, PoolID: newID, Tables: tables}
+}
+
+func replaceIDs(val any, ids map[string]string) any {
+	switch v := val.(type) {
+	case string:
+		newID, ok := ids[v]
+		if ok {
+			return newID
+		}
+		return v
+	case Row:
+		res := make(Row, len(v))
+		for key, item := range v {
+			res[key] = replaceIDs(item, ids)
+		}
+		return res
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for key, item := range v {
+			res[key] = replaceIDs(item, ids)
+		}
+		return res
+	case []any:
+		res := make([]any, 0, len(v))
+		for _, item := range v {
+			res = append(res, replaceIDs(item, ids))
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+func errMissingPool(want id.ADT) error {
+	return fault.New(fault.NotFound, "pool missing: %v", want)
+}
+
+func errVersionUnexpected(got int) error {
+	return fault.New(fault.ProtocolViolation, "dump version unexpected: want %v, got %v", docVersion, got)
+}
+
+func errTableUnexpected(got string) error {
+	return fault.New(fault.ProtocolViolation, "dump table unexpected: %q", got)
+}
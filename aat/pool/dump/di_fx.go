@@ -0,0 +1,43 @@
+//go:build !goverter
+
+package dump
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+
+	"orglang/orglang/avt/data"
+)
+
+var Module = fx.Module("aat/pool/dump",
+	fx.Provide(
+		fx.Annotate(newService, fx.As(new(API))),
+	),
+	fx.Provide(
+		fx.Private,
+		newHandlerEcho,
+		newRepoByMapping,
+	),
+	fx.Invoke(
+		cfgEcho,
+	),
+)
+
+func newRepoByMapping(m data.Mapping, l *slog.Logger) Repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
+func cfgEcho(e *echo.Echo, h *handlerEcho) error {
+	e.GET("/api/v1/pools/:id/dump", h.GetOne)
+	e.POST("/api/v1/dumps", h.PostOne)
+	return nil
+}
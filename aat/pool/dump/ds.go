@@ -0,0 +1,41 @@
+package dump
+
+import (
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Port
+type Repo interface {
+	SelectTables(data.Source, id.ADT) (map[string][]Row, error)
+	InsertTables(data.Source, map[string][]Row) error
+}
+
+const poolRoots = "pool_roots"
+
+// row filters per exported table, see scopeCTE
+var exportScopes = map[string]string{
+	poolRoots:      "pool_id in (select pool_id from tree)",
+	"pool_sups":    "pool_id in (select pool_id from tree)",
+	"pool_caps":    "pool_id in (select pool_id from tree)",
+	"pool_deps":    "pool_id in (select pool_id from tree)",
+	"pool_liabs":   "pool_id in (select pool_id from tree)",
+	"pool_quotas":  "pool_id in (select pool_id from tree)",
+	"proc_bnds":    "proc_id in (select proc_id from procs)",
+	"proc_steps":   "proc_id in (select proc_id from procs)",
+	"proc_comps":   "proc_id in (select proc_id from procs)",
+	"proc_journal": "proc_id in (select proc_id from procs)",
+	"states":       "id in (select id from terms)",
+	"role_roots":   "role_id in (select role_id from roles)",
+	"role_states":  "role_id in (select role_id from roles)",
+	"role_subs":    "role_id in (select role_id from roles)",
+	"sig_roots":    "sig_id in (select sig_id from sigs)",
+	"sig_pes":      "sig_id in (select sig_id from sigs)",
+	"sig_ces":      "sig_id in (select sig_id from sigs)",
+	"sig_subs":     "sig_id in (select sig_id from sigs)",
+	"aliases": `id in (
+		select pool_id from tree
+		union select role_id from roles
+		union select sig_id from sigs
+	)`,
+}
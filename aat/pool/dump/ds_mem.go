@@ -0,0 +1,31 @@
+package dump
+
+import (
+	"log/slog"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter, documents are made of storage rows the in-memory tables don't share
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	name := slog.String("name", "dumpDaoMem")
+	return &daoMem{l.With(name)}
+}
+
+func (r *daoMem) SelectTables(data.Source, id.ADT) (map[string][]Row, error) {
+	return nil, errMappingUnsupported(data.MappingMem)
+}
+
+func (r *daoMem) InsertTables(data.Source, map[string][]Row) error {
+	return errMappingUnsupported(data.MappingMem)
+}
+
+func errMappingUnsupported(got data.Mapping) error {
+	return fault.New(fault.ProtocolViolation, "dump unsupported by storage mapping: %v", got)
+}
@@ -0,0 +1,122 @@
+package dump
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoPgx struct {
+	log *slog.Logger
+}
+
+func newDaoPgx(l *slog.Logger) *daoPgx {
+	name := slog.String("name", "dumpDaoPgx")
+	return &daoPgx{l.With(name)}
+}
+
+// for compilation purposes
+func newRepo() Repo {
+	return &daoPgx{}
+}
+
+func (r *daoPgx) SelectTables(source data.Source, poolID id.ADT) (map[string][]Row, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	tables := make(map[string][]Row, len(exportScopes))
+	for table, scope := range exportScopes {
+		query := scopeCTE + `
+			select coalesce(jsonb_agg(to_jsonb(t)), '[]')
+			from ` + pgx.Identifier{table}.Sanitize() + ` t
+			where ` + scope
+		var dto []byte
+		err := ds.Conn.QueryRow(ds.Ctx, query, poolID.String()).Scan(&dto)
+		if err != nil {
+			r.log.Error("execution failed", slog.String("table", table))
+			return nil, err
+		}
+		var rows []Row
+		err = json.Unmarshal(dto, &rows)
+		if err != nil {
+			r.log.Error("unmarshalling failed", slog.String("table", table))
+			return nil, err
+		}
+		tables[table] = rows
+	}
+	return tables, nil
+}
+
+func (r *daoPgx) InsertTables(source data.Source, tables map[string][]Row) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	for table, rows := range tables {
+		if len(rows) == 0 {
+			continue
+		}
+		dto, err := json.Marshal(rows)
+		if err != nil {
+			r.log.Error("marshalling failed", slog.String("table", table))
+			return err
+		}
+		ident := pgx.Identifier{table}.Sanitize()
+		query := `
+			insert into ` + ident + `
+			select * from jsonb_populate_recordset(null::` + ident + `, $1)`
+		_, err = ds.Conn.Exec(ds.Ctx, query, dto)
+		if err != nil {
+			r.log.Error("execution failed", slog.String("table", table))
+			return err
+		}
+	}
+	return nil
+}
+
+// pool tree with everything it refers to
+const scopeCTE = `
+	with recursive tree as (
+		select pool_id from pool_roots where pool_id = $1
+		union
+		select sub.pool_id from pool_roots sub
+		join tree on sub.sup_pool_id = tree.pool_id
+	), procs as (
+		select distinct proc_id from pool_liabs
+		where pool_id in (select pool_id from tree)
+	), sigs as (
+		select sig_id from pool_caps
+		where pool_id in (select pool_id from tree)
+		union
+		select sig_id from pool_deps
+		where pool_id in (select pool_id from tree)
+	), sig_roles as (
+		select root.role_id from role_roots root
+		join aliases alias
+			on alias.id = root.role_id
+		join (
+			select role_fqn from sig_pes
+			where sig_id in (select sig_id from sigs)
+			union
+			select role_fqn from sig_ces
+			where sig_id in (select sig_id from sigs)
+		) chnl
+			on chnl.role_fqn = alias.sym
+	), terms as (
+		select id from states
+		where id in (
+			select state_id from proc_bnds
+			where proc_id in (select proc_id from procs)
+			union
+			select state_id from role_states
+			where role_id in (select role_id from sig_roles)
+		)
+		union
+		select st.id from states st
+		join terms on st.from_id = terms.id
+	), roles as (
+		select role_id from role_states
+		where state_id in (select id from terms)
+		union
+		select role_id from sig_roles
+	)`
@@ -0,0 +1,114 @@
+package dump
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	name := slog.String("name", "dumpDaoSql")
+	return &daoSql{l.With(name)}
+}
+
+// json columns stored as text, decoded so that documents match across mappings
+var jsonColumnsSql = map[string]bool{
+	"spec": true,
+}
+
+func (r *daoSql) SelectTables(source data.Source, poolID id.ADT) (map[string][]Row, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	tables := make(map[string][]Row, len(exportScopes))
+	for table, scope := range exportScopes {
+		query := scopeCTE + `
+			select * from ` + quoteSql(table) + `
+			where ` + scope
+		rows, err := ds.Conn.QueryContext(ds.Ctx, query, poolID.String())
+		if err != nil {
+			r.log.Error("execution failed", slog.String("table", table))
+			return nil, err
+		}
+		cols, err := rows.Columns()
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tables[table], err = data.CollectRowsSql(rows, func(rows *sql.Rows) (Row, error) {
+			vals := make([]any, len(cols))
+			ptrs := make([]any, len(cols))
+			for i := range vals {
+				ptrs[i] = &vals[i]
+			}
+			err := rows.Scan(ptrs...)
+			if err != nil {
+				return nil, err
+			}
+			row := make(Row, len(cols))
+			for i, col := range cols {
+				row[col] = vals[i]
+				text, ok := vals[i].(string)
+				if ok && jsonColumnsSql[col] {
+					var val any
+					err = json.Unmarshal([]byte(text), &val)
+					if err != nil {
+						return nil, err
+					}
+					row[col] = val
+				}
+			}
+			return row, nil
+		})
+		if err != nil {
+			r.log.Error("collection failed", slog.String("table", table))
+			return nil, err
+		}
+		if tables[table] == nil {
+			tables[table] = []Row{}
+		}
+	}
+	return tables, nil
+}
+
+func (r *daoSql) InsertTables(source data.Source, tables map[string][]Row) error {
+	ds := data.MustConform[data.SourceSql](source)
+	for table, rows := range tables {
+		for _, row := range rows {
+			cols := make([]string, 0, len(row))
+			args := make([]any, 0, len(row))
+			for col, val := range row {
+				if jsonColumnsSql[col] && val != nil {
+					text, err := json.Marshal(val)
+					if err != nil {
+						r.log.Error("marshalling failed", slog.String("table", table))
+						return err
+					}
+					val = string(text)
+				}
+				cols = append(cols, quoteSql(col))
+				args = append(args, val)
+			}
+			query := `
+				insert into ` + quoteSql(table) + ` (` + strings.Join(cols, ", ") + `)
+				values (` + strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") + `)`
+			_, err := ds.Conn.ExecContext(ds.Ctx, query, args...)
+			if err != nil {
+				r.log.Error("execution failed", slog.String("table", table))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func quoteSql(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
@@ -0,0 +1,85 @@
+package dump
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+
+	sqlitedb "orglang/orglang/db/sqlite"
+)
+
+func TestSelectTablesSqlFollowsSignatureRoles(t *testing.T) {
+	ctx := context.Background()
+	db := newSqliteStub(t)
+	poolID, sigID, roleID, otherID := id.New(), id.New(), id.New(), id.New()
+	stmts := []struct {
+		query string
+		args  []any
+	}{
+		{"insert into pool_roots (pool_id, title, rev) values (?, 'main', 1)", []any{poolID.String()}},
+		{"insert into pool_caps (pool_id, sig_id, rev) values (?, ?, 1)", []any{poolID.String(), sigID.String()}},
+		{"insert into sig_roots (sig_id, title, rev) values (?, 'sig', 1)", []any{sigID.String()}},
+		{"insert into sig_pes (sig_id, chnl_key, role_fqn) values (?, 'x', 'a.b')", []any{sigID.String()}},
+		{"insert into role_roots (role_id, title, rev) values (?, 'b', 1), (?, 'd', 1)", []any{roleID.String(), otherID.String()}},
+		{"insert into aliases (id, sym, kind, rev_from, rev_to) values (?, 'a.b', 1, 0, 9223372036854775807), (?, 'c.d', 1, 0, 9223372036854775807)", []any{roleID.String(), otherID.String()}},
+		{"insert into role_states (role_id, state_id) values (?, 's1'), (?, 's3')", []any{roleID.String(), otherID.String()}},
+		{"insert into states (id, from_id) values ('s1', null), ('s2', 's1'), ('s3', null)", nil},
+	}
+	for _, stmt := range stmts {
+		_, err := db.ExecContext(ctx, stmt.query, stmt.args...)
+		if err != nil {
+			t.Fatal(stmt.query, err)
+		}
+	}
+	r := newDaoSql(slog.Default())
+	tables, err := r.SelectTables(data.SourceSql{Ctx: ctx, Conn: db}, poolID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := column(tables["role_roots"], "role_id"); !slices.Equal(got, []string{roleID.String()}) {
+		t.Fatalf("want role %v, got %v", roleID, got)
+	}
+	if got := column(tables["states"], "id"); !slices.Equal(got, []string{"s1", "s2"}) {
+		t.Fatalf("want the role states, got %v", got)
+	}
+}
+
+func newSqliteStub(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	names, err := fs.Glob(sqlitedb.Migrations, "migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		text, err := fs.ReadFile(sqlitedb.Migrations, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.Exec(string(text))
+		if err != nil {
+			t.Fatal(name, err)
+		}
+	}
+	return db
+}
+
+func column(rows []Row, col string) []string {
+	var vals []string
+	for _, row := range rows {
+		vals = append(vals, row[col].(string))
+	}
+	slices.Sort(vals)
+	return vals
+}
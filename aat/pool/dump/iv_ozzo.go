@@ -0,0 +1,21 @@
+package dump
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"orglang/orglang/avt/id"
+)
+
+func (dto PoolIdentME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.PoolID, id.Required...),
+	)
+}
+
+func (dto DocME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.Version, validation.Required),
+		validation.Field(&dto.PoolID, id.Required...),
+		validation.Field(&dto.Tables, validation.Required),
+	)
+}
@@ -0,0 +1,15 @@
+package dump
+
+type PoolIdentME struct {
+	PoolID string `json:"pool_id" param:"id"`
+}
+
+type DocME struct {
+	Version int                         `json:"version"`
+	PoolID  string                      `json:"pool_id"`
+	Tables  map[string][]map[string]any `json:"tables"`
+}
+
+type DocRefME struct {
+	PoolID string `json:"pool_id"`
+}
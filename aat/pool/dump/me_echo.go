@@ -0,0 +1,70 @@
+package dump
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type handlerEcho struct {
+	api API
+	log *slog.Logger
+}
+
+func newHandlerEcho(a API, l *slog.Logger) *handlerEcho {
+	name := slog.String("name", "dumpHandlerEcho")
+	return &handlerEcho{a, l.With(name)}
+}
+
+func (h *handlerEcho) GetOne(c echo.Context) error {
+	var dto PoolIdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	doc, err := h.api.Export(c.Request().Context(), poolID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromDoc(doc))
+}
+
+func (h *handlerEcho) PostOne(c echo.Context) error {
+	var dto DocME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	doc, err := MsgToDoc(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	ref, err := h.api.Import(c.Request().Context(), doc)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, DocRefME{PoolID: ref.PoolID.String()})
+}
@@ -0,0 +1,33 @@
+package dump
+
+import (
+	"orglang/orglang/avt/id"
+)
+
+func MsgFromDoc(doc Doc) DocME {
+	tables := make(map[string][]map[string]any, len(doc.Tables))
+	for table, rows := range doc.Tables {
+		dtos := make([]map[string]any, 0, len(rows))
+		for _, row := range rows {
+			dtos = append(dtos, row)
+		}
+		tables[table] = dtos
+	}
+	return DocME{Version: doc.Version, PoolID: doc.PoolID.String(), Tables: tables}
+}
+
+func MsgToDoc(dto DocME) (Doc, error) {
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		return Doc{}, err
+	}
+	tables := make(map[string][]Row, len(dto.Tables))
+	for table, dtos := range dto.Tables {
+		rows := make([]Row, 0, len(dtos))
+		for _, dto := range dtos {
+			rows = append(rows, dto)
+		}
+		tables[table] = rows
+	}
+	return Doc{Version: dto.Version, PoolID: poolID, Tables: tables}, nil
+}
@@ -0,0 +1,105 @@
+package exec
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// moves fully closed processes out of the live tables
+// and prunes the superseded quota windows
+type archiver struct {
+	pools    Repo
+	operator data.Operator
+	policy   retention
+	log      *slog.Logger
+}
+
+type retention struct {
+	// how long closed processes stay live
+	Keep time.Duration
+	// pause between sweeps
+	Interval time.Duration
+	// processes per sweep
+	Batch int
+}
+
+func newArchiver(pools Repo, operator data.Operator, p *props, l *slog.Logger) *archiver {
+	name := slog.String("name", "poolArchiver")
+	policy := retention{
+		Keep:     p.Archive.Keep,
+		Interval: p.Archive.Interval,
+		Batch:    p.Archive.Batch,
+	}
+	return &archiver{pools, operator, policy, l.With(name)}
+}
+
+func (a *archiver) run(ctx context.Context) {
+	if a.policy.Interval <= 0 {
+		a.log.Info("archiving disabled")
+		return
+	}
+	ticker := time.NewTicker(a.policy.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := a.sweep(ctx)
+			if err != nil && ctx.Err() == nil {
+				a.log.Error("sweeping failed", slog.Any("reason", err))
+			}
+		}
+	}
+}
+
+func (a *archiver) sweep(ctx context.Context) (err error) {
+	var procIDs []id.ADT
+	err = a.operator.Implicit(ctx, func(ds data.Source) error {
+		procIDs, err = a.pools.SelectClosed(ds, time.Now().Add(-a.policy.Keep), a.policy.Batch)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, procID := range procIDs {
+		// one transaction per process keeps locks short
+		err = a.operator.Explicit(ctx, func(ds data.Source) error {
+			return a.pools.ArchiveProc(ds, procID)
+		})
+		if err != nil {
+			a.log.Error("archiving failed", slog.Any("procID", procID))
+			return err
+		}
+		metrics.Add("procs_archived", 1)
+	}
+	var dropped int64
+	err = a.operator.Explicit(ctx, func(ds data.Source) error {
+		dropped, err = a.pools.DeleteOrphans(ds)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	metrics.Add("states_dropped", dropped)
+	// quota windows only grow otherwise
+	var pruned int64
+	err = a.operator.Explicit(ctx, func(ds data.Source) error {
+		pruned, err = a.pools.DeleteCounters(ds)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	metrics.Add("counters_pruned", pruned)
+	a.log.Debug("sweeping succeeded",
+		slog.Int("archived", len(procIDs)),
+		slog.Int64("dropped", dropped),
+		slog.Int64("pruned", pruned),
+	)
+	return nil
+}
@@ -0,0 +1,111 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"testing"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+
+	procexec "orglang/orglang/aat/proc/exec"
+)
+
+func TestSelectClosedMemCountsFromCreation(t *testing.T) {
+	r := newDaoMem(slog.Default())
+	operator := data.NewOperatorMem()
+	ctx := context.Background()
+	procID := id.New()
+	err := operator.Explicit(ctx, func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		// closed without a single journal entry
+		data.InsertRows(ds, bndsMem,
+			bndRowMem{ProcID: procID.String(), ChnlPH: "x", ChnlID: id.New().String(), PoolRN: 1},
+			bndRowMem{ProcID: procID.String(), ChnlPH: "x", PoolRN: -2},
+		)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		before time.Time
+		want   int
+	}{
+		{"fresh", procID.Time().Add(-time.Hour), 0},
+		{"expired", procID.Time().Add(time.Hour), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []id.ADT
+			err := operator.Implicit(ctx, func(ds data.Source) (err error) {
+				got, err = r.SelectClosed(ds, tt.before, 10)
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want {
+				t.Fatalf("want %v processes, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArchiveCompletedExchangeMem(t *testing.T) {
+	r := newDaoMem(slog.Default())
+	operator := data.NewOperatorMem()
+	ctx := context.Background()
+	closerID, waiterID, chnlID := id.New(), id.New(), id.New()
+	err := operator.Explicit(ctx, func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		data.InsertRows(ds, bndsMem,
+			bndRowMem{ProcID: closerID.String(), ChnlPH: "x", ChnlID: chnlID.String(), PoolRN: 1},
+			bndRowMem{ProcID: waiterID.String(), ChnlPH: "y", ChnlID: chnlID.String(), PoolRN: 1},
+		)
+		// the close half step
+		data.InsertRows(ds, stepsMem, stepRowMem{Step: procexec.SemRecDS{
+			ID:  id.New().String(),
+			PID: sql.NullString{String: closerID.String(), Valid: true},
+			VID: sql.NullString{String: chnlID.String(), Valid: true},
+		}})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the wait completes the exchange
+	err = operator.Explicit(ctx, func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		data.InsertRows(ds, bndsMem,
+			bndRowMem{ProcID: closerID.String(), ChnlPH: "x", PoolRN: -2},
+			bndRowMem{ProcID: waiterID.String(), ChnlPH: "y", PoolRN: -2},
+		)
+		return r.DeleteStep(source, chnlID)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &archiver{r, operator, retention{Keep: -time.Hour, Batch: 10}, slog.Default()}
+	err = a.sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = operator.Implicit(ctx, func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		archived := data.Rows[archiveRowMem](ds, archiveMem)
+		if len(archived) != 2 {
+			t.Fatalf("want both sides archived, got %v", archived)
+		}
+		if live := data.Rows[bndRowMem](ds, bndsMem); len(live) != 0 {
+			t.Fatalf("want no live bindings, got %v", live)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
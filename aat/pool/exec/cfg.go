@@ -0,0 +1,49 @@
+package exec
+
+import (
+	"time"
+)
+
+type props struct {
+	Retry   retry   `mapstructure:"retry"`
+	Events  events  `mapstructure:"events"`
+	Poll    poll    `mapstructure:"poll"`
+	Quota   quota   `mapstructure:"quota"`
+	Archive archive `mapstructure:"archive"`
+}
+
+type retry struct {
+	Attempts int           `mapstructure:"attempts"`
+	MinDelay time.Duration `mapstructure:"min_delay"`
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+}
+
+type events struct {
+	// undelivered events per watcher before it gets dropped
+	Buffer int `mapstructure:"buffer"`
+}
+
+type poll struct {
+	Timeout time.Duration `mapstructure:"timeout"`
+	Aging   time.Duration `mapstructure:"aging"`
+	// how long a polled process stays with the poller without stepping
+	Lease time.Duration `mapstructure:"lease"`
+}
+
+// defaults for pools without overrides
+type quota struct {
+	LiveProcs int  `mapstructure:"live_procs"`
+	Spawns    rate `mapstructure:"spawns"`
+	Steps     rate `mapstructure:"steps"`
+}
+
+type rate struct {
+	Limit    int           `mapstructure:"limit"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+type archive struct {
+	Keep     time.Duration `mapstructure:"keep"`
+	Interval time.Duration `mapstructure:"interval"`
+	Batch    int           `mapstructure:"batch"`
+}
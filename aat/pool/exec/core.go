@@ -2,18 +2,24 @@ package exec
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"golang.org/x/exp/maps"
 
 	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/outbox"
 	"orglang/orglang/avt/pol"
 	"orglang/orglang/avt/rn"
 	"orglang/orglang/avt/sym"
 
 	pooldef "orglang/orglang/aat/pool/def"
+	poolledger "orglang/orglang/aat/pool/ledger"
 	procdec "orglang/orglang/aat/proc/dec"
 	procdef "orglang/orglang/aat/proc/def"
 	procexec "orglang/orglang/aat/proc/exec"
@@ -22,12 +28,17 @@ import (
 
 // Port
 type API interface {
-	Create(PoolSpec) (PoolRef, error)
-	Retrieve(id.ADT) (PoolSnap, error)
-	RetreiveRefs() ([]PoolRef, error)
-	Spawn(procexec.ProcSpec) (procexec.ProcRef, error)
-	Take(StepSpec) error
-	Poll(PollSpec) (procexec.ProcRef, error)
+	Create(context.Context, PoolSpec) (PoolRef, error)
+	Retrieve(context.Context, id.ADT) (PoolSnap, error)
+	RetreiveRefs(context.Context) ([]PoolRef, error)
+	Spawn(context.Context, procexec.ProcSpec) (procexec.ProcRef, error)
+	Take(context.Context, StepSpec) error
+	Poll(context.Context, PollSpec) (procexec.ProcRef, error)
+	Watch(context.Context, WatchSpec) (Subscription, error)
+	Cancel(context.Context, CancelSpec) error
+	RetrieveJournal(context.Context, id.ADT) ([]JournalEntry, error)
+	SetQuota(context.Context, QuotaSpec) error
+	RetrieveQuota(context.Context, id.ADT) (Quota, error)
 }
 
 type PoolSpec struct {
@@ -57,6 +68,33 @@ type StepSpec struct {
 	PoolID id.ADT
 	ProcID id.ADT
 	ProcTS procdef.TermSpec
+	// optional, makes replays return the original result
+	IdemKey string
+	// optional, undoes the step on cancelation or failure
+	CompTS procdef.TermSpec
+	// optional, the agent credited in the ledger
+	AgentQN sym.ADT
+	// optional, commits or rolls back together with the step
+	TxHook func(data.Source) error
+}
+
+type IdemKind int8
+
+const (
+	nonidem IdemKind = iota
+	StepIdem
+	SpawnIdem
+	CancelIdem
+)
+
+// remembered outcome of a keyed request
+type IdemRec struct {
+	PoolID  id.ADT
+	IdemKey string
+	Kind    IdemKind
+	// stepping or spawned process
+	ProcID id.ADT
+	PoolRN rn.ADT
 }
 
 type PollSpec struct {
@@ -69,9 +107,33 @@ type service struct {
 	procs    procdec.Repo
 	types    typedef.Repo
 	operator data.Operator
-	log      *slog.Logger
+	listener data.Listener
+	retry    retryPolicy
+	events   *eventHub
+	ledger   poolledger.Writer
+	outbox   outbox.Writer
+	replay   outbox.Reader
+	quota    Quota
+	shares   *fairShare
+	// upper bound for long polling
+	pollTimeout time.Duration
+	// waiting time per priority level
+	aging time.Duration
+	lease time.Duration
+	log   *slog.Logger
+}
+
+// bounded exponential backoff
+type retryPolicy struct {
+	Attempts int
+	MinDelay time.Duration
+	MaxDelay time.Duration
 }
 
+var metrics = expvar.NewMap("aat/pool/exec")
+
+const defaultLease = time.Minute
+
 // for compilation purposes
 func newAPI() API {
 	return &service{}
@@ -82,13 +144,32 @@ func newService(
 	procs procdec.Repo,
 	types typedef.Repo,
 	operator data.Operator,
+	listener data.Listener,
+	events *eventHub,
+	ledger poolledger.Writer,
+	outbox outbox.Writer,
+	replay outbox.Reader,
+	p *props,
 	l *slog.Logger,
 ) *service {
-	return &service{pools, procs, types, operator, l}
+	retry := retryPolicy{
+		Attempts: p.Retry.Attempts,
+		MinDelay: p.Retry.MinDelay,
+		MaxDelay: p.Retry.MaxDelay,
+	}
+	quota := Quota{
+		LiveProcs: p.Quota.LiveProcs,
+		Spawns:    Rate(p.Quota.Spawns),
+		Steps:     Rate(p.Quota.Steps),
+	}
+	lease := p.Poll.Lease
+	if lease <= 0 {
+		lease = defaultLease
+	}
+	return &service{pools, procs, types, operator, listener, retry, events, ledger, outbox, replay, quota, newFairShare(), p.Poll.Timeout, p.Poll.Aging, lease, l}
 }
 
-func (s *service) Create(spec PoolSpec) (PoolRef, error) {
-	ctx := context.Background()
+func (s *service) Create(ctx context.Context, spec PoolSpec) (PoolRef, error) {
 	s.log.Debug("creation started", slog.Any("spec", spec))
 	impl := PoolRec{
 		ExecID: id.New(),
@@ -122,105 +203,461 @@ func (s *service) Create(spec PoolSpec) (PoolRef, error) {
 	return ConvertRecToRef(impl), nil
 }
 
-func (s *service) Poll(spec PollSpec) (procexec.ProcRef, error) {
-	switch spec.PoolTS.(type) {
-	default:
-		return procexec.ProcRef{}, nil
+// waits for pending steps of the pool until the poll timeout elapses
+func (s *service) Poll(ctx context.Context, spec PollSpec) (procexec.ProcRef, error) {
+	idAttr := slog.Any("poolID", spec.PoolID)
+	s.log.Debug("polling started", idAttr)
+	ctx, cancel := context.WithTimeout(ctx, s.pollTimeout)
+	defer cancel()
+	// subscribe before looking so that no commit slips in between
+	wakeup, err := s.listener.Listen(ctx, stepsChannel(spec.PoolID))
+	if err != nil {
+		s.log.Error("polling failed", idAttr)
+		return procexec.ProcRef{}, err
+	}
+	defer wakeup.Cancel()
+	for {
+		var pendings []Pending
+		err = s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) error {
+			pendings, err = s.pools.SelectPending(ds, spec.PoolID, time.Now())
+			return err
+		})
+		if err != nil {
+			s.log.Error("polling failed", idAttr)
+			return procexec.ProcRef{}, err
+		}
+		next, ok, err := s.claimNext(ctx, spec.PoolID, pendings)
+		if err != nil {
+			s.log.Error("polling failed", idAttr)
+			return procexec.ProcRef{}, err
+		}
+		if ok {
+			s.log.Debug("polling succeeded", idAttr, slog.Any("procID", next.ProcID), slog.Int("priority", next.Priority))
+			return procexec.ProcRef{ExecID: next.ProcID}, nil
+		}
+		select {
+		case <-wakeup.C:
+			metrics.Add("poll_wakeups", 1)
+		case <-ctx.Done():
+			s.log.Debug("polling timed out", idAttr)
+			return procexec.ProcRef{}, nil
+		}
 	}
-	return procexec.ProcRef{}, nil
 }
 
-func (s *service) Spawn(spec procexec.ProcSpec) (_ procexec.ProcRef, err error) {
+func (s *service) Watch(ctx context.Context, spec WatchSpec) (Subscription, error) {
+	idAttr := slog.Any("poolID", spec.PoolID)
+	s.log.Debug("watching started", idAttr, slog.Int64("seq", spec.Seq))
+	// subscribing first leaves no gap between the replay and the live events
+	live := s.events.Subscribe(spec.PoolID)
+	if spec.Seq == 0 {
+		return live, nil
+	}
+	recs, err := s.replay.Replay(ctx, outbox.ReplaySpec{StreamID: spec.PoolID, Seq: spec.Seq})
+	if err != nil {
+		live.Cancel()
+		s.log.Error("watching failed", idAttr)
+		return Subscription{}, err
+	}
+	replay := make([]Event, 0, len(recs))
+	for _, rec := range recs {
+		ev, err := eventFromOutbox(rec)
+		if err != nil {
+			live.Cancel()
+			s.log.Error("watching failed", idAttr, slog.Any("eventID", rec.ID))
+			return Subscription{}, err
+		}
+		replay = append(replay, ev)
+	}
+	return resume(live, replay, spec.Seq), nil
+}
+
+func (s *service) Spawn(ctx context.Context, spec procexec.ProcSpec) (ref procexec.ProcRef, err error) {
+	if spec.ExecID.IsEmpty() {
+		spec.ExecID = id.New()
+	}
 	procAttr := slog.Any("procID", spec.ExecID)
 	s.log.Debug("spawning started", procAttr)
-	return procexec.ProcRef{}, nil
+	err = s.retrying(ctx, procAttr, func() error {
+		ref, err = s.spawnOnce(ctx, spec)
+		return err
+	})
+	if err != nil {
+		s.log.Error("spawning failed", procAttr)
+		return procexec.ProcRef{}, err
+	}
+	s.log.Debug("spawning succeeded", procAttr)
+	return ref, nil
 }
 
-func (s *service) Take(spec StepSpec) (err error) {
-	idAttr := slog.Any("procID", spec.ProcID)
-	s.log.Debug("taking started", idAttr)
-	ctx := context.Background()
-	// initial values
-	poolID := spec.PoolID
-	procID := spec.ProcID
-	termSpec := spec.ProcTS
-	for termSpec != nil {
-		var procCfg procexec.Cfg
-		err = s.operator.Implicit(ctx, func(ds data.Source) error {
-			procCfg, err = s.pools.SelectProc(ds, procID)
+func (s *service) spawnOnce(ctx context.Context, spec procexec.ProcSpec) (procexec.ProcRef, error) {
+	if spec.IdemKey != "" {
+		rec, found, err := s.replayed(ctx, spec.PoolID, spec.IdemKey, SpawnIdem)
+		if err != nil {
+			return procexec.ProcRef{}, err
+		}
+		if found {
+			return procexec.ProcRef{ExecID: rec.ProcID}, nil
+		}
+	}
+	var poolRec PoolRec
+	err := s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) (err error) {
+		poolRec, err = s.pools.SelectRec(ds, spec.PoolID)
+		return err
+	})
+	if err != nil {
+		return procexec.ProcRef{}, err
+	}
+	liab := procexec.Liab{
+		PoolID:   spec.PoolID,
+		ProcID:   spec.ExecID,
+		PoolRN:   poolRec.PoolRN.Next(),
+		Priority: spec.Priority,
+	}
+	procMod := procexec.Mod{
+		Locks: []procexec.Lock{{PoolID: spec.PoolID, PoolRN: poolRec.PoolRN}},
+		Liabs: []procexec.Liab{liab},
+	}
+	event := Event{PoolID: liab.PoolID, ProcID: liab.ProcID, Kind: LiabChanged, PoolRN: liab.PoolRN}
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
+		quota, err := s.selectQuota(ds, spec.PoolID)
+		if err != nil {
 			return err
-		})
+		}
+		err = s.enforceLive(ds, spec.PoolID, quota.LiveProcs)
 		if err != nil {
-			s.log.Error("taking failed", idAttr)
 			return err
 		}
-		if len(procCfg.Chnls) == 0 {
-			panic("zero channels")
+		err = s.enforceRate(ds, spec.PoolID, SpawnCounter, quota.Spawns)
+		if err != nil {
+			return err
 		}
-		sigIDs := procdef.CollectEnv(termSpec)
-		var sigs map[id.ADT]procdec.ProcRec
-		err = s.operator.Implicit(ctx, func(ds data.Source) error {
-			sigs, err = s.procs.SelectEnv(ds, sigIDs)
+		err = s.pools.UpdateProc(ds, procMod)
+		if err != nil {
 			return err
+		}
+		written, err := s.outbox.Write(ds, outbox.EventSpec{
+			Kind:      ProcSpawned,
+			SubjectID: spec.ExecID,
+			StreamID:  event.PoolID,
+			Payload:   MsgFromEvent(event),
 		})
 		if err != nil {
-			s.log.Error("taking failed", idAttr, slog.Any("sigs", sigIDs))
 			return err
 		}
-		typeQNs := procdec.CollectEnv(maps.Values(sigs))
-		var types map[sym.ADT]typedef.TypeRec
-		err = s.operator.Implicit(ctx, func(ds data.Source) error {
-			types, err = s.types.SelectTypeEnv(ds, typeQNs)
-			return err
+		event.Seq = written.Seq
+		if spec.IdemKey == "" {
+			return nil
+		}
+		return s.pools.InsertIdem(ds, IdemRec{
+			PoolID:  spec.PoolID,
+			IdemKey: spec.IdemKey,
+			Kind:    SpawnIdem,
+			ProcID:  spec.ExecID,
+			PoolRN:  liab.PoolRN,
 		})
+	})
+	if err != nil {
+		return procexec.ProcRef{}, err
+	}
+	s.events.Publish(event)
+	return procexec.ProcRef{ExecID: spec.ExecID}, nil
+}
+
+// looks up the outcome of an already committed request with the same key
+func (s *service) replayed(ctx context.Context, poolID id.ADT, key string, kind IdemKind) (IdemRec, bool, error) {
+	var rec IdemRec
+	err := s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) (err error) {
+		rec, err = s.pools.SelectIdem(ds, poolID, key)
+		return err
+	})
+	if fault.Is(err, fault.NotFound) {
+		return IdemRec{}, false, nil
+	}
+	if err != nil {
+		return IdemRec{}, false, err
+	}
+	if rec.Kind != kind {
+		return IdemRec{}, false, errIdemKeyReused(key)
+	}
+	metrics.Add("idem_replays", 1)
+	s.log.Debug("replay detected", slog.String("idemKey", key), slog.Any("procID", rec.ProcID))
+	return rec, true, nil
+}
+
+func (s *service) Take(ctx context.Context, spec StepSpec) (err error) {
+	idAttr := slog.Any("procID", spec.ProcID)
+	s.log.Debug("taking started", idAttr)
+	err = s.take(ctx, spec)
+	if fault.Is(err, fault.StateCorruption) {
+		s.fail(ctx, spec, err)
+	}
+	if err != nil {
+		s.log.Error("taking failed", idAttr)
+		return err
+	}
+	s.log.Debug("taking succeeded", idAttr)
+	return nil
+}
+
+func (s *service) take(ctx context.Context, spec StepSpec) (err error) {
+	for spec.ProcTS != nil {
+		// every step commits on its own, an abandoned request stops in between
+		err = ctx.Err()
 		if err != nil {
-			s.log.Error("taking failed", idAttr, slog.Any("types", typeQNs))
 			return err
 		}
-		envIDs := typedef.CollectEnv(maps.Values(types))
-		ctxIDs := CollectCtx(maps.Values(procCfg.Chnls))
-		var terms map[id.ADT]typedef.TermRec
-		err = s.operator.Implicit(ctx, func(ds data.Source) error {
-			terms, err = s.types.SelectTermEnv(ds, append(envIDs, ctxIDs...))
+		spec, err = s.takeRetrying(ctx, spec)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retries the step while it conflicts with concurrent steps
+func (s *service) takeRetrying(ctx context.Context, spec StepSpec) (nextSpec StepSpec, err error) {
+	idAttr := slog.Any("procID", spec.ProcID)
+	err = s.retrying(ctx, idAttr, func() error {
+		nextSpec, err = s.takeOnce(ctx, spec)
+		return err
+	})
+	return nextSpec, err
+}
+
+// repeats the operation on lock conflicts with bounded backoff,
+// serialization failures get retried by the operator within each attempt,
+// so the transactions multiply up to retry.Attempts × tx retry attempts
+func (s *service) retrying(ctx context.Context, idAttr slog.Attr, op func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if !fault.Is(err, fault.ConcurrentModification) {
+			return err
+		}
+		metrics.Add("take_conflicts", 1)
+		if attempt >= s.retry.Attempts {
+			metrics.Add("take_retries_exhausted", 1)
+			s.log.Error("retrying exhausted", idAttr, slog.Int("attempt", attempt))
+			return err
+		}
+		delay := data.Backoff(attempt, s.retry.MinDelay, s.retry.MaxDelay)
+		s.log.Warn("lock conflict", idAttr, slog.Int("attempt", attempt), slog.Duration("delay", delay))
+		metrics.Add("take_retries", 1)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// concurrent pollers race for the same candidates, the losers move on to the next ones
+func (s *service) claimNext(ctx context.Context, poolID id.ADT, pendings []Pending) (Pending, bool, error) {
+	for len(pendings) > 0 {
+		next, ok := s.shares.pick(poolID, pendings, s.aging)
+		if !ok {
+			break
+		}
+		now := time.Now()
+		claim := PollClaim{ProcID: next.ProcID, ClaimedAt: now, Until: now.Add(s.lease)}
+		var claimed bool
+		err := s.operator.Explicit(ctx, func(ds data.Source) (err error) {
+			claimed, err = s.pools.InsertClaim(ds, claim)
 			return err
 		})
 		if err != nil {
-			s.log.Error("taking failed", idAttr, slog.Any("env", envIDs), slog.Any("ctx", ctxIDs))
+			return Pending{}, false, err
+		}
+		if claimed {
+			return next, true, nil
+		}
+		metrics.Add("poll_claim_conflicts", 1)
+		pendings = slices.DeleteFunc(pendings, func(p Pending) bool { return p.ProcID == next.ProcID })
+	}
+	return Pending{}, false, nil
+}
+
+// reloads the configuration and takes a single step against it
+func (s *service) takeOnce(ctx context.Context, spec StepSpec) (_ StepSpec, err error) {
+	idAttr := slog.Any("procID", spec.ProcID)
+	poolID := spec.PoolID
+	procID := spec.ProcID
+	termSpec := spec.ProcTS
+	// only the step submitted by the client carries the key
+	if spec.IdemKey != "" {
+		rec, found, err := s.replayed(ctx, poolID, spec.IdemKey, StepIdem)
+		if err != nil {
+			return StepSpec{}, err
+		}
+		if found && rec.ProcID != procID {
+			return StepSpec{}, errIdemKeyReused(spec.IdemKey)
+		}
+		if found {
+			return StepSpec{}, nil
+		}
+	}
+	var procCfg procexec.Cfg
+	// the previous step may not have reached the replica yet,
+	// the rest of the read set must not lag behind its channels either
+	primaryCtx := data.WithPrimary(ctx)
+	err = s.operator.Implicit(primaryCtx, func(ds data.Source) error {
+		procCfg, err = s.pools.SelectProc(ds, procID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("taking failed", idAttr)
+		return StepSpec{}, err
+	}
+	// the client names the pool, the liability decides
+	if len(procCfg.Chnls) == 0 || procCfg.PoolID != poolID {
+		err = errMissingProc(procID)
+		s.log.Error("taking failed", idAttr, slog.Any("poolID", poolID))
+		return StepSpec{}, err
+	}
+	sigIDs := procdef.CollectEnv(termSpec)
+	var sigs map[id.ADT]procdec.ProcRec
+	err = s.operator.Implicit(primaryCtx, func(ds data.Source) error {
+		sigs, err = s.procs.SelectEnv(ds, sigIDs)
+		return err
+	})
+	if err != nil {
+		s.log.Error("taking failed", idAttr, slog.Any("sigs", sigIDs))
+		return StepSpec{}, err
+	}
+	typeQNs := procdec.CollectEnv(maps.Values(sigs))
+	var types map[sym.ADT]typedef.TypeRec
+	err = s.operator.Implicit(primaryCtx, func(ds data.Source) error {
+		types, err = s.types.SelectTypeEnv(ds, typeQNs)
+		return err
+	})
+	if err != nil {
+		s.log.Error("taking failed", idAttr, slog.Any("types", typeQNs))
+		return StepSpec{}, err
+	}
+	envIDs := typedef.CollectEnv(maps.Values(types))
+	ctxIDs := CollectCtx(maps.Values(procCfg.Chnls))
+	var terms map[id.ADT]typedef.TermRec
+	err = s.operator.Implicit(primaryCtx, func(ds data.Source) error {
+		terms, err = s.types.SelectTermEnv(ds, append(envIDs, ctxIDs...))
+		return err
+	})
+	if err != nil {
+		s.log.Error("taking failed", idAttr, slog.Any("env", envIDs), slog.Any("ctx", ctxIDs))
+		return StepSpec{}, err
+	}
+	procEnv := procexec.Env{ProcSigs: sigs, Types: types, TypeTerms: terms}
+	procCtx := convertToCtx(poolID, maps.Values(procCfg.Chnls), terms)
+	// type checking
+	err = s.checkState(poolID, procEnv, procCtx, procCfg, termSpec)
+	if err != nil {
+		s.log.Error("taking failed", idAttr)
+		return StepSpec{}, fault.Classify(fault.ProtocolViolation, err)
+	}
+	// step taking
+	nextSpec, procMod, err := s.takeWith(procEnv, procCfg, termSpec)
+	if err != nil {
+		s.log.Error("taking failed", idAttr)
+		return StepSpec{}, fault.Classify(fault.ProtocolViolation, err)
+	}
+	events := collectEvents(spec, procCfg, procMod)
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
+		quota, err := s.selectQuota(ds, poolID)
+		if err != nil {
 			return err
 		}
-		procEnv := procexec.Env{ProcSigs: sigs, Types: types, TypeTerms: terms}
-		procCtx := convertToCtx(poolID, maps.Values(procCfg.Chnls), terms)
-		// type checking
-		err = s.checkState(poolID, procEnv, procCtx, procCfg, termSpec)
+		err = s.enforceRate(ds, poolID, StepCounter, quota.Steps)
 		if err != nil {
-			s.log.Error("taking failed", idAttr)
+			s.log.Warn("taking rejected", idAttr)
 			return err
 		}
-		// step taking
-		nextSpec, procMod, err := s.takeWith(procEnv, procCfg, termSpec)
+		err = s.pools.UpdateProc(ds, procMod)
 		if err != nil {
 			s.log.Error("taking failed", idAttr)
 			return err
 		}
-		err = s.operator.Explicit(ctx, func(ds data.Source) error {
-			err = s.pools.UpdateProc(ds, procMod)
+		// the poller is done with the process
+		err = s.pools.DeleteClaim(ds, procID)
+		if err != nil {
+			return err
+		}
+		consumedID, ok := consumedStep(spec, procCfg, procMod)
+		if ok {
+			err = s.pools.DeleteStep(ds, consumedID)
 			if err != nil {
-				s.log.Error("taking failed", idAttr)
 				return err
 			}
-			return nil
+		}
+		err = s.pools.InsertJournal(ds, JournalEntry{
+			PoolID: poolID,
+			ProcID: procID,
+			Kind:   StepJournaled,
+			PoolRN: procCfg.PoolRN.Next(),
+			Detail: fmt.Sprintf("%T", termSpec),
+			At:     time.Now(),
 		})
 		if err != nil {
-			s.log.Error("taking failed", idAttr)
 			return err
 		}
-		// next values
-		poolID = nextSpec.PoolID
-		procID = nextSpec.ProcID
-		termSpec = nextSpec.ProcTS
+		if spec.CompTS != nil {
+			err = s.pools.InsertComp(ds, CompRec{
+				PoolID: poolID,
+				ProcID: procID,
+				PoolRN: procCfg.PoolRN.Next(),
+				CompTS: spec.CompTS,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(procMod.Steps) == 0 {
+			err = s.ledger.Write(ds, ledgerEntry(spec, procCfg))
+			if err != nil {
+				return err
+			}
+		}
+		for i, ev := range events {
+			written, err := s.outbox.Write(ds, outboxSpec(ev))
+			if err != nil {
+				return err
+			}
+			events[i].Seq = written.Seq
+		}
+		if spec.TxHook != nil {
+			err = spec.TxHook(ds)
+			if err != nil {
+				return err
+			}
+		}
+		if spec.IdemKey == "" {
+			return nil
+		}
+		return s.pools.InsertIdem(ds, IdemRec{
+			PoolID:  poolID,
+			IdemKey: spec.IdemKey,
+			Kind:    StepIdem,
+			ProcID:  procID,
+			PoolRN:  procCfg.PoolRN.Next(),
+		})
+	})
+	if err != nil {
+		s.log.Error("taking failed", idAttr)
+		return StepSpec{}, err
 	}
-	s.log.Debug("taking succeeded", idAttr)
-	return nil
+	s.events.Publish(events...)
+	return nextSpec, nil
+}
+
+// the pending half on the channel, unless the step is a half itself
+func consumedStep(spec StepSpec, procCfg procexec.Cfg, procMod procexec.Mod) (id.ADT, bool) {
+	if len(procMod.Steps) > 0 {
+		return id.Empty(), false
+	}
+	viaChnl, ok := procCfg.Chnls[spec.ProcTS.Via()]
+	if !ok {
+		return id.Empty(), false
+	}
+	_, ok = procCfg.Steps[viaChnl.ChnlID]
+	return viaChnl.ChnlID, ok
 }
 
 func (s *service) takeWith(
@@ -263,7 +700,9 @@ func (s *service) takeWith(
 		}
 		svcStep, ok := rcvrStep.(procexec.SvcRec)
 		if !ok {
-			panic(procexec.ErrRootTypeUnexpected(rcvrStep))
+			err := errStepTypeUnexpected(rcvrStep)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 		switch termImpl := svcStep.Cont.(type) {
 		case procdef.WaitRec:
@@ -287,7 +726,9 @@ func (s *service) takeWith(
 			s.log.Debug("taking succeeded", viaAttr)
 			return tranSpec, procMod, nil
 		default:
-			panic(procdef.ErrRecTypeUnexpected(svcStep.Cont))
+			err := errRecTypeUnexpected(svcStep.Cont)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 	case procdef.WaitSpec:
 		viaChnl, ok := procCfg.Chnls[termSpec.CommPH]
@@ -320,7 +761,9 @@ func (s *service) takeWith(
 		}
 		msgStep, ok := sndrStep.(procexec.MsgRec)
 		if !ok {
-			panic(procexec.ErrRootTypeUnexpected(sndrStep))
+			err := errStepTypeUnexpected(sndrStep)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 		switch termImpl := msgStep.Val.(type) {
 		case procdef.CloseRec:
@@ -360,7 +803,9 @@ func (s *service) takeWith(
 			s.log.Debug("taking succeeded", viaAttr)
 			return tranSpec, procMod, nil
 		default:
-			panic(procdef.ErrRecTypeUnexpected(msgStep.Val))
+			err := errRecTypeUnexpected(msgStep.Val)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 	case procdef.SendSpec:
 		viaChnl, ok := procCfg.Chnls[termSpec.CommPH]
@@ -377,7 +822,7 @@ func (s *service) takeWith(
 		procMod.Locks = append(procMod.Locks, sndrLock)
 		viaState, ok := procEnv.TypeTerms[viaChnl.TermID]
 		if !ok {
-			err := typedef.ErrMissingInEnv(viaChnl.TermID)
+			err := errMissingState(viaChnl.TermID)
 			s.log.Error("taking failed", viaAttr)
 			return StepSpec{}, procexec.Mod{}, err
 		}
@@ -423,7 +868,9 @@ func (s *service) takeWith(
 		}
 		svcStep, ok := rcvrStep.(procexec.SvcRec)
 		if !ok {
-			panic(procexec.ErrRootTypeUnexpected(rcvrStep))
+			err := errStepTypeUnexpected(rcvrStep)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 		switch termImpl := svcStep.Cont.(type) {
 		case procdef.RecvRec:
@@ -459,7 +906,9 @@ func (s *service) takeWith(
 			s.log.Debug("taking succeeded", viaAttr)
 			return tranSpec, procMod, nil
 		default:
-			panic(procdef.ErrRecTypeUnexpected(svcStep.Cont))
+			err := errRecTypeUnexpected(svcStep.Cont)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 	case procdef.RecvSpec:
 		viaChnl, ok := procCfg.Chnls[termSpec.CommPH]
@@ -494,13 +943,15 @@ func (s *service) takeWith(
 		}
 		sndrMsgRec, ok := sndrSemRec.(procexec.MsgRec)
 		if !ok {
-			panic(procexec.ErrRootTypeUnexpected(sndrSemRec))
+			err := errStepTypeUnexpected(sndrSemRec)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 		switch termRec := sndrMsgRec.Val.(type) {
 		case procdef.SendRec:
 			viaState, ok := procEnv.TypeTerms[viaChnl.TermID]
 			if !ok {
-				err := typedef.ErrMissingInEnv(viaChnl.TermID)
+				err := errMissingState(viaChnl.TermID)
 				s.log.Error("taking failed", viaAttr)
 				return StepSpec{}, procexec.Mod{}, err
 			}
@@ -528,7 +979,9 @@ func (s *service) takeWith(
 			s.log.Debug("taking succeeded", viaAttr)
 			return tranSpec, procMod, nil
 		default:
-			panic(procdef.ErrRecTypeUnexpected(sndrMsgRec.Val))
+			err := errRecTypeUnexpected(sndrMsgRec.Val)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 	case procdef.LabSpec:
 		viaChnl, ok := procCfg.Chnls[termSpec.CommPH]
@@ -545,7 +998,7 @@ func (s *service) takeWith(
 		procMod.Locks = append(procMod.Locks, sndrLock)
 		viaState, ok := procEnv.TypeTerms[viaChnl.TermID]
 		if !ok {
-			err := typedef.ErrMissingInEnv(viaChnl.TermID)
+			err := errMissingState(viaChnl.TermID)
 			s.log.Error("taking failed", viaAttr)
 			return StepSpec{}, procexec.Mod{}, err
 		}
@@ -578,7 +1031,9 @@ func (s *service) takeWith(
 		}
 		svcStep, ok := rcvrStep.(procexec.SvcRec)
 		if !ok {
-			panic(procexec.ErrRootTypeUnexpected(rcvrStep))
+			err := errStepTypeUnexpected(rcvrStep)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 		switch termImpl := svcStep.Cont.(type) {
 		case procdef.CaseRec:
@@ -606,7 +1061,9 @@ func (s *service) takeWith(
 			s.log.Debug("taking succeeded", viaAttr)
 			return tranSpec, procMod, nil
 		default:
-			panic(procdef.ErrRecTypeUnexpected(svcStep.Cont))
+			err := errRecTypeUnexpected(svcStep.Cont)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 	case procdef.CaseSpec:
 		viaChnl, ok := procCfg.Chnls[termSpec.CommPH]
@@ -640,13 +1097,15 @@ func (s *service) takeWith(
 		}
 		msgStep, ok := sndrStep.(procexec.MsgRec)
 		if !ok {
-			panic(procexec.ErrRootTypeUnexpected(sndrStep))
+			err := errStepTypeUnexpected(sndrStep)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 		switch termImpl := msgStep.Val.(type) {
 		case procdef.LabRec:
 			viaState, ok := procEnv.TypeTerms[viaChnl.TermID]
 			if !ok {
-				err := typedef.ErrMissingInEnv(viaChnl.TermID)
+				err := errMissingState(viaChnl.TermID)
 				s.log.Error("taking failed", viaAttr)
 				return StepSpec{}, procexec.Mod{}, err
 			}
@@ -666,7 +1125,9 @@ func (s *service) takeWith(
 			s.log.Debug("taking succeeded", viaAttr)
 			return tranSpec, procMod, nil
 		default:
-			panic(procdef.ErrRecTypeUnexpected(msgStep.Val))
+			err := errRecTypeUnexpected(msgStep.Val)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 	case procdef.SpawnSpecOld:
 		rcvrSnap, ok := procEnv.Locks[termSpec.PoolQN]
@@ -676,9 +1137,10 @@ func (s *service) takeWith(
 			return StepSpec{}, procexec.Mod{}, err
 		}
 		rcvrLiab := procexec.Liab{
-			ProcID: id.New(),
-			PoolID: rcvrSnap.PoolID,
-			PoolRN: rcvrSnap.PoolRN.Next(),
+			ProcID:   id.New(),
+			PoolID:   rcvrSnap.PoolID,
+			PoolRN:   rcvrSnap.PoolRN.Next(),
+			Priority: procCfg.Priority,
 		}
 		procMod.Liabs = append(procMod.Liabs, rcvrLiab)
 		rcvrSig, ok := procEnv.ProcSigs[termSpec.SigID]
@@ -749,7 +1211,7 @@ func (s *service) takeWith(
 		viaAttr := slog.Any("chnlID", viaChnl.ChnlID)
 		viaState, ok := procEnv.TypeTerms[viaChnl.TermID]
 		if !ok {
-			err := typedef.ErrMissingInEnv(viaChnl.TermID)
+			err := errMissingState(viaChnl.TermID)
 			s.log.Error("taking failed", viaAttr)
 			return StepSpec{}, procexec.Mod{}, err
 		}
@@ -821,7 +1283,9 @@ func (s *service) takeWith(
 				s.log.Debug("taking half done", viaAttr)
 				return tranSpec, procMod, nil
 			default:
-				panic(procexec.ErrRootTypeUnexpected(vs))
+				err := errStepTypeUnexpected(vs)
+				s.log.Error("taking failed")
+				return StepSpec{}, procexec.Mod{}, err
 			}
 		case pol.Neg:
 			switch viaStep := vs.(type) {
@@ -871,18 +1335,23 @@ func (s *service) takeWith(
 				s.log.Debug("taking half done", viaAttr)
 				return tranSpec, procMod, nil
 			default:
-				panic(procexec.ErrRootTypeUnexpected(vs))
+				err := errStepTypeUnexpected(vs)
+				s.log.Error("taking failed")
+				return StepSpec{}, procexec.Mod{}, err
 			}
 		default:
-			panic(typedef.ErrPolarityUnexpected(viaState))
+			err := errPolarityUnexpected(viaState)
+			s.log.Error("taking failed")
+			return StepSpec{}, procexec.Mod{}, err
 		}
 	default:
-		panic(procdef.ErrTermTypeUnexpected(ts))
+		err := errTermTypeUnexpected(ts)
+		s.log.Error("taking failed")
+		return StepSpec{}, procexec.Mod{}, err
 	}
 }
 
-func (s *service) Retrieve(poolID id.ADT) (snap PoolSnap, err error) {
-	ctx := context.Background()
+func (s *service) Retrieve(ctx context.Context, poolID id.ADT) (snap PoolSnap, err error) {
 	err = s.operator.Implicit(ctx, func(ds data.Source) error {
 		snap, err = s.pools.SelectSubs(ds, poolID)
 		return err
@@ -894,8 +1363,7 @@ func (s *service) Retrieve(poolID id.ADT) (snap PoolSnap, err error) {
 	return snap, nil
 }
 
-func (s *service) RetreiveRefs() (refs []PoolRef, err error) {
-	ctx := context.Background()
+func (s *service) RetreiveRefs(ctx context.Context) (refs []PoolRef, err error) {
 	err = s.operator.Implicit(ctx, func(ds data.Source) error {
 		refs, err = s.pools.SelectRefs(ds)
 		return err
@@ -931,9 +1399,17 @@ func (s *service) checkState(
 	procCfg procexec.Cfg,
 	termSpec procdef.TermSpec,
 ) error {
+	// continuations come from the client as well
+	if termSpec == nil {
+		err := procdef.ErrTermValueNil(procCfg.ProcID)
+		s.log.Error("checking failed")
+		return err
+	}
 	ch, ok := procCfg.Chnls[termSpec.Via()]
 	if !ok {
-		panic("no via in proc snap")
+		err := procdef.ErrMissingInCfg(termSpec.Via())
+		s.log.Error("checking failed")
+		return fault.Wrap(fault.ProtocolViolation, err)
 	}
 	if poolID == ch.PoolID {
 		return s.checkProvider(poolID, procEnv, procCtx, procCfg, termSpec)
@@ -1127,7 +1603,9 @@ func (s *service) checkProvider(
 		delete(procCtx.Assets, termSpec.Y)
 		return nil
 	default:
-		panic(procdef.ErrTermTypeUnexpected(ts))
+		err := errTermTypeUnexpected(ts)
+		s.log.Error("checking failed")
+		return err
 	}
 }
 
@@ -1336,22 +1814,68 @@ func (s *service) checkClient(
 		procCtx.Assets[termSpec.X] = wantVia
 		return s.checkState(poolID, procEnv, procCtx, procCfg, termSpec.Cont)
 	default:
-		panic(procdef.ErrTermTypeUnexpected(ts))
+		err := errTermTypeUnexpected(ts)
+		s.log.Error("checking failed")
+		return err
 	}
 }
 
 func errOptimisticUpdate(got rn.ADT) error {
-	return fmt.Errorf("entity concurrent modification: got revision %v", got)
+	return fault.New(fault.ConcurrentModification, "entity concurrent modification: got revision %v", got)
+}
+
+func errMissingProc(want id.ADT) error {
+	return fault.New(fault.NotFound, "proc missing in pool: %v", want)
 }
 
 func errMissingPool(want sym.ADT) error {
-	return fmt.Errorf("pool missing in env: %v", want)
+	return fault.New(fault.NotFound, "pool missing in env: %v", want)
 }
 
 func errMissingSig(want id.ADT) error {
-	return fmt.Errorf("sig missing in env: %v", want)
+	return fault.New(fault.NotFound, "sig missing in env: %v", want)
 }
 
 func errMissingRole(want sym.ADT) error {
-	return fmt.Errorf("role missing in env: %v", want)
+	return fault.New(fault.NotFound, "role missing in env: %v", want)
+}
+
+func errMissingState(want id.ADT) error {
+	return fault.Wrap(fault.StateCorruption, typedef.ErrMissingInEnv(want))
+}
+
+func errPolarityUnexpected(got typedef.TermRec) error {
+	return fault.Wrap(fault.StateCorruption, typedef.ErrPolarityUnexpected(got))
+}
+
+func errStepTypeUnexpected(got procexec.SemRec) error {
+	return fault.Wrap(fault.StateCorruption, procexec.ErrRootTypeUnexpected(got))
+}
+
+func errRecTypeUnexpected(got procdef.TermRec) error {
+	return fault.Wrap(fault.ProtocolViolation, procdef.ErrRecTypeUnexpected(got))
+}
+
+func errTermTypeUnexpected(got procdef.TermSpec) error {
+	return fault.Wrap(fault.ProtocolViolation, procdef.ErrTermTypeUnexpected(got))
+}
+
+func errMissingPoolID(want id.ADT) error {
+	return fault.New(fault.NotFound, "pool missing: %v", want)
+}
+
+func errMissingQuota(want id.ADT) error {
+	return fault.New(fault.NotFound, "pool quota missing: %v", want)
+}
+
+func errMissingIdem(want string) error {
+	return fault.New(fault.NotFound, "idempotency key missing: %v", want)
+}
+
+func errIdemConflict(got string) error {
+	return fault.New(fault.ConcurrentModification, "idempotency key concurrent use: %v", got)
+}
+
+func errIdemKeyReused(got string) error {
+	return fault.New(fault.ProtocolViolation, "idempotency key reused for another request: %v", got)
 }
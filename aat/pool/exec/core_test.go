@@ -0,0 +1,492 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/outbox"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+
+	poolledger "orglang/orglang/aat/pool/ledger"
+	procdec "orglang/orglang/aat/proc/dec"
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+	typedef "orglang/orglang/aat/type/def"
+)
+
+func TestMain(m *testing.M) {
+	// the generated converters are not checked in
+	if MsgFromEvent == nil {
+		MsgFromEvent = func(ev Event) EventME {
+			return EventME{
+				PoolID: ev.PoolID.String(),
+				ProcID: ev.ProcID.String(),
+				ChnlID: ev.ChnlID.String(),
+				Kind:   string(ev.Kind),
+				PoolRN: rn.ConvertToInt(ev.PoolRN),
+			}
+		}
+	}
+	if MsgToEvent == nil {
+		MsgToEvent = func(dto EventME) (Event, error) {
+			poolID, err := id.ConvertFromString(dto.PoolID)
+			if err != nil {
+				return Event{}, err
+			}
+			procID, err := id.ConvertFromString(dto.ProcID)
+			if err != nil {
+				return Event{}, err
+			}
+			return Event{PoolID: poolID, ProcID: procID, Kind: EventKind(dto.Kind), PoolRN: rn.ADT(dto.PoolRN)}, nil
+		}
+	}
+	os.Exit(m.Run())
+}
+
+func TestSpawnReplaysKeyedRequests(t *testing.T) {
+	s, pools := newServiceStub()
+	ctx := context.Background()
+	pool := pools.create()
+	spec := procexec.ProcSpec{PoolID: pool.ExecID, IdemKey: "spawn-1"}
+	first, err := s.Spawn(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.Spawn(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.ExecID != first.ExecID {
+		t.Fatalf("want %v, got %v", first.ExecID, second.ExecID)
+	}
+	// the replay leaves the pool alone
+	if len(pools.liabs) != 1 || len(pools.idems) != 1 {
+		t.Fatalf("want 1 liability and 1 key, got %v and %v", pools.liabs, pools.idems)
+	}
+	if pools.idems[0].PoolRN != rn.ADT(2) {
+		t.Fatalf("want revision 2, got %v", pools.idems[0].PoolRN)
+	}
+}
+
+func TestSpawnRejectsReusedKeys(t *testing.T) {
+	s, pools := newServiceStub()
+	ctx := context.Background()
+	pool := pools.create()
+	pools.idems = append(pools.idems, IdemRec{PoolID: pool.ExecID, IdemKey: "key-1", Kind: StepIdem, ProcID: id.New()})
+	_, err := s.Spawn(ctx, procexec.ProcSpec{PoolID: pool.ExecID, IdemKey: "key-1"})
+	if !fault.Is(err, fault.ProtocolViolation) {
+		t.Fatalf("want protocol violation, got %v", err)
+	}
+}
+
+func TestSpawnRetriesConflicts(t *testing.T) {
+	s, pools := newServiceStub()
+	s.retry = retryPolicy{Attempts: 3}
+	racer := &racingRepoStub{repoStub: pools, races: 1}
+	s.pools = racer
+	pool := pools.create()
+	_, err := s.Spawn(context.Background(), procexec.ProcSpec{PoolID: pool.ExecID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the retry reloads the revision the racer left behind
+	if racer.loads != 2 || len(pools.liabs) != 1 || pools.liabs[0].PoolRN != rn.ADT(3) {
+		t.Fatalf("want 2 loads and the liability at 3, got %v and %v", racer.loads, pools.liabs)
+	}
+}
+
+func TestSpawnExhaustsRetries(t *testing.T) {
+	s, pools := newServiceStub()
+	s.retry = retryPolicy{Attempts: 3}
+	racer := &racingRepoStub{repoStub: pools, races: math.MaxInt}
+	s.pools = racer
+	pool := pools.create()
+	_, err := s.Spawn(context.Background(), procexec.ProcSpec{PoolID: pool.ExecID})
+	if !fault.Is(err, fault.ConcurrentModification) {
+		t.Fatalf("want concurrent modification, got %v", err)
+	}
+	if racer.loads != 3 || len(pools.liabs) != 0 {
+		t.Fatalf("want 3 loads and no liabilities, got %v and %v", racer.loads, pools.liabs)
+	}
+}
+
+func TestSpawnStopsBackoffOnCancel(t *testing.T) {
+	s, pools := newServiceStub()
+	s.retry = retryPolicy{Attempts: 3, MinDelay: time.Hour, MaxDelay: time.Hour}
+	s.pools = &racingRepoStub{repoStub: pools, races: math.MaxInt}
+	pool := pools.create()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := s.Spawn(ctx, procexec.ProcSpec{PoolID: pool.ExecID})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want deadline exceeded, got %v", err)
+	}
+}
+
+// a concurrent step moves the pool on between the load and the update
+type racingRepoStub struct {
+	*repoStub
+	races int
+	loads int
+}
+
+func (r *racingRepoStub) SelectRec(source data.Source, poolID id.ADT) (PoolRec, error) {
+	r.loads++
+	rec, err := r.repoStub.SelectRec(source, poolID)
+	if err == nil && r.races > 0 {
+		r.races--
+		bumped := r.roots[poolID]
+		bumped.PoolRN++
+		r.roots[poolID] = bumped
+	}
+	return rec, err
+}
+
+func newServiceStub() (*service, *repoStub) {
+	operator := data.NewOperatorMem()
+	pools := &repoStub{roots: make(map[id.ADT]PoolRec)}
+	p := &props{Retry: retry{Attempts: 1}, Events: events{Buffer: 8}}
+	box := &outboxStub{}
+	s := newService(
+		pools,
+		procsStub{},
+		&typesStub{},
+		operator,
+		operator,
+		newEventHub(p),
+		&ledgerStub{},
+		box,
+		box,
+		p,
+		slog.Default(),
+	)
+	return s, pools
+}
+
+func TestTakeRejectsForeignPool(t *testing.T) {
+	s, pools := newServiceStub()
+	procID := newProcStub(s, pools)
+	spec := StepSpec{PoolID: pools.create().ExecID, ProcID: procID, ProcTS: procdef.CloseSpec{CommPH: sym.New("x")}}
+	err := s.Take(context.Background(), spec)
+	if !fault.Is(err, fault.NotFound) {
+		t.Fatalf("want not found, got %v", err)
+	}
+	if len(pools.journal) != 0 {
+		t.Fatalf("want nothing taken, got %v", pools.journal)
+	}
+}
+
+func TestTakeClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		// adjusts the provider of x
+		setup func(*procexec.Cfg)
+		term  procdef.TermSpec
+		want  fault.Kind
+	}{
+		{
+			"missing binding",
+			func(*procexec.Cfg) {},
+			procdef.CloseSpec{CommPH: sym.New("q")},
+			fault.ProtocolViolation,
+		},
+		{
+			"bad term",
+			func(*procexec.Cfg) {},
+			procdef.WaitSpec{CommPH: sym.New("x"), ContTS: procdef.CloseSpec{CommPH: sym.New("x")}},
+			fault.ProtocolViolation,
+		},
+		{
+			"missing cont",
+			func(cfg *procexec.Cfg) {
+				x := cfg.Chnls[sym.New("x")]
+				cfg.Chnls[sym.New("y")] = procexec.EP{ChnlPH: sym.New("y"), ChnlID: id.New(), TermID: x.TermID}
+			},
+			procdef.WaitSpec{CommPH: sym.New("y")},
+			fault.ProtocolViolation,
+		},
+		{
+			"state mismatch",
+			func(cfg *procexec.Cfg) {
+				// the close meets another close instead of a wait
+				x := cfg.Chnls[sym.New("x")]
+				cfg.Steps = map[id.ADT]procexec.SemRec{x.ChnlID: procexec.MsgRec{ChnlID: x.ChnlID, Val: procdef.CloseRec{X: sym.New("x")}}}
+			},
+			procdef.CloseSpec{CommPH: sym.New("x")},
+			fault.StateCorruption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, pools := newServiceStub()
+			procID := newProcStub(s, pools)
+			procCfg := pools.procs[procID]
+			tt.setup(&procCfg)
+			pools.procs[procID] = procCfg
+			err := s.Take(context.Background(), StepSpec{PoolID: procCfg.PoolID, ProcID: procID, ProcTS: tt.term})
+			if !fault.Is(err, tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, err)
+			}
+			if len(pools.liabs) != 0 {
+				t.Fatalf("want nothing taken, got %v", pools.liabs)
+			}
+		})
+	}
+}
+
+func TestTakeDeletesConsumedStep(t *testing.T) {
+	s, pools := newServiceStub()
+	// the provider closed the channel first
+	closerID := newProcStub(s, pools)
+	poolID := pools.procs[closerID].PoolID
+	err := s.Take(context.Background(), StepSpec{PoolID: poolID, ProcID: closerID, ProcTS: procdef.CloseSpec{CommPH: sym.New("x")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.consumed) != 0 {
+		t.Fatalf("want the half step kept, got %v", pools.consumed)
+	}
+	closer := pools.procs[closerID].Chnls[sym.New("x")]
+	waiterID := id.New()
+	pools.procs[waiterID] = procexec.Cfg{
+		ProcID: waiterID,
+		PoolID: poolID,
+		Chnls: map[sym.ADT]procexec.EP{
+			sym.New("y"): {ChnlPH: sym.New("y"), ChnlID: closer.ChnlID, TermID: closer.TermID},
+			sym.New("z"): {ChnlPH: sym.New("z"), ChnlID: id.New(), TermID: closer.TermID, PoolID: poolID},
+		},
+		Steps: map[id.ADT]procexec.SemRec{
+			closer.ChnlID: procexec.MsgRec{PoolID: poolID, ProcID: closerID, ChnlID: closer.ChnlID, Val: procdef.CloseRec{X: sym.New("x")}},
+		},
+	}
+	err = s.Take(context.Background(), StepSpec{PoolID: poolID, ProcID: waiterID, ProcTS: procdef.WaitSpec{CommPH: sym.New("y"), ContTS: procdef.CloseSpec{CommPH: sym.New("z")}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.consumed) != 1 || pools.consumed[0] != closer.ChnlID {
+		t.Fatalf("want the close deleted, got %v", pools.consumed)
+	}
+}
+
+// keeps the rows the service touches, the rest of Repo stays unimplemented
+type repoStub struct {
+	Repo
+	roots map[id.ADT]PoolRec
+	liabs []procexec.Liab
+	idems []IdemRec
+	// lease ends by process
+	claims  map[id.ADT]time.Time
+	procs   map[id.ADT]procexec.Cfg
+	comps   []CompRec
+	journal []JournalEntry
+	// channels with the pending half deleted
+	consumed []id.ADT
+}
+
+func (r *repoStub) create() PoolRec {
+	rec := PoolRec{ExecID: id.New(), ProcID: id.New(), PoolRN: rn.Initial()}
+	r.roots[rec.ExecID] = rec
+	return rec
+}
+
+func (r *repoStub) SelectRec(source data.Source, poolID id.ADT) (PoolRec, error) {
+	rec, ok := r.roots[poolID]
+	if !ok {
+		return PoolRec{}, errMissingPoolID(poolID)
+	}
+	return rec, nil
+}
+
+func (r *repoStub) SelectQuota(source data.Source, poolID id.ADT) (Quota, error) {
+	return Quota{}, errMissingQuota(poolID)
+}
+
+func (r *repoStub) CountLive(source data.Source, poolID id.ADT) (int, error) {
+	return 0, nil
+}
+
+func (r *repoStub) UpdateProc(source data.Source, mod procexec.Mod) error {
+	for _, lock := range mod.Locks {
+		rec := r.roots[lock.PoolID]
+		if rec.PoolRN != lock.PoolRN {
+			return errOptimisticUpdate(lock.PoolRN)
+		}
+		rec.PoolRN++
+		r.roots[lock.PoolID] = rec
+	}
+	r.liabs = append(r.liabs, mod.Liabs...)
+	// revoked channels leave the configuration
+	for _, bnd := range mod.Bnds {
+		if bnd.PoolRN < 0 {
+			delete(r.procs[bnd.ProcID].Chnls, bnd.ChnlPH)
+		}
+	}
+	return nil
+}
+
+func (r *repoStub) InsertIdem(source data.Source, rec IdemRec) error {
+	r.idems = append(r.idems, rec)
+	return nil
+}
+
+func (r *repoStub) SelectIdem(source data.Source, poolID id.ADT, key string) (IdemRec, error) {
+	for _, rec := range r.idems {
+		if rec.PoolID == poolID && rec.IdemKey == key {
+			return rec, nil
+		}
+	}
+	return IdemRec{}, errMissingIdem(key)
+}
+
+// the configuration follows the pool revision
+func (r *repoStub) SelectProc(source data.Source, procID id.ADT) (procexec.Cfg, error) {
+	cfg := r.procs[procID]
+	// a copy, as the real repo reads fresh rows
+	cfg.Chnls = maps.Clone(cfg.Chnls)
+	cfg.PoolRN = r.roots[cfg.PoolID].PoolRN
+	return cfg, nil
+}
+
+func (r *repoStub) SelectComps(source data.Source, procID id.ADT) ([]CompRec, error) {
+	var comps []CompRec
+	for _, comp := range slices.Backward(r.comps) {
+		if comp.ProcID == procID {
+			comps = append(comps, comp)
+		}
+	}
+	return comps, nil
+}
+
+func (r *repoStub) DeleteComp(source data.Source, rec CompRec) error {
+	r.comps = slices.DeleteFunc(r.comps, func(comp CompRec) bool { return comp == rec })
+	return nil
+}
+
+func (r *repoStub) InsertJournal(source data.Source, entry JournalEntry) error {
+	r.journal = append(r.journal, entry)
+	return nil
+}
+
+func (r *repoStub) DeleteStep(source data.Source, chnlID id.ADT) error {
+	r.consumed = append(r.consumed, chnlID)
+	return nil
+}
+
+func (r *repoStub) DeleteClaim(source data.Source, procID id.ADT) error {
+	delete(r.claims, procID)
+	return nil
+}
+
+func (r *repoStub) InsertClaim(source data.Source, claim PollClaim) (bool, error) {
+	if r.claims == nil {
+		r.claims = make(map[id.ADT]time.Time)
+	}
+	if r.claims[claim.ProcID].After(claim.ClaimedAt) {
+		return false, nil
+	}
+	r.claims[claim.ProcID] = claim.Until
+	return true, nil
+}
+
+type procsStub struct {
+	procdec.Repo
+}
+
+func (procsStub) SelectEnv(source data.Source, ids []id.ADT) (map[id.ADT]procdec.ProcRec, error) {
+	return nil, nil
+}
+
+// channel states come from the terms regardless of the ids asked
+type typesStub struct {
+	typedef.Repo
+	terms map[id.ADT]typedef.TermRec
+}
+
+func (r *typesStub) SelectTypeEnv(source data.Source, qns []sym.ADT) (map[sym.ADT]typedef.TypeRec, error) {
+	return nil, nil
+}
+
+func (r *typesStub) SelectTermEnv(source data.Source, ids []id.ADT) (map[id.ADT]typedef.TermRec, error) {
+	return r.terms, nil
+}
+
+type ledgerStub struct {
+	entries []poolledger.EntrySpec
+}
+
+func (w *ledgerStub) Write(source data.Source, spec poolledger.EntrySpec) error {
+	w.entries = append(w.entries, spec)
+	return nil
+}
+
+// numbers the stream events the way the outbox does
+type outboxStub struct {
+	events  []outbox.EventSpec
+	written []outbox.Event
+}
+
+func (w *outboxStub) Write(source data.Source, spec outbox.EventSpec) (outbox.Event, error) {
+	payload, err := json.Marshal(spec.Payload)
+	if err != nil {
+		return outbox.Event{}, err
+	}
+	ev := outbox.Event{
+		ID:        id.New(),
+		Kind:      spec.Kind,
+		SubjectID: spec.SubjectID,
+		StreamID:  spec.StreamID,
+		Payload:   payload,
+	}
+	if !spec.StreamID.IsEmpty() {
+		ev.Seq = int64(len(w.stream(spec.StreamID, 0))) + 1
+	}
+	w.events = append(w.events, spec)
+	w.written = append(w.written, ev)
+	return ev, nil
+}
+
+func (w *outboxStub) Replay(ctx context.Context, spec outbox.ReplaySpec) ([]outbox.Event, error) {
+	return w.stream(spec.StreamID, spec.Seq), nil
+}
+
+func (w *outboxStub) stream(streamID id.ADT, after int64) []outbox.Event {
+	var events []outbox.Event
+	for _, ev := range w.written {
+		if ev.StreamID == streamID && ev.Seq > after {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+func TestPropsRejectZeroPollTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		wantErr bool
+	}{
+		{"zero", 0, true},
+		{"negative", -time.Second, true},
+		{"positive", time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := props{Poll: poll{Timeout: tt.timeout}}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
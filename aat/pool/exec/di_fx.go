@@ -3,6 +3,7 @@
 package exec
 
 import (
+	"context"
 	"embed"
 	"html/template"
 	"log/slog"
@@ -10,8 +11,12 @@ import (
 	"github.com/Masterminds/sprig/v3"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
+	"google.golang.org/grpc"
 
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
 	"orglang/orglang/avt/msg"
+	"orglang/orglang/rpc"
 )
 
 var Module = fx.Module("aat/pool",
@@ -22,15 +27,47 @@ var Module = fx.Module("aat/pool",
 		fx.Private,
 		newHandlerEcho,
 		newStepHandlerEcho,
-		fx.Annotate(newDaoPgx, fx.As(new(Repo))),
+		newHandlerGrpc,
+		newStepHandlerGrpc,
+		newRepoByMapping,
 		fx.Annotate(newRenderer, fx.As(new(msg.Renderer))),
+		newCfg,
+		newEventHub,
+		newArchiver,
 	),
 	fx.Invoke(
 		cfgEcho,
+		cfgArchiver,
 		cfgStepEcho,
+		cfgGrpc,
+		cfgStepGrpc,
 	),
 )
 
+func newCfg(k core.Keeper) (*props, error) {
+	props := &props{}
+	err := k.Load("execution", props)
+	if err != nil {
+		return nil, err
+	}
+	err = props.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func newRepoByMapping(m data.Mapping, l *slog.Logger) Repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
 //go:embed *.html
 var viewsFs embed.FS
 
@@ -42,10 +79,40 @@ func newRenderer(l *slog.Logger) (*msg.RendererStdlib, error) {
 	return msg.NewRendererStdlib(t, l), nil
 }
 
+func cfgArchiver(a *archiver, lc fx.Lifecycle) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(
+		fx.Hook{
+			OnStart: func(context.Context) error {
+				go func() {
+					defer close(done)
+					a.run(ctx)
+				}()
+				return nil
+			},
+			OnStop: func(stopCtx context.Context) error {
+				cancel()
+				select {
+				case <-done:
+					return nil
+				case <-stopCtx.Done():
+					return stopCtx.Err()
+				}
+			},
+		},
+	)
+}
+
 func cfgEcho(e *echo.Echo, h *handlerEcho) error {
 	e.POST("/api/v1/pools", h.PostOne)
 	e.GET("/api/v1/pools/:id", h.GetOne)
 	e.POST("/api/v1/pools/:id/procs", h.PostProc)
+	e.GET("/api/v1/pools/:id/events", h.GetEvents)
+	e.POST("/api/v1/pools/:id/procs/:proc_id/cancel", h.PostCancel)
+	e.GET("/api/v1/procs/:id/journal", h.GetJournal)
+	e.GET("/api/v1/pools/:id/quota", h.GetQuota)
+	e.PUT("/api/v1/pools/:id/quota", h.PutQuota)
 	return nil
 }
 
@@ -53,3 +120,13 @@ func cfgStepEcho(e *echo.Echo, h *stepHandlerEcho) error {
 	e.POST("/api/v1/pools/:id/steps", h.PostOne)
 	return nil
 }
+
+func cfgGrpc(s *grpc.Server, h *handlerGrpc) error {
+	rpc.RegisterPoolServiceServer(s, h)
+	return nil
+}
+
+func cfgStepGrpc(s *grpc.Server, h *stepHandlerGrpc) error {
+	rpc.RegisterStepServiceServer(s, h)
+	return nil
+}
@@ -2,10 +2,14 @@ package exec
 
 import (
 	"database/sql"
+	"time"
 
+	"orglang/orglang/avt/core"
 	"orglang/orglang/avt/data"
 	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
 
+	procdef "orglang/orglang/aat/proc/def"
 	procexec "orglang/orglang/aat/proc/exec"
 )
 
@@ -17,6 +21,37 @@ type Repo interface {
 	SelectSubs(data.Source, id.ADT) (PoolSnap, error)
 	SelectProc(data.Source, id.ADT) (procexec.Cfg, error)
 	UpdateProc(data.Source, procexec.Mod) error
+	// the pending half step on the channel once the other half completes it
+	DeleteStep(data.Source, id.ADT) error
+	// unclaimed at the given moment
+	SelectPending(data.Source, id.ADT, time.Time) ([]Pending, error)
+	// false if someone else holds the claim
+	InsertClaim(data.Source, PollClaim) (bool, error)
+	DeleteClaim(data.Source, id.ADT) error
+	SelectRec(data.Source, id.ADT) (PoolRec, error)
+	InsertIdem(data.Source, IdemRec) error
+	SelectIdem(data.Source, id.ADT, string) (IdemRec, error)
+	SelectQuota(data.Source, id.ADT) (Quota, error)
+	UpsertQuota(data.Source, QuotaSpec) error
+	IncrementCounter(data.Source, CounterRec) (int, error)
+	// windows superseded by later ones of the same pool and kind
+	DeleteCounters(data.Source) (int64, error)
+	CountLive(data.Source, id.ADT) (int, error)
+	InsertComp(data.Source, CompRec) error
+	SelectComps(data.Source, id.ADT) ([]CompRec, error)
+	DeleteComp(data.Source, CompRec) error
+	InsertJournal(data.Source, JournalEntry) error
+	SelectJournal(data.Source, id.ADT) ([]JournalEntry, error)
+	// closed before the given moment
+	SelectClosed(data.Source, time.Time, int) ([]id.ADT, error)
+	ArchiveProc(data.Source, id.ADT) error
+	// states nothing refers to anymore
+	DeleteOrphans(data.Source) (int64, error)
+}
+
+// per pool notification channel for committed steps
+func stepsChannel(poolID id.ADT) string {
+	return "pool_steps_" + poolID.String()
 }
 
 type poolRefDS struct {
@@ -37,10 +72,102 @@ type poolRecDS struct {
 	PoolRN int64          `db:"rev"`
 }
 
+// the liable pool with its current revision
+type procRootDS struct {
+	PoolID   string `db:"pool_id"`
+	PoolRN   int64  `db:"rev"`
+	Priority int    `db:"priority"`
+}
+
+func dataToCfg(procID id.ADT, root procRootDS, chnls []procexec.EP, steps []procexec.SemRec) (procexec.Cfg, error) {
+	cfg := procexec.Cfg{
+		ProcID:   procID,
+		Chnls:    core.IndexBy(procexec.ChnlPH, chnls),
+		Steps:    core.IndexBy(procexec.ChnlID, steps),
+		PoolRN:   rn.ConvertFromInt(root.PoolRN),
+		Priority: root.Priority,
+	}
+	if root.PoolID == "" {
+		return cfg, nil
+	}
+	poolID, err := id.ConvertFromString(root.PoolID)
+	if err != nil {
+		return procexec.Cfg{}, err
+	}
+	cfg.PoolID = poolID
+	return cfg, nil
+}
+
 type liabDS struct {
-	PoolID string `db:"pool_id"`
-	ProcID string `db:"proc_id"`
-	PoolRN int64  `db:"rev"`
+	PoolID   string `db:"pool_id"`
+	ProcID   string `db:"proc_id"`
+	PoolRN   int64  `db:"rev"`
+	Priority int    `db:"priority"`
+}
+
+type pendingDS struct {
+	PoolID    string    `db:"pool_id"`
+	ProcID    string    `db:"proc_id"`
+	Priority  int       `db:"priority"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type idemDS struct {
+	PoolID  string `db:"pool_id"`
+	IdemKey string `db:"idem_key"`
+	Kind    int8   `db:"kind"`
+	ProcID  string `db:"proc_id"`
+	PoolRN  int64  `db:"rev"`
+}
+
+type quotaDS struct {
+	PoolID          string `db:"pool_id"`
+	LiveProcs       int    `db:"live_procs"`
+	SpawnLimit      int    `db:"spawn_limit"`
+	SpawnIntervalMS int64  `db:"spawn_interval_ms"`
+	StepLimit       int    `db:"step_limit"`
+	StepIntervalMS  int64  `db:"step_interval_ms"`
+}
+
+func dataToQuota(dto quotaDS) Quota {
+	return Quota{
+		LiveProcs: dto.LiveProcs,
+		Spawns: Rate{
+			Limit:    dto.SpawnLimit,
+			Interval: time.Duration(dto.SpawnIntervalMS) * time.Millisecond,
+		},
+		Steps: Rate{
+			Limit:    dto.StepLimit,
+			Interval: time.Duration(dto.StepIntervalMS) * time.Millisecond,
+		},
+	}
+}
+
+func dataFromQuota(spec QuotaSpec) quotaDS {
+	return quotaDS{
+		PoolID:          spec.PoolID.String(),
+		LiveProcs:       spec.Quota.LiveProcs,
+		SpawnLimit:      spec.Quota.Spawns.Limit,
+		SpawnIntervalMS: spec.Quota.Spawns.Interval.Milliseconds(),
+		StepLimit:       spec.Quota.Steps.Limit,
+		StepIntervalMS:  spec.Quota.Steps.Interval.Milliseconds(),
+	}
+}
+
+type compDS struct {
+	PoolID string             `db:"pool_id"`
+	ProcID string             `db:"proc_id"`
+	PoolRN int64              `db:"rev"`
+	CompTS procdef.TermSpecME `db:"spec"`
+}
+
+type journalDS struct {
+	PoolID string    `db:"pool_id"`
+	ProcID string    `db:"proc_id"`
+	Kind   string    `db:"kind"`
+	PoolRN int64     `db:"rev"`
+	Detail string    `db:"detail"`
+	At     time.Time `db:"created_at"`
 }
 
 type epDS struct {
@@ -0,0 +1,608 @@
+package exec
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+	typedef "orglang/orglang/aat/type/def"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	return &daoMem{l}
+}
+
+const (
+	rootsMem    = "pool_roots"
+	liabsMem    = "pool_liabs"
+	bndsMem     = "proc_bnds"
+	stepsMem    = "proc_steps"
+	idemsMem    = "pool_idems"
+	quotasMem   = "pool_quotas"
+	countersMem = "pool_counters"
+	compsMem    = "proc_comps"
+	journalMem  = "proc_journal"
+	archiveMem  = "proc_archive"
+	claimsMem   = "poll_claims"
+)
+
+type bndRowMem struct {
+	ProcID  string
+	ChnlPH  string
+	ChnlID  string
+	StateID string
+	PoolRN  int64
+}
+
+type stepRowMem struct {
+	Step      procexec.SemRecDS
+	CreatedAt time.Time
+}
+
+type claimRowMem struct {
+	ProcID string
+	Until  time.Time
+}
+
+type counterRowMem struct {
+	PoolID string
+	Kind   CounterKind
+	Window time.Time
+	Hits   int
+}
+
+type archiveRowMem struct {
+	ProcID     string
+	PoolID     string
+	Bnds       []bndRowMem
+	Liabs      []liabDS
+	Journal    []journalDS
+	ArchivedAt time.Time
+}
+
+func (r *daoMem) Insert(source data.Source, rec PoolRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.InsertRows(ds, rootsMem, DataFromPoolRec(rec))
+	r.log.Debug("insertion succeeded", slog.Any("poolID", rec.ExecID))
+	return nil
+}
+
+func (r *daoMem) InsertLiab(source data.Source, liab procexec.Liab) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.InsertRows(ds, liabsMem, DataFromLiab(liab))
+	r.log.Debug("insertion succeeded", slog.Any("poolID", liab.PoolID))
+	return nil
+}
+
+func (r *daoMem) SelectRefs(source data.Source) ([]PoolRef, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	rows := data.Rows[poolRecDS](ds, rootsMem)
+	dtos := make([]poolRefDS, 0, len(rows))
+	for _, row := range rows {
+		dtos = append(dtos, poolRefDS{row.PoolID, row.ProcID})
+	}
+	return DataToPoolRefs(dtos)
+}
+
+func (r *daoMem) SelectSubs(source data.Source, poolID id.ADT) (PoolSnap, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	rows := data.Rows[poolRecDS](ds, rootsMem)
+	_, ok := findRow(rows, func(row poolRecDS) bool { return row.PoolID == poolID.String() })
+	if !ok {
+		r.log.Error("selection failed", slog.Any("poolID", poolID))
+		return PoolSnap{}, errMissingPoolID(poolID)
+	}
+	dto := poolSnapDS{PoolID: poolID.String()}
+	for _, row := range rows {
+		if row.SupID.Valid && row.SupID.String == poolID.String() {
+			dto.Subs = append(dto.Subs, poolRefDS{row.PoolID, row.ProcID})
+		}
+	}
+	return DataToPoolSnap(dto)
+}
+
+func (r *daoMem) SelectProc(source data.Source, procID id.ADT) (procexec.Cfg, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	idAttr := slog.Any("procID", procID)
+	bnds := latestBnds(data.Rows[bndRowMem](ds, bndsMem), procID.String())
+	liab, hasLiab := latestLiab(data.Rows[liabDS](ds, liabsMem), procID.String())
+	chnlIDs := make(map[string]bool, len(bnds))
+	chnlDtos := make([]epDS, 0, len(bnds))
+	for _, bnd := range bnds {
+		dto := epDS{ProcID: bnd.ProcID, ChnlPH: bnd.ChnlPH, ChnlID: bnd.ChnlID, StateID: bnd.StateID}
+		if hasLiab {
+			dto.PoolID = liab.PoolID
+		}
+		chnlDtos = append(chnlDtos, dto)
+		chnlIDs[bnd.ChnlID] = true
+	}
+	chnls, err := DataToEPs(chnlDtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	var stepDtos []procexec.SemRecDS
+	for _, row := range data.Rows[stepRowMem](ds, stepsMem) {
+		if row.Step.VID.Valid && chnlIDs[row.Step.VID.String] {
+			stepDtos = append(stepDtos, row.Step)
+		}
+	}
+	steps, err := procexec.DataToSemRecs(stepDtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	root := procRootDS{Priority: liab.Priority}
+	if hasLiab {
+		pool, ok := findRow(data.Rows[poolRecDS](ds, rootsMem), func(row poolRecDS) bool { return row.PoolID == liab.PoolID })
+		if ok {
+			root.PoolID = pool.PoolID
+			root.PoolRN = pool.PoolRN
+		}
+	}
+	cfg, err := dataToCfg(procID, root, chnls, steps)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return cfg, nil
+}
+
+func (r *daoMem) UpdateProc(source data.Source, mod procexec.Mod) error {
+	if len(mod.Locks) == 0 {
+		panic("empty locks")
+	}
+	ds := data.MustConform[data.SourceMem](source)
+	dto, err := procexec.DataFromMod(mod)
+	if err != nil {
+		r.log.Error("mapping failed")
+		return err
+	}
+	for _, bnd := range dto.Bnds {
+		data.InsertRows(ds, bndsMem, bndRowMem{bnd.ProcID, bnd.ChnlPH, bnd.ChnlID, bnd.StateID, bnd.PoolRN})
+	}
+	now := time.Now()
+	for _, step := range dto.Steps {
+		data.InsertRows(ds, stepsMem, stepRowMem{step, now})
+	}
+	roots := data.Rows[poolRecDS](ds, rootsMem)
+	for _, poolID := range stepPools(mod.Steps) {
+		ds.Notify(stepsChannel(poolID))
+		// supervisors poll on behalf of their sub-pools
+		root, ok := findRow(roots, func(row poolRecDS) bool { return row.PoolID == poolID.String() })
+		if ok && root.SupID.Valid {
+			ds.Notify("pool_steps_" + root.SupID.String)
+		}
+	}
+	for _, liab := range mod.Liabs {
+		data.InsertRows(ds, liabsMem, DataFromLiab(liab))
+	}
+	for _, lock := range dto.Locks {
+		updated := data.UpdateRows(ds, rootsMem, func(row *poolRecDS) bool {
+			if row.PoolID != lock.PoolID || row.PoolRN != lock.PoolRN {
+				return false
+			}
+			row.PoolRN++
+			return true
+		})
+		if updated == 0 {
+			r.log.Error("update failed", slog.Any("dto", lock))
+			return errOptimisticUpdate(rn.ADT(lock.PoolRN))
+		}
+	}
+	r.log.Debug("update succeeded")
+	return nil
+}
+
+func (r *daoMem) SelectPending(source data.Source, poolID id.ADT, at time.Time) ([]Pending, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	pools := map[string]bool{}
+	for _, row := range data.Rows[poolRecDS](ds, rootsMem) {
+		if row.PoolID == poolID.String() || row.SupID.Valid && row.SupID.String == poolID.String() {
+			pools[row.PoolID] = true
+		}
+	}
+	liabs := data.Rows[liabDS](ds, liabsMem)
+	var dtos []pendingDS
+	for _, row := range data.Rows[stepRowMem](ds, stepsMem) {
+		procID := row.Step.PID.String
+		liab, ok := latestLiab(liabs, procID)
+		if !ok || liab.PoolRN <= 0 || !pools[liab.PoolID] {
+			continue
+		}
+		_, claimed := findRow(data.Rows[claimRowMem](ds, claimsMem), func(row claimRowMem) bool {
+			return row.ProcID == procID && row.Until.After(at)
+		})
+		if claimed {
+			continue
+		}
+		dtos = append(dtos, pendingDS{liab.PoolID, procID, liab.Priority, row.CreatedAt})
+	}
+	return DataToPendings(dtos)
+}
+
+func (r *daoMem) InsertClaim(source data.Source, claim PollClaim) (bool, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	procID := claim.ProcID.String()
+	row, found := findRow(data.Rows[claimRowMem](ds, claimsMem), func(row claimRowMem) bool {
+		return row.ProcID == procID
+	})
+	if found && row.Until.After(claim.ClaimedAt) {
+		return false, nil
+	}
+	data.DeleteRows(ds, claimsMem, func(row claimRowMem) bool { return row.ProcID == procID })
+	data.InsertRows(ds, claimsMem, claimRowMem{procID, claim.Until})
+	return true, nil
+}
+
+func (r *daoMem) DeleteStep(source data.Source, chnlID id.ADT) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.DeleteRows(ds, stepsMem, func(row stepRowMem) bool { return row.Step.VID.String == chnlID.String() })
+	return nil
+}
+
+func (r *daoMem) DeleteClaim(source data.Source, procID id.ADT) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.DeleteRows(ds, claimsMem, func(row claimRowMem) bool { return row.ProcID == procID.String() })
+	return nil
+}
+
+func (r *daoMem) SelectRec(source data.Source, poolID id.ADT) (PoolRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dto, ok := findRow(data.Rows[poolRecDS](ds, rootsMem), func(row poolRecDS) bool {
+		return row.PoolID == poolID.String()
+	})
+	if !ok {
+		return PoolRec{}, errMissingPoolID(poolID)
+	}
+	return DataToPoolRec(dto)
+}
+
+func (r *daoMem) InsertIdem(source data.Source, rec IdemRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	dto := DataFromIdemRec(rec)
+	_, taken := findRow(data.Rows[idemDS](ds, idemsMem), func(row idemDS) bool {
+		return row.PoolID == dto.PoolID && row.IdemKey == dto.IdemKey
+	})
+	if taken {
+		r.log.Warn("insertion skipped", slog.Any("dto", dto))
+		return errIdemConflict(rec.IdemKey)
+	}
+	data.InsertRows(ds, idemsMem, dto)
+	return nil
+}
+
+func (r *daoMem) SelectIdem(source data.Source, poolID id.ADT, key string) (IdemRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dto, ok := findRow(data.Rows[idemDS](ds, idemsMem), func(row idemDS) bool {
+		return row.PoolID == poolID.String() && row.IdemKey == key
+	})
+	if !ok {
+		return IdemRec{}, errMissingIdem(key)
+	}
+	return DataToIdemRec(dto)
+}
+
+func (r *daoMem) SelectQuota(source data.Source, poolID id.ADT) (Quota, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dto, ok := findRow(data.Rows[quotaDS](ds, quotasMem), func(row quotaDS) bool {
+		return row.PoolID == poolID.String()
+	})
+	if !ok {
+		return Quota{}, errMissingQuota(poolID)
+	}
+	return dataToQuota(dto), nil
+}
+
+func (r *daoMem) UpsertQuota(source data.Source, spec QuotaSpec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	dto := dataFromQuota(spec)
+	data.DeleteRows(ds, quotasMem, func(row quotaDS) bool { return row.PoolID == dto.PoolID })
+	data.InsertRows(ds, quotasMem, dto)
+	return nil
+}
+
+func (r *daoMem) IncrementCounter(source data.Source, counter CounterRec) (int, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	match := func(row counterRowMem) bool {
+		return row.PoolID == counter.PoolID.String() && row.Kind == counter.Kind && row.Window.Equal(counter.Window)
+	}
+	hits := 1
+	updated := data.UpdateRows(ds, countersMem, func(row *counterRowMem) bool {
+		if !match(*row) {
+			return false
+		}
+		row.Hits++
+		hits = row.Hits
+		return true
+	})
+	if updated == 0 {
+		data.InsertRows(ds, countersMem, counterRowMem{counter.PoolID.String(), counter.Kind, counter.Window, hits})
+	}
+	return hits, nil
+}
+
+func (r *daoMem) DeleteCounters(source data.Source) (int64, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	rows := data.Rows[counterRowMem](ds, countersMem)
+	deleted := data.DeleteRows(ds, countersMem, func(row counterRowMem) bool {
+		_, superseded := findRow(rows, func(later counterRowMem) bool {
+			return later.PoolID == row.PoolID && later.Kind == row.Kind && later.Window.After(row.Window)
+		})
+		return superseded
+	})
+	return int64(deleted), nil
+}
+
+func (r *daoMem) CountLive(source data.Source, poolID id.ADT) (int, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	live := 0
+	for _, liab := range latestLiabs(data.Rows[liabDS](ds, liabsMem)) {
+		if liab.PoolID == poolID.String() && liab.PoolRN > 0 {
+			live++
+		}
+	}
+	return live, nil
+}
+
+func (r *daoMem) InsertComp(source data.Source, rec CompRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.InsertRows(ds, compsMem, dataFromComp(rec))
+	return nil
+}
+
+func (r *daoMem) SelectComps(source data.Source, procID id.ADT) ([]CompRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	var dtos []compDS
+	for _, row := range data.Rows[compDS](ds, compsMem) {
+		if row.ProcID == procID.String() {
+			dtos = append(dtos, row)
+		}
+	}
+	slices.SortStableFunc(dtos, func(a, b compDS) int { return cmp.Compare(b.PoolRN, a.PoolRN) })
+	return dataToComps(dtos)
+}
+
+func (r *daoMem) DeleteComp(source data.Source, rec CompRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.DeleteRows(ds, compsMem, func(row compDS) bool {
+		return row.ProcID == rec.ProcID.String() && row.PoolRN == rn.ConvertToInt(rec.PoolRN)
+	})
+	return nil
+}
+
+func (r *daoMem) InsertJournal(source data.Source, entry JournalEntry) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.InsertRows(ds, journalMem, DataFromJournal(entry))
+	return nil
+}
+
+func (r *daoMem) SelectJournal(source data.Source, procID id.ADT) ([]JournalEntry, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	var dtos []journalDS
+	for _, row := range data.Rows[journalDS](ds, journalMem) {
+		if row.ProcID == procID.String() {
+			dtos = append(dtos, row)
+		}
+	}
+	// archived entries keep resolving
+	for _, row := range data.Rows[archiveRowMem](ds, archiveMem) {
+		if row.ProcID == procID.String() {
+			dtos = append(dtos, row.Journal...)
+		}
+	}
+	slices.SortStableFunc(dtos, func(a, b journalDS) int {
+		return cmp.Or(a.At.Compare(b.At), cmp.Compare(a.PoolRN, b.PoolRN))
+	})
+	return DataToJournal(dtos)
+}
+
+func (r *daoMem) SelectClosed(source data.Source, before time.Time, limit int) ([]id.ADT, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	bnds := data.Rows[bndRowMem](ds, bndsMem)
+	pending := make(map[string]bool)
+	for _, row := range data.Rows[stepRowMem](ds, stepsMem) {
+		pending[row.Step.PID.String] = true
+	}
+	// a process without journal entries counts from its creation
+	lastEntry := make(map[string]time.Time)
+	for _, bnd := range bnds {
+		procID, err := id.ConvertFromString(bnd.ProcID)
+		if err != nil {
+			r.log.Error("conversion failed", slog.String("procID", bnd.ProcID))
+			return nil, err
+		}
+		lastEntry[bnd.ProcID] = procID.Time()
+	}
+	for _, row := range data.Rows[journalDS](ds, journalMem) {
+		if row.At.After(lastEntry[row.ProcID]) {
+			lastEntry[row.ProcID] = row.At
+		}
+	}
+	seen := make(map[string]bool)
+	var procIDs []id.ADT
+	for _, bnd := range bnds {
+		if len(procIDs) >= limit || seen[bnd.ProcID] {
+			continue
+		}
+		seen[bnd.ProcID] = true
+		// every channel got its negative binding and nothing is pending
+		closed := !pending[bnd.ProcID] && lastEntry[bnd.ProcID].Before(before)
+		for _, latest := range latestBnds(bnds, bnd.ProcID) {
+			closed = closed && latest.PoolRN < 0
+		}
+		if !closed {
+			continue
+		}
+		procID, err := id.ConvertFromString(bnd.ProcID)
+		if err != nil {
+			r.log.Error("conversion failed", slog.String("procID", bnd.ProcID))
+			return nil, err
+		}
+		procIDs = append(procIDs, procID)
+	}
+	return procIDs, nil
+}
+
+func (r *daoMem) ArchiveProc(source data.Source, procID id.ADT) error {
+	ds := data.MustConform[data.SourceMem](source)
+	byProc := func(rowProcID string) bool { return rowProcID == procID.String() }
+	row := archiveRowMem{ProcID: procID.String(), ArchivedAt: time.Now()}
+	liab, ok := latestLiab(data.Rows[liabDS](ds, liabsMem), procID.String())
+	if ok {
+		row.PoolID = liab.PoolID
+	}
+	for _, bnd := range data.Rows[bndRowMem](ds, bndsMem) {
+		if byProc(bnd.ProcID) {
+			row.Bnds = append(row.Bnds, bnd)
+		}
+	}
+	for _, liab := range data.Rows[liabDS](ds, liabsMem) {
+		if byProc(liab.ProcID) {
+			row.Liabs = append(row.Liabs, liab)
+		}
+	}
+	for _, entry := range data.Rows[journalDS](ds, journalMem) {
+		if byProc(entry.ProcID) {
+			row.Journal = append(row.Journal, entry)
+		}
+	}
+	// the snapshot goes first, the live rows after
+	data.InsertRows(ds, archiveMem, row)
+	data.DeleteRows(ds, bndsMem, func(row bndRowMem) bool { return byProc(row.ProcID) })
+	data.DeleteRows(ds, liabsMem, func(row liabDS) bool { return byProc(row.ProcID) })
+	data.DeleteRows(ds, journalMem, func(row journalDS) bool { return byProc(row.ProcID) })
+	data.DeleteRows(ds, compsMem, func(row compDS) bool { return byProc(row.ProcID) })
+	data.DeleteRows(ds, stepsMem, func(row stepRowMem) bool { return byProc(row.Step.PID.String) })
+	r.log.Debug("archiving succeeded", slog.Any("procID", procID))
+	return nil
+}
+
+func (r *daoMem) DeleteOrphans(source data.Source) (int64, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	bound := make(map[string]bool)
+	for _, bnd := range data.Rows[bndRowMem](ds, bndsMem) {
+		bound[bnd.StateID] = true
+	}
+	return typedef.DeleteOrphansMem(ds, bound), nil
+}
+
+func findRow[T any](rows []T, match func(T) bool) (T, bool) {
+	i := slices.IndexFunc(rows, match)
+	if i < 0 {
+		var zero T
+		return zero, false
+	}
+	return rows[i], true
+}
+
+// the latest binding per placeholder
+func latestBnds(rows []bndRowMem, procID string) []bndRowMem {
+	latest := make(map[string]bndRowMem)
+	var order []string
+	for _, row := range rows {
+		if row.ProcID != procID {
+			continue
+		}
+		prev, ok := latest[row.ChnlPH]
+		if !ok {
+			order = append(order, row.ChnlPH)
+		}
+		if !ok || abs(row.PoolRN) >= abs(prev.PoolRN) {
+			latest[row.ChnlPH] = row
+		}
+	}
+	bnds := make([]bndRowMem, 0, len(order))
+	for _, chnlPH := range order {
+		bnds = append(bnds, latest[chnlPH])
+	}
+	return bnds
+}
+
+func latestLiab(rows []liabDS, procID string) (liabDS, bool) {
+	var latest liabDS
+	found := false
+	for _, row := range rows {
+		if row.ProcID == procID && (!found || abs(row.PoolRN) >= abs(latest.PoolRN)) {
+			latest = row
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// the latest liability per process
+func latestLiabs(rows []liabDS) map[string]liabDS {
+	latest := make(map[string]liabDS)
+	for _, row := range rows {
+		prev, ok := latest[row.ProcID]
+		if !ok || abs(row.PoolRN) >= abs(prev.PoolRN) {
+			latest[row.ProcID] = row
+		}
+	}
+	return latest
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// pending service step with the counterpart binding
+type ServiceMem struct {
+	ChnlID  string
+	PoolID  string
+	ProcID  string
+	ChnlPH  string
+	StateID string
+	Cont    procdef.TermRecDS
+}
+
+// for the in-memory inbox, which knows the key but not the pool
+func IdemTakenMem(ds data.SourceMem, key string) bool {
+	_, taken := findRow(data.Rows[idemDS](ds, idemsMem), func(row idemDS) bool { return row.IdemKey == key })
+	return taken
+}
+
+// for the in-memory repos built on top of the executor tables
+func SelectServicesMem(ds data.SourceMem) []ServiceMem {
+	bnds := data.Rows[bndRowMem](ds, bndsMem)
+	liabs := latestLiabs(data.Rows[liabDS](ds, liabsMem))
+	var svcs []ServiceMem
+	for _, row := range data.Rows[stepRowMem](ds, stepsMem) {
+		// services only
+		if row.Step.K != 2 || !row.Step.VID.Valid {
+			continue
+		}
+		for _, bnd := range bnds {
+			if bnd.ChnlID != row.Step.VID.String || bnd.ProcID == row.Step.PID.String {
+				continue
+			}
+			latest, ok := findRow(latestBnds(bnds, bnd.ProcID), func(b bndRowMem) bool { return b.ChnlPH == bnd.ChnlPH })
+			if !ok || latest != bnd || bnd.PoolRN <= 0 {
+				continue
+			}
+			liab, ok := liabs[bnd.ProcID]
+			if !ok || liab.PoolRN <= 0 {
+				continue
+			}
+			svcs = append(svcs, ServiceMem{row.Step.VID.String, liab.PoolID, bnd.ProcID, bnd.ChnlPH, bnd.StateID, row.Step.TR})
+		}
+	}
+	return svcs
+}
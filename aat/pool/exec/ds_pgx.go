@@ -4,10 +4,10 @@ import (
 	"errors"
 	"log/slog"
 	"reflect"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
-	"orglang/orglang/avt/core"
 	"orglang/orglang/avt/data"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/rn"
@@ -51,9 +51,10 @@ func (r *daoPgx) InsertLiab(source data.Source, liab procexec.Liab) (err error)
 	ds := data.MustConform[data.SourcePgx](source)
 	dto := DataFromLiab(liab)
 	args := pgx.NamedArgs{
-		"pool_id": dto.PoolID,
-		"proc_id": dto.ProcID,
-		"rev":     dto.PoolRN,
+		"pool_id":  dto.PoolID,
+		"proc_id":  dto.ProcID,
+		"rev":      dto.PoolRN,
+		"priority": dto.Priority,
 	}
 	_, err = ds.Conn.Exec(ds.Ctx, insertLiab, args)
 	if err != nil {
@@ -99,11 +100,19 @@ func (r *daoPgx) SelectProc(source data.Source, procID id.ADT) (procexec.Cfg, er
 		r.log.Error("mapping failed", idAttr)
 		return procexec.Cfg{}, err
 	}
+	var root procRootDS
+	err = ds.Conn.QueryRow(ds.Ctx, selectProcRoot, procID.String()).Scan(&root.PoolID, &root.PoolRN, &root.Priority)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.log.Error("execution failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	cfg, err := dataToCfg(procID, root, chnls, steps)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return procexec.Cfg{}, err
+	}
 	r.log.Debug("selection succeeded", idAttr)
-	return procexec.Cfg{
-		Chnls: core.IndexBy(procexec.ChnlPH, chnls),
-		Steps: core.IndexBy(procexec.ChnlID, steps),
-	}, nil
+	return cfg, nil
 }
 
 func (r *daoPgx) UpdateProc(source data.Source, mod procexec.Mod) (err error) {
@@ -128,20 +137,10 @@ func (r *daoPgx) UpdateProc(source data.Source, mod procexec.Mod) (err error) {
 		}
 		bndReq.Queue(insertBnd, args)
 	}
-	if bndReq.Len() > 0 {
-		bndRes := ds.Conn.SendBatch(ds.Ctx, &bndReq)
-		defer func() {
-			err = errors.Join(err, bndRes.Close())
-		}()
-		for _, dto := range dto.Bnds {
-			_, err = bndRes.Exec()
-			if err != nil {
-				r.log.Error("execution failed", slog.Any("dto", dto))
-			}
-		}
-		if err != nil {
-			return err
-		}
+	err = execBatch(ds, &bndReq)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dtos", dto.Bnds))
+		return err
 	}
 	// steps
 	stepReq := pgx.Batch{}
@@ -154,20 +153,33 @@ func (r *daoPgx) UpdateProc(source data.Source, mod procexec.Mod) (err error) {
 		}
 		stepReq.Queue(insertStep, args)
 	}
-	if stepReq.Len() > 0 {
-		stepRes := ds.Conn.SendBatch(ds.Ctx, &stepReq)
-		defer func() {
-			err = errors.Join(err, stepRes.Close())
-		}()
-		for _, dto := range dto.Steps {
-			_, err = stepRes.Exec()
-			if err != nil {
-				r.log.Error("execution failed", slog.Any("dto", dto))
-			}
-		}
-		if err != nil {
-			return err
+	// wakeups get delivered on commit only
+	for _, poolID := range stepPools(mod.Steps) {
+		stepReq.Queue(notifySteps, stepsChannel(poolID))
+		// supervisors poll on behalf of their sub-pools
+		stepReq.Queue(notifySupSteps, poolID.String())
+	}
+	err = execBatch(ds, &stepReq)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dtos", dto.Steps))
+		return err
+	}
+	// liabilities
+	liabReq := pgx.Batch{}
+	for _, liab := range mod.Liabs {
+		dto := DataFromLiab(liab)
+		args := pgx.NamedArgs{
+			"pool_id":  dto.PoolID,
+			"proc_id":  dto.ProcID,
+			"rev":      dto.PoolRN,
+			"priority": dto.Priority,
 		}
+		liabReq.Queue(insertLiab, args)
+	}
+	err = execBatch(ds, &liabReq)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("liabs", mod.Liabs))
+		return err
 	}
 	// roots
 	rootReq := pgx.Batch{}
@@ -186,19 +198,416 @@ func (r *daoPgx) UpdateProc(source data.Source, mod procexec.Mod) (err error) {
 		ct, err := rootRes.Exec()
 		if err != nil {
 			r.log.Error("execution failed", slog.Any("dto", dto))
+			return err
 		}
 		if ct.RowsAffected() == 0 {
-			r.log.Error("update failed")
+			r.log.Error("update failed", slog.Any("dto", dto))
 			return errOptimisticUpdate(rn.ADT(dto.PoolRN))
 		}
 	}
+	r.log.Debug("update succeeded")
+	return nil
+}
+
+func (r *daoPgx) SelectRec(source data.Source, poolID id.ADT) (PoolRec, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("poolID", poolID)
+	rows, err := ds.Conn.Query(ds.Ctx, selectRoot, poolID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return PoolRec{}, err
+	}
+	defer rows.Close()
+	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[poolRecDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return PoolRec{}, errMissingPoolID(poolID)
+	}
 	if err != nil {
+		r.log.Error("collection failed", idAttr, slog.Any("struct", reflect.TypeOf(dto)))
+		return PoolRec{}, err
+	}
+	rec, err := DataToPoolRec(dto)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return PoolRec{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return rec, nil
+}
+
+func (r *daoPgx) InsertIdem(source data.Source, rec IdemRec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	dto := DataFromIdemRec(rec)
+	args := pgx.NamedArgs{
+		"pool_id":  dto.PoolID,
+		"idem_key": dto.IdemKey,
+		"kind":     dto.Kind,
+		"proc_id":  dto.ProcID,
+		"rev":      dto.PoolRN,
+	}
+	ct, err := ds.Conn.Exec(ds.Ctx, insertIdem, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dto", dto))
 		return err
 	}
-	r.log.Debug("update succeeded")
+	if ct.RowsAffected() == 0 {
+		// a concurrent request with the same key won
+		r.log.Warn("insertion skipped", slog.Any("dto", dto))
+		return errIdemConflict(rec.IdemKey)
+	}
+	r.log.Debug("insertion succeeded", slog.Any("poolID", rec.PoolID))
+	return nil
+}
+
+func (r *daoPgx) SelectIdem(source data.Source, poolID id.ADT, key string) (IdemRec, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("poolID", poolID)
+	rows, err := ds.Conn.Query(ds.Ctx, selectIdem, poolID.String(), key)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return IdemRec{}, err
+	}
+	defer rows.Close()
+	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[idemDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return IdemRec{}, errMissingIdem(key)
+	}
+	if err != nil {
+		r.log.Error("collection failed", idAttr, slog.Any("struct", reflect.TypeOf(dto)))
+		return IdemRec{}, err
+	}
+	rec, err := DataToIdemRec(dto)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return IdemRec{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return rec, nil
+}
+
+func (r *daoPgx) SelectQuota(source data.Source, poolID id.ADT) (Quota, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("poolID", poolID)
+	rows, err := ds.Conn.Query(ds.Ctx, selectQuota, poolID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return Quota{}, err
+	}
+	defer rows.Close()
+	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[quotaDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Quota{}, errMissingQuota(poolID)
+	}
+	if err != nil {
+		r.log.Error("collection failed", idAttr, slog.Any("struct", reflect.TypeOf(dto)))
+		return Quota{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return dataToQuota(dto), nil
+}
+
+func (r *daoPgx) UpsertQuota(source data.Source, spec QuotaSpec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("poolID", spec.PoolID)
+	dto := dataFromQuota(spec)
+	args := pgx.NamedArgs{
+		"pool_id":           dto.PoolID,
+		"live_procs":        dto.LiveProcs,
+		"spawn_limit":       dto.SpawnLimit,
+		"spawn_interval_ms": dto.SpawnIntervalMS,
+		"step_limit":        dto.StepLimit,
+		"step_interval_ms":  dto.StepIntervalMS,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, upsertQuota, args)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return err
+	}
+	r.log.Debug("upsertion succeeded", idAttr)
 	return nil
 }
 
+func (r *daoPgx) IncrementCounter(source data.Source, counter CounterRec) (int, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("poolID", counter.PoolID)
+	args := pgx.NamedArgs{
+		"pool_id":      counter.PoolID.String(),
+		"kind":         counter.Kind,
+		"window_start": counter.Window,
+	}
+	var hits int
+	err := ds.Conn.QueryRow(ds.Ctx, upsertCounter, args).Scan(&hits)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return 0, err
+	}
+	return hits, nil
+}
+
+func (r *daoPgx) DeleteCounters(source data.Source) (int64, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	ct, err := ds.Conn.Exec(ds.Ctx, deleteCounters)
+	if err != nil {
+		r.log.Error("execution failed")
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+func (r *daoPgx) CountLive(source data.Source, poolID id.ADT) (int, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("poolID", poolID)
+	var live int
+	err := ds.Conn.QueryRow(ds.Ctx, countLive, poolID.String()).Scan(&live)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return 0, err
+	}
+	return live, nil
+}
+
+func (r *daoPgx) InsertComp(source data.Source, rec CompRec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	dto := dataFromComp(rec)
+	args := pgx.NamedArgs{
+		"pool_id": dto.PoolID,
+		"proc_id": dto.ProcID,
+		"rev":     dto.PoolRN,
+		"spec":    dto.CompTS,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, insertComp, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", rec.ProcID))
+		return err
+	}
+	r.log.Debug("insertion succeeded", slog.Any("procID", rec.ProcID))
+	return nil
+}
+
+func (r *daoPgx) SelectComps(source data.Source, procID id.ADT) ([]CompRec, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("procID", procID)
+	rows, err := ds.Conn.Query(ds.Ctx, selectComps, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[compDS])
+	if err != nil {
+		r.log.Error("collection failed", idAttr, slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	recs, err := dataToComps(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", idAttr, slog.Int("count", len(recs)))
+	return recs, nil
+}
+
+func (r *daoPgx) DeleteComp(source data.Source, rec CompRec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	_, err := ds.Conn.Exec(ds.Ctx, deleteComp, rec.ProcID.String(), rn.ConvertToInt(rec.PoolRN))
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", rec.ProcID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoPgx) InsertJournal(source data.Source, entry JournalEntry) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	dto := DataFromJournal(entry)
+	args := pgx.NamedArgs{
+		"pool_id":    dto.PoolID,
+		"proc_id":    dto.ProcID,
+		"kind":       dto.Kind,
+		"rev":        dto.PoolRN,
+		"detail":     dto.Detail,
+		"created_at": dto.At,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, insertJournal, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", entry.ProcID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoPgx) SelectJournal(source data.Source, procID id.ADT) ([]JournalEntry, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("procID", procID)
+	rows, err := ds.Conn.Query(ds.Ctx, selectJournal, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[journalDS])
+	if err != nil {
+		r.log.Error("collection failed", idAttr, slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	entries, err := DataToJournal(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", idAttr, slog.Int("count", len(entries)))
+	return entries, nil
+}
+
+func (r *daoPgx) SelectClosed(source data.Source, before time.Time, limit int) ([]id.ADT, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	rows, err := ds.Conn.Query(ds.Ctx, selectClosed, before, limit)
+	if err != nil {
+		r.log.Error("execution failed")
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		r.log.Error("collection failed", slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	procIDs := make([]id.ADT, 0, len(dtos))
+	for _, dto := range dtos {
+		procID, err := id.ConvertFromString(dto)
+		if err != nil {
+			r.log.Error("conversion failed", slog.String("procID", dto))
+			return nil, err
+		}
+		procIDs = append(procIDs, procID)
+	}
+	return procIDs, nil
+}
+
+func (r *daoPgx) ArchiveProc(source data.Source, procID id.ADT) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	req := pgx.Batch{}
+	// the snapshot goes first, the live rows after
+	for _, query := range []string{insertArchive, deleteBnds, deleteLiabs, deleteJournal, deleteComps, deleteTerms} {
+		req.Queue(query, procID.String())
+	}
+	err := execBatch(ds, &req)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", procID))
+		return err
+	}
+	r.log.Debug("archiving succeeded", slog.Any("procID", procID))
+	return nil
+}
+
+func (r *daoPgx) DeleteOrphans(source data.Source) (int64, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	tag, err := ds.Conn.Exec(ds.Ctx, deleteOrphans)
+	if err != nil {
+		r.log.Error("execution failed")
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// drains the results so that the connection can be reused
+func execBatch(ds data.SourcePgx, req *pgx.Batch) (err error) {
+	if req.Len() == 0 {
+		return nil
+	}
+	res := ds.Conn.SendBatch(ds.Ctx, req)
+	defer func() {
+		err = errors.Join(err, res.Close())
+	}()
+	for range req.Len() {
+		_, err = res.Exec()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *daoPgx) SelectPending(source data.Source, poolID id.ADT, at time.Time) ([]Pending, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("poolID", poolID)
+	rows, err := ds.Conn.Query(ds.Ctx, selectPending, poolID.String(), at)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[pendingDS])
+	if err != nil {
+		r.log.Error("collection failed", idAttr, slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	pendings, err := DataToPendings(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", idAttr, slog.Int("count", len(pendings)))
+	return pendings, nil
+}
+
+func (r *daoPgx) InsertClaim(source data.Source, claim PollClaim) (bool, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("procID", claim.ProcID)
+	args := pgx.NamedArgs{
+		"proc_id":       claim.ProcID.String(),
+		"claimed_at":    claim.ClaimedAt,
+		"claimed_until": claim.Until,
+	}
+	ct, err := ds.Conn.Exec(ds.Ctx, upsertClaim, args)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return false, err
+	}
+	r.log.Debug("claiming done", idAttr, slog.Bool("claimed", ct.RowsAffected() > 0))
+	return ct.RowsAffected() > 0, nil
+}
+
+func (r *daoPgx) DeleteStep(source data.Source, chnlID id.ADT) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	_, err := ds.Conn.Exec(ds.Ctx, deleteStep, chnlID.String())
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("chnlID", chnlID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoPgx) DeleteClaim(source data.Source, procID id.ADT) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	_, err := ds.Conn.Exec(ds.Ctx, deleteClaim, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", procID))
+		return err
+	}
+	return nil
+}
+
+func stepPools(steps []procexec.SemRec) []id.ADT {
+	seen := make(map[id.ADT]bool, len(steps))
+	var poolIDs []id.ADT
+	for _, step := range steps {
+		var poolID id.ADT
+		switch rec := step.(type) {
+		case procexec.MsgRec:
+			poolID = rec.PoolID
+		case procexec.SvcRec:
+			poolID = rec.PoolID
+		}
+		if poolID.IsEmpty() || seen[poolID] {
+			continue
+		}
+		seen[poolID] = true
+		poolIDs = append(poolIDs, poolID)
+	}
+	return poolIDs
+}
+
 func (r *daoPgx) SelectSubs(source data.Source, poolID id.ADT) (PoolSnap, error) {
 	ds := data.MustConform[data.SourcePgx](source)
 	idAttr := slog.Any("poolID", poolID)
@@ -257,9 +666,9 @@ const (
 
 	insertLiab = `
 		insert into pool_liabs (
-			pool_id, proc_id, rev
+			pool_id, proc_id, rev, priority
 		) values (
-			@pool_id, @proc_id, @rev
+			@pool_id, @proc_id, @rev, @priority
 		)`
 
 	insertBnd = `
@@ -276,6 +685,220 @@ const (
 			@proc_id, @chnl_id, @kind, @spec
 		)`
 
+	insertIdem = `
+		insert into pool_idems (
+			pool_id, idem_key, kind, proc_id, rev
+		) values (
+			@pool_id, @idem_key, @kind, @proc_id, @rev
+		)
+		on conflict (pool_id, idem_key) do nothing`
+
+	selectIdem = `
+		select
+			pool_id, idem_key, kind, proc_id, rev
+		from pool_idems
+		where pool_id = $1
+			and idem_key = $2`
+
+	selectRoot = `
+		select
+			pool_id, proc_id, sup_pool_id, rev
+		from pool_roots
+		where pool_id = $1`
+
+	selectQuota = `
+		select
+			pool_id, live_procs,
+			spawn_limit, spawn_interval_ms,
+			step_limit, step_interval_ms
+		from pool_quotas
+		where pool_id = $1`
+
+	upsertQuota = `
+		insert into pool_quotas (
+			pool_id, live_procs,
+			spawn_limit, spawn_interval_ms,
+			step_limit, step_interval_ms
+		) values (
+			@pool_id, @live_procs,
+			@spawn_limit, @spawn_interval_ms,
+			@step_limit, @step_interval_ms
+		)
+		on conflict (pool_id)
+		do update set
+			live_procs = excluded.live_procs,
+			spawn_limit = excluded.spawn_limit,
+			spawn_interval_ms = excluded.spawn_interval_ms,
+			step_limit = excluded.step_limit,
+			step_interval_ms = excluded.step_interval_ms`
+
+	upsertCounter = `
+		insert into pool_counters (
+			pool_id, kind, window_start, hits
+		) values (
+			@pool_id, @kind, @window_start, 1
+		)
+		on conflict (pool_id, kind, window_start)
+		do update set hits = pool_counters.hits + 1
+		returning hits`
+
+	deleteCounters = `
+		delete from pool_counters
+		where exists (
+			select 1
+			from pool_counters later
+			where later.pool_id = pool_counters.pool_id
+				and later.kind = pool_counters.kind
+				and later.window_start > pool_counters.window_start
+		)`
+
+	countLive = `
+		with liabs as not materialized (
+			select distinct on (proc_id)
+				*
+			from pool_liabs
+			order by proc_id, abs(rev) desc
+		)
+		select
+			count(*)
+		from liabs
+		where pool_id = $1
+			and rev > 0`
+
+	insertComp = `
+		insert into proc_comps (
+			pool_id, proc_id, rev, spec
+		) values (
+			@pool_id, @proc_id, @rev, @spec
+		)`
+
+	selectComps = `
+		select
+			pool_id, proc_id, rev, spec
+		from proc_comps
+		where proc_id = $1
+		order by rev desc`
+
+	deleteComp = `
+		delete from proc_comps
+		where proc_id = $1
+			and rev = $2`
+
+	insertJournal = `
+		insert into proc_journal (
+			pool_id, proc_id, kind, rev, detail, created_at
+		) values (
+			@pool_id, @proc_id, @kind, @rev, @detail, @created_at
+		)`
+
+	// archived entries keep resolving
+	selectJournal = `
+		select
+			pool_id, proc_id, kind, rev, detail, created_at
+		from proc_journal
+		where proc_id = $1
+		union all
+		select
+			entry.pool_id, entry.proc_id, entry.kind, entry.rev, entry.detail, entry.created_at
+		from proc_archive arch,
+			jsonb_to_recordset(arch.journal) as entry(
+				pool_id varchar, proc_id varchar, kind varchar,
+				rev integer, detail text, created_at timestamptz
+			)
+		where arch.proc_id = $1
+		order by created_at, rev`
+
+	// every channel got its negative binding and nothing is pending
+	selectClosed = `
+		with latest as (
+			select distinct on (proc_id, chnl_ph)
+				proc_id, rev
+			from proc_bnds
+			order by proc_id, chnl_ph, abs(rev) desc
+		)
+		select latest.proc_id
+		from latest
+		group by latest.proc_id
+		having bool_and(latest.rev < 0)
+			and not exists (
+				select 1 from proc_steps step
+				where step.proc_id = latest.proc_id
+			)
+			-- a process without journal entries counts from its creation
+			and coalesce((
+				select max(entry.created_at) from proc_journal entry
+				where entry.proc_id = latest.proc_id
+			), (
+				select min(liab.created_at) from pool_liabs liab
+				where liab.proc_id = latest.proc_id
+			)) < $1
+		limit $2`
+
+	insertArchive = `
+		insert into proc_archive (
+			proc_id, pool_id, bnds, liabs, journal, archived_at
+		)
+		select
+			$1,
+			(select liab.pool_id from pool_liabs liab
+				where liab.proc_id = $1
+				order by abs(liab.rev) desc limit 1),
+			coalesce((select jsonb_agg(to_jsonb(bnd)) from proc_bnds bnd
+				where bnd.proc_id = $1), '[]'),
+			coalesce((select jsonb_agg(to_jsonb(liab)) from pool_liabs liab
+				where liab.proc_id = $1), '[]'),
+			coalesce((select jsonb_agg(to_jsonb(entry) order by entry.created_at, entry.rev)
+				from proc_journal entry
+				where entry.proc_id = $1), '[]'),
+			now()`
+
+	deleteBnds = `
+		delete from proc_bnds
+		where proc_id = $1`
+
+	deleteLiabs = `
+		delete from pool_liabs
+		where proc_id = $1`
+
+	deleteJournal = `
+		delete from proc_journal
+		where proc_id = $1`
+
+	deleteComps = `
+		delete from proc_comps
+		where proc_id = $1`
+
+	deleteTerms = `
+		delete from proc_steps
+		where proc_id = $1`
+
+	// the states reachable from roles and bindings survive with their subtrees
+	deleteOrphans = `
+		with recursive live as (
+			select state_id as id from role_states
+			union
+			select state_id from proc_bnds
+			union
+			select sub.id
+			from states sub
+			join live
+				on sub.from_id = live.id
+		)
+		delete from states st
+		where not exists (
+			select 1 from live
+			where live.id = st.id
+		)`
+
+	notifySteps = `
+		select pg_notify($1, '')`
+
+	notifySupSteps = `
+		select pg_notify('pool_steps_' || sup_pool_id, '')
+		from pool_roots
+		where pool_id = $1
+			and sup_pool_id is not null`
+
 	updateRoot = `
 		update pool_roots
 		set rev = @rev + 1
@@ -320,4 +943,61 @@ const (
 			on prvd.pool_id = liab.pool_id`
 
 	selectSteps = ``
+
+	selectPending = `
+		with liabs as not materialized (
+			select distinct on (proc_id)
+				*
+			from pool_liabs
+			order by proc_id, abs(rev) desc
+		), pools as not materialized (
+			select pool_id from pool_roots where pool_id = $1
+			union
+			select pool_id from pool_roots where sup_pool_id = $1
+		)
+		select
+			liab.pool_id,
+			step.proc_id,
+			liab.priority,
+			step.created_at
+		from proc_steps step
+		join liabs liab
+			on liab.proc_id = step.proc_id
+			and liab.rev > 0
+		join pools pool
+			on pool.pool_id = liab.pool_id
+		left join poll_claims claim
+			on claim.proc_id = step.proc_id
+			and claim.claimed_until > $2
+		where claim.proc_id is null`
+
+	upsertClaim = `
+		insert into poll_claims (
+			proc_id, claimed_until
+		) values (
+			@proc_id, @claimed_until
+		)
+		on conflict (proc_id)
+		do update set claimed_until = excluded.claimed_until
+		where poll_claims.claimed_until <= @claimed_at`
+
+	deleteClaim = `
+		delete from poll_claims
+		where proc_id = $1`
+
+	deleteStep = `
+		delete from proc_steps
+		where chnl_id = $1`
+
+	selectProcRoot = `
+		select
+			liab.pool_id,
+			pool.rev,
+			liab.priority
+		from pool_liabs liab
+		join pool_roots pool
+			on pool.pool_id = liab.pool_id
+		where liab.proc_id = $1
+		order by abs(liab.rev) desc
+		limit 1`
 )
@@ -0,0 +1,888 @@
+package exec
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	return &daoSql{l}
+}
+
+func (r *daoSql) Insert(source data.Source, rec PoolRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := DataFromPoolRec(rec)
+	args := data.NamedArgsSql{
+		"pool_id":     dto.PoolID,
+		"title":       nil,
+		"proc_id":     dto.ProcID,
+		"sup_pool_id": dto.SupID,
+		"rev":         dto.PoolRN,
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, insertRoot, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed")
+		return err
+	}
+	r.log.Debug("insertion succeeded", slog.Any("poolID", rec.ExecID))
+	return nil
+}
+
+func (r *daoSql) InsertLiab(source data.Source, liab procexec.Liab) error {
+	ds := data.MustConform[data.SourceSql](source)
+	err := r.insertLiab(ds, DataFromLiab(liab))
+	if err != nil {
+		r.log.Error("execution failed")
+		return err
+	}
+	r.log.Debug("insertion succeeded", slog.Any("poolID", liab.PoolID))
+	return nil
+}
+
+// sqlite has no column defaults for the timestamps
+func (r *daoSql) insertLiab(ds data.SourceSql, dto liabDS) error {
+	args := data.NamedArgsSql{
+		"pool_id":    dto.PoolID,
+		"proc_id":    dto.ProcID,
+		"rev":        dto.PoolRN,
+		"priority":   dto.Priority,
+		"created_at": data.TimeSql{V: time.Now()},
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, insertLiabSql, args.List()...)
+	return err
+}
+
+func (r *daoSql) SelectProc(source data.Source, procID id.ADT) (procexec.Cfg, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("procID", procID)
+	chnlRows, err := ds.Conn.QueryContext(ds.Ctx, selectChnlsSql, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	chnlDtos, err := data.CollectRowsSql(chnlRows, func(rows *sql.Rows) (dto epDS, err error) {
+		var poolID sql.NullString
+		err = rows.Scan(&dto.ProcID, &dto.ChnlPH, &dto.ChnlID, &dto.StateID, &poolID)
+		dto.PoolID = poolID.String
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	chnls, err := DataToEPs(chnlDtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	stepRows, err := ds.Conn.QueryContext(ds.Ctx, selectStepsSql, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	stepDtos, err := data.CollectRowsSql(stepRows, func(rows *sql.Rows) (dto procexec.SemRecDS, err error) {
+		spec := data.JsonSql[procdef.TermRecDS]{}
+		err = rows.Scan(&dto.ID, &dto.K, &dto.PID, &dto.VID, &spec)
+		dto.TR = spec.V
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	steps, err := procexec.DataToSemRecs(stepDtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	var root procRootDS
+	err = ds.Conn.QueryRowContext(ds.Ctx, selectProcRoot, procID.String()).Scan(&root.PoolID, &root.PoolRN, &root.Priority)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.log.Error("execution failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	cfg, err := dataToCfg(procID, root, chnls, steps)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return procexec.Cfg{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return cfg, nil
+}
+
+func (r *daoSql) UpdateProc(source data.Source, mod procexec.Mod) error {
+	if len(mod.Locks) == 0 {
+		panic("empty locks")
+	}
+	ds := data.MustConform[data.SourceSql](source)
+	dto, err := procexec.DataFromMod(mod)
+	if err != nil {
+		r.log.Error("mapping failed")
+		return err
+	}
+	// bindings
+	for _, bnd := range dto.Bnds {
+		args := data.NamedArgsSql{
+			"proc_id":  bnd.ProcID,
+			"chnl_ph":  bnd.ChnlPH,
+			"chnl_id":  bnd.ChnlID,
+			"state_id": bnd.StateID,
+			"rev":      bnd.PoolRN,
+		}
+		_, err = ds.Conn.ExecContext(ds.Ctx, insertBndSql, args.List()...)
+		if err != nil {
+			r.log.Error("execution failed", slog.Any("dto", bnd))
+			return err
+		}
+	}
+	// steps
+	now := data.TimeSql{V: time.Now()}
+	for _, step := range dto.Steps {
+		args := data.NamedArgsSql{
+			"id":         step.ID,
+			"proc_id":    step.PID,
+			"chnl_id":    step.VID,
+			"kind":       step.K,
+			"spec":       data.JsonSql[procdef.TermRecDS]{V: step.TR},
+			"created_at": now,
+		}
+		_, err = ds.Conn.ExecContext(ds.Ctx, insertStepSql, args.List()...)
+		if err != nil {
+			r.log.Error("execution failed", slog.Any("dto", step))
+			return err
+		}
+	}
+	// wakeups get delivered on commit only
+	for _, poolID := range stepPools(mod.Steps) {
+		ds.Notify(stepsChannel(poolID))
+		// supervisors poll on behalf of their sub-pools
+		var supID sql.NullString
+		err = ds.Conn.QueryRowContext(ds.Ctx, selectSupSql, poolID.String()).Scan(&supID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			r.log.Error("execution failed", slog.Any("poolID", poolID))
+			return err
+		}
+		if supID.Valid {
+			ds.Notify("pool_steps_" + supID.String)
+		}
+	}
+	// liabilities
+	for _, liab := range mod.Liabs {
+		err = r.insertLiab(ds, DataFromLiab(liab))
+		if err != nil {
+			r.log.Error("execution failed", slog.Any("liab", liab))
+			return err
+		}
+	}
+	// roots
+	for _, lock := range dto.Locks {
+		args := data.NamedArgsSql{
+			"pool_id": lock.PoolID,
+			"rev":     lock.PoolRN,
+		}
+		res, err := ds.Conn.ExecContext(ds.Ctx, updateRoot, args.List()...)
+		if err != nil {
+			r.log.Error("execution failed", slog.Any("dto", lock))
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			r.log.Error("update failed", slog.Any("dto", lock))
+			return errOptimisticUpdate(rn.ADT(lock.PoolRN))
+		}
+	}
+	r.log.Debug("update succeeded")
+	return nil
+}
+
+func (r *daoSql) SelectPending(source data.Source, poolID id.ADT, at time.Time) ([]Pending, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("poolID", poolID)
+	rows, err := ds.Conn.QueryContext(ds.Ctx, selectPendingSql, poolID.String(), data.TimeSql{V: at})
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto pendingDS, err error) {
+		createdAt := data.TimeSql{}
+		err = rows.Scan(&dto.PoolID, &dto.ProcID, &dto.Priority, &createdAt)
+		dto.CreatedAt = createdAt.V
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed", idAttr)
+		return nil, err
+	}
+	pendings, err := DataToPendings(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", idAttr, slog.Int("count", len(pendings)))
+	return pendings, nil
+}
+
+func (r *daoSql) InsertClaim(source data.Source, claim PollClaim) (bool, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("procID", claim.ProcID)
+	args := data.NamedArgsSql{
+		"proc_id":       claim.ProcID.String(),
+		"claimed_at":    data.TimeSql{V: claim.ClaimedAt},
+		"claimed_until": data.TimeSql{V: claim.Until},
+	}
+	res, err := ds.Conn.ExecContext(ds.Ctx, upsertClaim, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return false, err
+	}
+	r.log.Debug("claiming done", idAttr, slog.Bool("claimed", affected > 0))
+	return affected > 0, nil
+}
+
+func (r *daoSql) DeleteStep(source data.Source, chnlID id.ADT) error {
+	ds := data.MustConform[data.SourceSql](source)
+	_, err := ds.Conn.ExecContext(ds.Ctx, deleteStepSql, chnlID.String())
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("chnlID", chnlID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) DeleteClaim(source data.Source, procID id.ADT) error {
+	ds := data.MustConform[data.SourceSql](source)
+	_, err := ds.Conn.ExecContext(ds.Ctx, deleteClaimSql, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", procID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) SelectRec(source data.Source, poolID id.ADT) (PoolRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("poolID", poolID)
+	var dto poolRecDS
+	err := ds.Conn.QueryRowContext(ds.Ctx, selectRoot, poolID.String()).Scan(
+		&dto.PoolID, &dto.ProcID, &dto.SupID, &dto.PoolRN,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PoolRec{}, errMissingPoolID(poolID)
+	}
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return PoolRec{}, err
+	}
+	rec, err := DataToPoolRec(dto)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return PoolRec{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return rec, nil
+}
+
+func (r *daoSql) InsertIdem(source data.Source, rec IdemRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := DataFromIdemRec(rec)
+	args := data.NamedArgsSql{
+		"pool_id":  dto.PoolID,
+		"idem_key": dto.IdemKey,
+		"kind":     dto.Kind,
+		"proc_id":  dto.ProcID,
+		"rev":      dto.PoolRN,
+	}
+	res, err := ds.Conn.ExecContext(ds.Ctx, insertIdem, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dto", dto))
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// a concurrent request with the same key won
+		r.log.Warn("insertion skipped", slog.Any("dto", dto))
+		return errIdemConflict(rec.IdemKey)
+	}
+	r.log.Debug("insertion succeeded", slog.Any("poolID", rec.PoolID))
+	return nil
+}
+
+func (r *daoSql) SelectIdem(source data.Source, poolID id.ADT, key string) (IdemRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("poolID", poolID)
+	var dto idemDS
+	err := ds.Conn.QueryRowContext(ds.Ctx, selectIdemSql, poolID.String(), key).Scan(
+		&dto.PoolID, &dto.IdemKey, &dto.Kind, &dto.ProcID, &dto.PoolRN,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return IdemRec{}, errMissingIdem(key)
+	}
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return IdemRec{}, err
+	}
+	rec, err := DataToIdemRec(dto)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return IdemRec{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return rec, nil
+}
+
+func (r *daoSql) SelectQuota(source data.Source, poolID id.ADT) (Quota, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("poolID", poolID)
+	var dto quotaDS
+	err := ds.Conn.QueryRowContext(ds.Ctx, selectQuota, poolID.String()).Scan(
+		&dto.PoolID, &dto.LiveProcs,
+		&dto.SpawnLimit, &dto.SpawnIntervalMS,
+		&dto.StepLimit, &dto.StepIntervalMS,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Quota{}, errMissingQuota(poolID)
+	}
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return Quota{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return dataToQuota(dto), nil
+}
+
+func (r *daoSql) UpsertQuota(source data.Source, spec QuotaSpec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := dataFromQuota(spec)
+	args := data.NamedArgsSql{
+		"pool_id":           dto.PoolID,
+		"live_procs":        dto.LiveProcs,
+		"spawn_limit":       dto.SpawnLimit,
+		"spawn_interval_ms": dto.SpawnIntervalMS,
+		"step_limit":        dto.StepLimit,
+		"step_interval_ms":  dto.StepIntervalMS,
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, upsertQuota, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("poolID", spec.PoolID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) IncrementCounter(source data.Source, counter CounterRec) (int, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"pool_id":      counter.PoolID.String(),
+		"kind":         counter.Kind,
+		"window_start": data.TimeSql{V: counter.Window},
+	}
+	var hits int
+	err := ds.Conn.QueryRowContext(ds.Ctx, upsertCounter, args.List()...).Scan(&hits)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("poolID", counter.PoolID))
+		return 0, err
+	}
+	return hits, nil
+}
+
+func (r *daoSql) DeleteCounters(source data.Source) (int64, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	res, err := ds.Conn.ExecContext(ds.Ctx, deleteCounters)
+	if err != nil {
+		r.log.Error("execution failed")
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *daoSql) CountLive(source data.Source, poolID id.ADT) (int, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	var live int
+	err := ds.Conn.QueryRowContext(ds.Ctx, countLiveSql, poolID.String()).Scan(&live)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("poolID", poolID))
+		return 0, err
+	}
+	return live, nil
+}
+
+func (r *daoSql) InsertComp(source data.Source, rec CompRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := dataFromComp(rec)
+	args := data.NamedArgsSql{
+		"pool_id": dto.PoolID,
+		"proc_id": dto.ProcID,
+		"rev":     dto.PoolRN,
+		"spec":    data.JsonSql[procdef.TermSpecME]{V: dto.CompTS},
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, insertComp, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", rec.ProcID))
+		return err
+	}
+	r.log.Debug("insertion succeeded", slog.Any("procID", rec.ProcID))
+	return nil
+}
+
+func (r *daoSql) SelectComps(source data.Source, procID id.ADT) ([]CompRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("procID", procID)
+	rows, err := ds.Conn.QueryContext(ds.Ctx, selectCompsSql, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto compDS, err error) {
+		spec := data.JsonSql[procdef.TermSpecME]{}
+		err = rows.Scan(&dto.PoolID, &dto.ProcID, &dto.PoolRN, &spec)
+		dto.CompTS = spec.V
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed", idAttr)
+		return nil, err
+	}
+	recs, err := dataToComps(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", idAttr, slog.Int("count", len(recs)))
+	return recs, nil
+}
+
+func (r *daoSql) DeleteComp(source data.Source, rec CompRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	_, err := ds.Conn.ExecContext(ds.Ctx, deleteCompSql, rec.ProcID.String(), rn.ConvertToInt(rec.PoolRN))
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", rec.ProcID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) InsertJournal(source data.Source, entry JournalEntry) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := DataFromJournal(entry)
+	args := data.NamedArgsSql{
+		"pool_id":    dto.PoolID,
+		"proc_id":    dto.ProcID,
+		"kind":       dto.Kind,
+		"rev":        dto.PoolRN,
+		"detail":     dto.Detail,
+		"created_at": data.TimeSql{V: dto.At},
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, insertJournal, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", entry.ProcID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) SelectJournal(source data.Source, procID id.ADT) ([]JournalEntry, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("procID", procID)
+	rows, err := ds.Conn.QueryContext(ds.Ctx, selectJournalSql, procID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto journalDS, err error) {
+		createdAt := data.TimeSql{}
+		err = rows.Scan(&dto.PoolID, &dto.ProcID, &dto.Kind, &dto.PoolRN, &dto.Detail, &createdAt)
+		dto.At = createdAt.V
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed", idAttr)
+		return nil, err
+	}
+	entries, err := DataToJournal(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", idAttr, slog.Int("count", len(entries)))
+	return entries, nil
+}
+
+func (r *daoSql) SelectClosed(source data.Source, before time.Time, limit int) ([]id.ADT, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	rows, err := ds.Conn.QueryContext(ds.Ctx, selectClosedSql, data.TimeSql{V: before}, limit)
+	if err != nil {
+		r.log.Error("execution failed")
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto string, err error) {
+		err = rows.Scan(&dto)
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed")
+		return nil, err
+	}
+	procIDs := make([]id.ADT, 0, len(dtos))
+	for _, dto := range dtos {
+		procID, err := id.ConvertFromString(dto)
+		if err != nil {
+			r.log.Error("conversion failed", slog.String("procID", dto))
+			return nil, err
+		}
+		procIDs = append(procIDs, procID)
+	}
+	return procIDs, nil
+}
+
+func (r *daoSql) ArchiveProc(source data.Source, procID id.ADT) error {
+	ds := data.MustConform[data.SourceSql](source)
+	archivedAt := data.TimeSql{V: time.Now()}
+	_, err := ds.Conn.ExecContext(ds.Ctx, insertArchiveSql, procID.String(), archivedAt)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("procID", procID))
+		return err
+	}
+	// the snapshot goes first, the live rows after
+	for _, query := range []string{deleteBndsSql, deleteLiabsSql, deleteJournalSql, deleteCompsSql, deleteTermsSql} {
+		_, err = ds.Conn.ExecContext(ds.Ctx, query, procID.String())
+		if err != nil {
+			r.log.Error("execution failed", slog.Any("procID", procID), slog.String("q", query))
+			return err
+		}
+	}
+	r.log.Debug("archiving succeeded", slog.Any("procID", procID))
+	return nil
+}
+
+func (r *daoSql) DeleteOrphans(source data.Source) (int64, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	res, err := ds.Conn.ExecContext(ds.Ctx, deleteOrphansSql)
+	if err != nil {
+		r.log.Error("execution failed")
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *daoSql) SelectSubs(source data.Source, poolID id.ADT) (PoolSnap, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("poolID", poolID)
+	var dto poolSnapDS
+	var title sql.NullString
+	subs := data.JsonSql[[]poolRefDS]{}
+	err := ds.Conn.QueryRowContext(ds.Ctx, selectOrgSnapSql, poolID.String()).Scan(
+		&dto.PoolID, &title, &subs,
+	)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return PoolSnap{}, err
+	}
+	dto.Title, dto.Subs = title.String, subs.V
+	snap, err := DataToPoolSnap(dto)
+	if err != nil {
+		r.log.Error("mapping failed")
+		return PoolSnap{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return snap, nil
+}
+
+func (r *daoSql) SelectRefs(source data.Source) ([]PoolRef, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	query := `
+		select
+			pool_id, proc_id
+		from pool_roots`
+	rows, err := ds.Conn.QueryContext(ds.Ctx, query)
+	if err != nil {
+		r.log.Error("execution failed", slog.String("q", query))
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto poolRefDS, err error) {
+		err = rows.Scan(&dto.PoolID, &dto.ProcID)
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed")
+		return nil, err
+	}
+	refs, err := DataToPoolRefs(dtos)
+	if err != nil {
+		r.log.Error("mapping failed")
+		return nil, err
+	}
+	return refs, nil
+}
+
+// sqlite has neither distinct on nor jsonb, the rest is shared
+const (
+	insertBndSql = `
+		insert into proc_bnds (
+			proc_id, chnl_ph, chnl_id, state_id, rev
+		) values (
+			@proc_id, @chnl_ph, @chnl_id, @state_id, @rev
+		)`
+
+	insertStepSql = `
+		insert into proc_steps (
+			id, proc_id, chnl_id, kind, spec, created_at
+		) values (
+			@id, @proc_id, @chnl_id, @kind, @spec, @created_at
+		)`
+
+	selectIdemSql = `
+		select
+			pool_id, idem_key, kind, proc_id, rev
+		from pool_idems
+		where pool_id = ?1
+			and idem_key = ?2`
+
+	selectSupSql = `
+		select
+			sup_pool_id
+		from pool_roots
+		where pool_id = ?`
+
+	latestLiabsSql = `
+		liabs as (
+			select * from (
+				select
+					liab.*,
+					row_number() over (partition by proc_id order by abs(rev) desc) as pos
+				from pool_liabs liab
+			)
+			where pos = 1
+		)`
+
+	latestBndsSql = `
+		bnds as (
+			select * from (
+				select
+					bnd.*,
+					row_number() over (partition by proc_id, chnl_ph order by abs(rev) desc) as pos
+				from proc_bnds bnd
+			)
+			where pos = 1
+		)`
+
+	countLiveSql = `
+		with` + latestLiabsSql + `
+		select
+			count(*)
+		from liabs
+		where pool_id = ?
+			and rev > 0`
+
+	selectCompsSql = `
+		select
+			pool_id, proc_id, rev, spec
+		from proc_comps
+		where proc_id = ?
+		order by rev desc`
+
+	deleteCompSql = `
+		delete from proc_comps
+		where proc_id = ?1
+			and rev = ?2`
+
+	// archived entries keep resolving
+	selectJournalSql = `
+		select
+			pool_id, proc_id, kind, rev, detail, created_at
+		from proc_journal
+		where proc_id = ?1
+		union all
+		select
+			entry.value ->> 'pool_id',
+			entry.value ->> 'proc_id',
+			entry.value ->> 'kind',
+			entry.value ->> 'rev',
+			entry.value ->> 'detail',
+			entry.value ->> 'created_at'
+		from proc_archive arch, json_each(arch.journal) entry
+		where arch.proc_id = ?1
+		order by 6, 4`
+
+	// every channel got its negative binding and nothing is pending
+	selectClosedSql = `
+		with` + latestBndsSql + `
+		select latest.proc_id
+		from bnds latest
+		group by latest.proc_id
+		having max(latest.rev >= 0) = 0
+			and not exists (
+				select 1 from proc_steps step
+				where step.proc_id = latest.proc_id
+			)
+			-- a process without journal entries counts from its creation
+			and coalesce((
+				select max(entry.created_at) from proc_journal entry
+				where entry.proc_id = latest.proc_id
+			), (
+				select min(liab.created_at) from pool_liabs liab
+				where liab.proc_id = latest.proc_id
+			)) < ?1
+		limit ?2`
+
+	insertArchiveSql = `
+		insert into proc_archive (
+			proc_id, pool_id, bnds, liabs, journal, archived_at
+		)
+		select
+			?1,
+			(select liab.pool_id from pool_liabs liab
+				where liab.proc_id = ?1
+				order by abs(liab.rev) desc limit 1),
+			(select json_group_array(json_object(
+					'proc_id', bnd.proc_id, 'chnl_ph', bnd.chnl_ph, 'chnl_id', bnd.chnl_id,
+					'state_id', bnd.state_id, 'rev', bnd.rev))
+				from proc_bnds bnd
+				where bnd.proc_id = ?1),
+			(select json_group_array(json_object(
+					'pool_id', liab.pool_id, 'proc_id', liab.proc_id,
+					'rev', liab.rev, 'priority', liab.priority))
+				from pool_liabs liab
+				where liab.proc_id = ?1),
+			(select json_group_array(json_object(
+					'pool_id', entry.pool_id, 'proc_id', entry.proc_id, 'kind', entry.kind,
+					'rev', entry.rev, 'detail', entry.detail, 'created_at', entry.created_at))
+				from (select * from proc_journal
+					where proc_id = ?1
+					order by created_at, rev) entry),
+			?2`
+
+	insertLiabSql = `
+		insert into pool_liabs (
+			pool_id, proc_id, rev, priority, created_at
+		) values (
+			@pool_id, @proc_id, @rev, @priority, @created_at
+		)`
+
+	deleteBndsSql = `
+		delete from proc_bnds
+		where proc_id = ?`
+
+	deleteLiabsSql = `
+		delete from pool_liabs
+		where proc_id = ?`
+
+	deleteJournalSql = `
+		delete from proc_journal
+		where proc_id = ?`
+
+	deleteCompsSql = `
+		delete from proc_comps
+		where proc_id = ?`
+
+	deleteTermsSql = `
+		delete from proc_steps
+		where proc_id = ?`
+
+	// the states reachable from roles and bindings survive with their subtrees
+	deleteOrphansSql = `
+		with recursive live(id) as (
+			select state_id from role_states
+			union
+			select state_id from proc_bnds
+			union
+			select sub.id
+			from states sub
+			join live
+				on sub.from_id = live.id
+		)
+		delete from states
+		where id not in (
+			select id from live
+			where id is not null
+		)`
+
+	selectOrgSnapSql = `
+		select
+			sup.pool_id,
+			sup.title,
+			json_group_array(json_object('pool_id', sub.pool_id, 'proc_id', sub.proc_id))
+				filter (where sub.pool_id is not null) as subs
+		from pool_roots sup
+		left join pool_sups rel
+			on rel.sup_pool_id = sup.pool_id
+		left join pool_roots sub
+			on sub.pool_id = rel.pool_id
+			and sub.rev = rel.rev
+		where sup.pool_id = ?
+		group by sup.pool_id, sup.title`
+
+	selectChnlsSql = `
+		with` + latestBndsSql + `,` + latestLiabsSql + `
+		select
+			bnd.proc_id, bnd.chnl_ph, bnd.chnl_id, bnd.state_id,
+			liab.pool_id
+		from bnds bnd
+		left join liabs liab
+			on liab.proc_id = bnd.proc_id
+		where bnd.proc_id = ?`
+
+	// pending steps on the channels the process is bound to
+	selectStepsSql = `
+		with` + latestBndsSql + `
+		select
+			step.id, step.kind, step.proc_id, step.chnl_id, step.spec
+		from proc_steps step
+		join bnds bnd
+			on bnd.chnl_id = step.chnl_id
+		where bnd.proc_id = ?`
+
+	selectPendingSql = `
+		with` + latestLiabsSql + `, pools as (
+			select pool_id from pool_roots where pool_id = ?1
+			union
+			select pool_id from pool_roots where sup_pool_id = ?1
+		)
+		select
+			liab.pool_id,
+			step.proc_id,
+			liab.priority,
+			step.created_at
+		from proc_steps step
+		join liabs liab
+			on liab.proc_id = step.proc_id
+			and liab.rev > 0
+		join pools pool
+			on pool.pool_id = liab.pool_id
+		left join poll_claims claim
+			on claim.proc_id = step.proc_id
+			and claim.claimed_until > ?2
+		where claim.proc_id is null`
+
+	deleteClaimSql = `
+		delete from poll_claims
+		where proc_id = ?`
+
+	deleteStepSql = `
+		delete from proc_steps
+		where chnl_id = ?`
+)
@@ -0,0 +1,258 @@
+package exec
+
+import (
+	"encoding/json"
+	"sync"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/outbox"
+	"orglang/orglang/avt/rn"
+
+	poolledger "orglang/orglang/aat/pool/ledger"
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+)
+
+type EventKind string
+
+const (
+	StepTaken       = EventKind("step-taken")
+	HalfStepPending = EventKind("half-step-pending")
+	ProcClosed      = EventKind("proc-closed")
+	LiabChanged     = EventKind("liab-changed")
+)
+
+// domain events recorded in the outbox
+const (
+	ProcSpawned   = outbox.Kind("proc.spawned")
+	StepCommitted = outbox.Kind("step.taken")
+	LiabMoved     = outbox.Kind("liab.moved")
+)
+
+type Event struct {
+	PoolID id.ADT
+	ProcID id.ADT
+	ChnlID id.ADT
+	Kind   EventKind
+	PoolRN rn.ADT
+	// numbers the events of a pool one by one, unlike the revision
+	Seq int64
+}
+
+type WatchSpec struct {
+	PoolID id.ADT
+	// events after this sequence number get replayed first
+	Seq int64
+}
+
+type Subscription struct {
+	Events <-chan Event
+	Cancel func()
+}
+
+// in-process fan-out of committed events,
+// the replay comes from the outbox
+type eventHub struct {
+	mu    sync.Mutex
+	subs  map[id.ADT]map[chan Event]struct{}
+	limit int
+}
+
+func newEventHub(p *props) *eventHub {
+	return &eventHub{
+		subs:  make(map[id.ADT]map[chan Event]struct{}),
+		limit: p.Events.Buffer,
+	}
+}
+
+func (h *eventHub) Subscribe(poolID id.ADT) Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan Event, h.limit)
+	if h.subs[poolID] == nil {
+		h.subs[poolID] = make(map[chan Event]struct{})
+	}
+	h.subs[poolID][ch] = struct{}{}
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() { h.unsubscribe(poolID, ch) })
+	}
+	return Subscription{Events: ch, Cancel: cancel}
+}
+
+func (h *eventHub) unsubscribe(poolID id.ADT, ch chan Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.subs[poolID][ch]
+	if !ok {
+		return
+	}
+	h.drop(poolID, ch)
+}
+
+// pools without subscribers leave nothing behind
+func (h *eventHub) drop(poolID id.ADT, ch chan Event) {
+	delete(h.subs[poolID], ch)
+	if len(h.subs[poolID]) == 0 {
+		delete(h.subs, poolID)
+	}
+	close(ch)
+}
+
+func (h *eventHub) Publish(events ...Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ev := range events {
+		for ch := range h.subs[ev.PoolID] {
+			select {
+			case ch <- ev:
+			default:
+				// slow subscribers get dropped and resume by sequence number
+				h.drop(ev.PoolID, ch)
+				metrics.Add("events_dropped", 1)
+			}
+		}
+	}
+}
+
+// hands out the replayed events first, then the live ones not replayed yet
+func resume(live Subscription, replay []Event, after int64) Subscription {
+	out := make(chan Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(out)
+		for _, ev := range replay {
+			select {
+			case out <- ev:
+				after = ev.Seq
+			case <-done:
+				return
+			}
+		}
+		for ev := range live.Events {
+			if ev.Seq <= after {
+				continue
+			}
+			select {
+			case out <- ev:
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			live.Cancel()
+		})
+	}
+	return Subscription{Events: out, Cancel: cancel}
+}
+
+// restores the event from the outbox payload
+func eventFromOutbox(ev outbox.Event) (Event, error) {
+	var dto EventME
+	err := json.Unmarshal(ev.Payload, &dto)
+	if err != nil {
+		return Event{}, err
+	}
+	event, err := MsgToEvent(dto)
+	if err != nil {
+		return Event{}, err
+	}
+	event.Seq = ev.Seq
+	return event, nil
+}
+
+// derives events from the modification committed for the step
+func collectEvents(
+	spec StepSpec,
+	procCfg procexec.Cfg,
+	procMod procexec.Mod,
+) []Event {
+	var events []Event
+	for _, step := range procMod.Steps {
+		switch rec := step.(type) {
+		case procexec.MsgRec:
+			events = append(events, Event{PoolID: rec.PoolID, ProcID: rec.ProcID, ChnlID: rec.ChnlID, Kind: HalfStepPending, PoolRN: rec.PoolRN})
+		case procexec.SvcRec:
+			events = append(events, Event{PoolID: rec.PoolID, ProcID: rec.ProcID, ChnlID: rec.ChnlID, Kind: HalfStepPending, PoolRN: rec.PoolRN})
+		}
+	}
+	viaChnl := procCfg.Chnls[spec.ProcTS.Via()]
+	// the revision the step lock advances the pool to
+	poolRN := procCfg.PoolRN.Next()
+	if len(procMod.Steps) == 0 {
+		events = append(events, Event{PoolID: procCfg.PoolID, ProcID: spec.ProcID, ChnlID: viaChnl.ChnlID, Kind: StepTaken, PoolRN: poolRN})
+		closedID := closedProc(spec, procCfg, viaChnl.ChnlID)
+		if !closedID.IsEmpty() {
+			events = append(events, Event{PoolID: procCfg.PoolID, ProcID: closedID, ChnlID: viaChnl.ChnlID, Kind: ProcClosed, PoolRN: poolRN})
+		}
+	}
+	for _, liab := range procMod.Liabs {
+		liabRN := liab.PoolRN
+		if liabRN < 0 {
+			liabRN = -liabRN
+		}
+		events = append(events, Event{PoolID: liab.PoolID, ProcID: liab.ProcID, Kind: LiabChanged, PoolRN: liabRN})
+	}
+	return events
+}
+
+// liabilities move on their own, everything else comes with the step
+func outboxSpec(ev Event) outbox.EventSpec {
+	kind := StepCommitted
+	if ev.Kind == LiabChanged {
+		kind = LiabMoved
+	}
+	return outbox.EventSpec{Kind: kind, SubjectID: ev.ProcID, StreamID: ev.PoolID, Payload: MsgFromEvent(ev)}
+}
+
+// the provider side of a completed close-wait exchange terminates
+func closedProc(spec StepSpec, procCfg procexec.Cfg, chnlID id.ADT) id.ADT {
+	switch spec.ProcTS.(type) {
+	case procdef.CloseSpec:
+		return spec.ProcID
+	case procdef.WaitSpec:
+		msgStep, ok := procCfg.Steps[chnlID].(procexec.MsgRec)
+		if !ok {
+			return id.Empty()
+		}
+		_, ok = msgStep.Val.(procdef.CloseRec)
+		if !ok {
+			return id.Empty()
+		}
+		return msgStep.ProcID
+	default:
+		return id.Empty()
+	}
+}
+
+// attributes the completed step to the pool liable for the process
+func ledgerEntry(spec StepSpec, procCfg procexec.Cfg) poolledger.EntrySpec {
+	viaChnl := procCfg.Chnls[spec.ProcTS.Via()]
+	entry := poolledger.EntrySpec{
+		PoolID:  procCfg.PoolID,
+		ProcID:  spec.ProcID,
+		ChnlID:  viaChnl.ChnlID,
+		PoolRN:  procCfg.PoolRN.Next(),
+		AgentQN: spec.AgentQN,
+	}
+	switch termSpec := spec.ProcTS.(type) {
+	case procdef.LabSpec:
+		entry.Label = termSpec.Label
+	case procdef.SpawnSpecOld:
+		entry.SigID = termSpec.SigID
+	case procdef.CaseSpec:
+		msgStep, ok := procCfg.Steps[viaChnl.ChnlID].(procexec.MsgRec)
+		if !ok {
+			break
+		}
+		labRec, ok := msgStep.Val.(procdef.LabRec)
+		if ok {
+			entry.Label = labRec.Label
+		}
+	}
+	return entry
+}
@@ -0,0 +1,157 @@
+package exec
+
+import (
+	"context"
+	"testing"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+
+	poolledger "orglang/orglang/aat/pool/ledger"
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+)
+
+func TestCollectEventsTakesLoadedPool(t *testing.T) {
+	spec := StepSpec{
+		PoolID: id.New(),
+		ProcID: id.New(),
+		ProcTS: procdef.CloseSpec{CommPH: sym.New("x")},
+	}
+	chnlID := id.New()
+	procCfg := procexec.Cfg{
+		Chnls:  map[sym.ADT]procexec.EP{sym.New("x"): {ChnlPH: sym.New("x"), ChnlID: chnlID}},
+		PoolID: id.New(),
+		PoolRN: rn.ADT(7),
+	}
+	events := collectEvents(spec, procCfg, procexec.Mod{})
+	want := []Event{
+		{PoolID: procCfg.PoolID, ProcID: spec.ProcID, ChnlID: chnlID, Kind: StepTaken, PoolRN: rn.ADT(8)},
+		{PoolID: procCfg.PoolID, ProcID: spec.ProcID, ChnlID: chnlID, Kind: ProcClosed, PoolRN: rn.ADT(8)},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("want %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("want %v, got %v", want[i], events[i])
+		}
+	}
+}
+
+func TestLedgerEntryCreditsLoadedPool(t *testing.T) {
+	spec := StepSpec{
+		PoolID:  id.New(),
+		ProcID:  id.New(),
+		ProcTS:  procdef.LabSpec{CommPH: sym.New("x"), Label: sym.New("ok")},
+		AgentQN: sym.New("alice"),
+	}
+	chnlID := id.New()
+	// the client may name any pool
+	procCfg := procexec.Cfg{
+		Chnls:  map[sym.ADT]procexec.EP{sym.New("x"): {ChnlPH: sym.New("x"), ChnlID: chnlID}},
+		PoolID: id.New(),
+		PoolRN: rn.ADT(4),
+	}
+	want := poolledger.EntrySpec{
+		PoolID:  procCfg.PoolID,
+		ProcID:  spec.ProcID,
+		ChnlID:  chnlID,
+		PoolRN:  rn.ADT(5),
+		AgentQN: spec.AgentQN,
+		Label:   sym.New("ok"),
+	}
+	if got := ledgerEntry(spec, procCfg); got != want {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestEventHubDelivery(t *testing.T) {
+	hub := newEventHub(&props{Events: events{Buffer: 8}})
+	poolID := id.New()
+	sub := hub.Subscribe(poolID)
+	defer sub.Cancel()
+	other := Event{PoolID: id.New(), Kind: StepTaken, PoolRN: rn.ADT(2)}
+	ev := Event{PoolID: poolID, Kind: StepTaken, PoolRN: rn.ADT(2)}
+	hub.Publish(other, ev)
+	select {
+	case got := <-sub.Events:
+		if got != ev {
+			t.Fatalf("want %v, got %v", ev, got)
+		}
+	default:
+		t.Fatal("not delivered")
+	}
+	select {
+	case got := <-sub.Events:
+		t.Fatalf("delivered from another pool: %v", got)
+	default:
+	}
+}
+
+func TestEventHubForgetsIdlePools(t *testing.T) {
+	hub := newEventHub(&props{Events: events{Buffer: 1}})
+	cancelledID, droppedID := id.New(), id.New()
+	hub.Subscribe(cancelledID).Cancel()
+	dropped := hub.Subscribe(droppedID)
+	for range 2 {
+		hub.Publish(Event{PoolID: droppedID, Kind: StepTaken})
+	}
+	<-dropped.Events
+	if _, ok := <-dropped.Events; ok {
+		t.Fatal("open after drop")
+	}
+	if len(hub.subs) != 0 {
+		t.Fatalf("want no pools left, got %v", len(hub.subs))
+	}
+}
+
+func TestResumeSkipsReplayedEvents(t *testing.T) {
+	hub := newEventHub(&props{Events: events{Buffer: 8}})
+	poolID := id.New()
+	// the events of a single step share the revision
+	step := func(seq int64, kind EventKind) Event {
+		return Event{PoolID: poolID, Kind: kind, PoolRN: rn.ADT(3), Seq: seq}
+	}
+	live := hub.Subscribe(poolID)
+	sub := resume(live, []Event{step(2, StepTaken), step(3, ProcClosed)}, 1)
+	defer sub.Cancel()
+	hub.Publish(step(3, ProcClosed), step(4, LiabChanged))
+	var got []int64
+	for len(got) < 3 {
+		got = append(got, (<-sub.Events).Seq)
+	}
+	if got[0] != 2 || got[1] != 3 || got[2] != 4 {
+		t.Fatalf("want events 2, 3 and 4, got %v", got)
+	}
+	sub.Cancel()
+	if _, ok := <-sub.Events; ok {
+		t.Fatal("open after cancel")
+	}
+}
+
+func TestWatchReplaysFromOutbox(t *testing.T) {
+	s, pools := newServiceStub()
+	ctx := context.Background()
+	pool := pools.create()
+	for range 3 {
+		_, err := s.Spawn(ctx, procexec.ProcSpec{PoolID: pool.ExecID})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the hub saw none of it, as if the spawns ran on another instance
+	s.events = newEventHub(&props{Events: events{Buffer: 8}})
+	sub, err := s.Watch(ctx, WatchSpec{PoolID: pool.ExecID, Seq: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Cancel()
+	for _, want := range []int64{2, 3} {
+		got := <-sub.Events
+		if got.Seq != want || got.Kind != LiabChanged {
+			t.Fatalf("want event %v, got %v", want, got)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
 	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/msg"
+	"orglang/orglang/avt/sym"
 )
 
 func (dto PoolSpecME) Validate() error {
@@ -12,10 +14,55 @@ func (dto PoolSpecME) Validate() error {
 	)
 }
 
+func (dto IdentME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.PoolID, id.Required...),
+	)
+}
+
 func (dto StepSpecME) Validate() error {
 	return validation.ValidateStruct(&dto,
 		validation.Field(&dto.PoolID, id.Required...),
 		validation.Field(&dto.ProcID, id.Required...),
 		validation.Field(&dto.Term, validation.Required),
+		validation.Field(&dto.IdemKey, msg.IdemKeyOptional...),
+		validation.Field(&dto.AgentQN, sym.Optional...),
+	)
+}
+
+func (dto ProcIdentME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.ProcID, id.Required...),
+	)
+}
+
+func (dto CancelSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.PoolID, id.Required...),
+		validation.Field(&dto.ProcID, id.Required...),
+		validation.Field(&dto.Reason, validation.Length(0, 1024)),
+	)
+}
+
+func (dto WatchSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.PoolID, id.Required...),
+		validation.Field(&dto.Seq, validation.Min(int64(0))),
+	)
+}
+
+func (dto QuotaME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.PoolID, id.Required...),
+		validation.Field(&dto.LiveProcs, validation.Min(0)),
+		validation.Field(&dto.Spawns),
+		validation.Field(&dto.Steps),
+	)
+}
+
+func (dto RateME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.Limit, validation.Min(0)),
+		validation.Field(&dto.IntervalMS, validation.Min(int64(0))),
 	)
 }
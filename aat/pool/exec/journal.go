@@ -0,0 +1,230 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+)
+
+type JournalKind string
+
+const (
+	StepJournaled        = JournalKind("step")
+	CancelJournaled      = JournalKind("cancel")
+	FailJournaled        = JournalKind("fail")
+	CompensateJournaled  = JournalKind("compensate")
+	CompFailureJournaled = JournalKind("compensate-failed")
+)
+
+type JournalEntry struct {
+	PoolID id.ADT
+	ProcID id.ADT
+	Kind   JournalKind
+	PoolRN rn.ADT
+	Detail string
+	At     time.Time
+}
+
+// compensation registered by a committed step
+type CompRec struct {
+	PoolID id.ADT
+	ProcID id.ADT
+	PoolRN rn.ADT
+	CompTS procdef.TermSpec
+}
+
+type CancelSpec struct {
+	PoolID id.ADT
+	ProcID id.ADT
+	Reason string
+}
+
+func (s *service) Cancel(ctx context.Context, spec CancelSpec) error {
+	idAttr := slog.Any("procID", spec.ProcID)
+	s.log.Debug("cancelation started", idAttr)
+	idemKey := cancelKey(spec.ProcID)
+	// the process is out of the pool once canceled, so the key goes first
+	_, found, err := s.replayed(ctx, spec.PoolID, idemKey, CancelIdem)
+	if err != nil {
+		s.log.Error("cancelation failed", idAttr)
+		return err
+	}
+	if found {
+		return nil
+	}
+	var procCfg procexec.Cfg
+	err = s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) error {
+		procCfg, err = s.pools.SelectProc(ds, spec.ProcID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("cancelation failed", idAttr)
+		return err
+	}
+	if len(procCfg.Chnls) == 0 || procCfg.PoolID != spec.PoolID {
+		err = errMissingProc(spec.ProcID)
+		s.log.Error("cancelation failed", idAttr, slog.Any("poolID", spec.PoolID))
+		return err
+	}
+	// every compensation is a step of its own
+	err = s.compensate(ctx, spec.PoolID, spec.ProcID)
+	if err != nil {
+		s.log.Error("cancelation failed", idAttr)
+		return err
+	}
+	err = s.retrying(ctx, idAttr, func() error {
+		return s.operator.Explicit(ctx, func(ds data.Source) error {
+			// a concurrent cancel got there first
+			_, err := s.pools.SelectIdem(ds, spec.PoolID, idemKey)
+			if err == nil {
+				return nil
+			}
+			if !fault.Is(err, fault.NotFound) {
+				return err
+			}
+			procCfg, err := s.pools.SelectProc(ds, spec.ProcID)
+			if err != nil {
+				return err
+			}
+			// takes the process out of its pool so that nobody steps it anymore
+			err = s.pools.UpdateProc(ds, procexec.Mod{
+				Locks: []procexec.Lock{{PoolID: procCfg.PoolID, PoolRN: procCfg.PoolRN}},
+				Liabs: []procexec.Liab{{
+					PoolID: procCfg.PoolID,
+					ProcID: spec.ProcID,
+					PoolRN: -procCfg.PoolRN.Next(),
+				}},
+			})
+			if err != nil {
+				return err
+			}
+			err = s.pools.InsertJournal(ds, JournalEntry{
+				PoolID: spec.PoolID,
+				ProcID: spec.ProcID,
+				Kind:   CancelJournaled,
+				PoolRN: procCfg.PoolRN.Next(),
+				Detail: spec.Reason,
+				At:     time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			return s.pools.InsertIdem(ds, IdemRec{
+				PoolID:  spec.PoolID,
+				IdemKey: idemKey,
+				Kind:    CancelIdem,
+				ProcID:  spec.ProcID,
+				PoolRN:  procCfg.PoolRN.Next(),
+			})
+		})
+	})
+	if err != nil {
+		s.log.Error("cancelation failed", idAttr)
+		return err
+	}
+	s.log.Debug("cancelation succeeded", idAttr)
+	return nil
+}
+
+// one cancelation per process, whoever asks
+func cancelKey(procID id.ADT) string {
+	return "cancel:" + procID.String()
+}
+
+func (s *service) RetrieveJournal(ctx context.Context, procID id.ADT) (entries []JournalEntry, err error) {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		entries, err = s.pools.SelectJournal(ds, procID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("retrieval failed", slog.Any("procID", procID))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// fails the process and undoes its completed steps
+func (s *service) fail(ctx context.Context, spec StepSpec, reason error) {
+	idAttr := slog.Any("procID", spec.ProcID)
+	err := s.journal(ctx, JournalEntry{
+		PoolID: spec.PoolID,
+		ProcID: spec.ProcID,
+		Kind:   FailJournaled,
+		Detail: reason.Error(),
+	})
+	if err != nil {
+		s.log.Error("journaling failed", idAttr, slog.Any("reason", err))
+	}
+	err = s.compensate(ctx, spec.PoolID, spec.ProcID)
+	if err != nil {
+		s.log.Error("compensation failed", idAttr, slog.Any("reason", err))
+	}
+}
+
+// runs registered compensations latest first, saga style
+func (s *service) compensate(ctx context.Context, poolID id.ADT, procID id.ADT) error {
+	idAttr := slog.Any("procID", procID)
+	var comps []CompRec
+	err := s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) (err error) {
+		comps, err = s.pools.SelectComps(ds, procID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, comp := range comps {
+		rnAttr := slog.Any("poolRN", comp.PoolRN)
+		err = s.take(ctx, StepSpec{
+			PoolID: comp.PoolID,
+			ProcID: comp.ProcID,
+			ProcTS: comp.CompTS,
+		})
+		if err != nil {
+			s.log.Error("compensation failed", idAttr, rnAttr)
+			journalErr := s.journal(ctx, JournalEntry{
+				PoolID: poolID,
+				ProcID: procID,
+				Kind:   CompFailureJournaled,
+				PoolRN: comp.PoolRN,
+				Detail: err.Error(),
+			})
+			// the saga stays half undone whatever the step failed with
+			return fault.Wrap(fault.StateCorruption, errors.Join(err, journalErr))
+		}
+		err = s.operator.Explicit(ctx, func(ds data.Source) error {
+			err := s.pools.DeleteComp(ds, comp)
+			if err != nil {
+				return err
+			}
+			return s.pools.InsertJournal(ds, JournalEntry{
+				PoolID: poolID,
+				ProcID: procID,
+				Kind:   CompensateJournaled,
+				PoolRN: comp.PoolRN,
+				At:     time.Now(),
+			})
+		})
+		if err != nil {
+			return err
+		}
+		metrics.Add("compensations", 1)
+		s.log.Debug("compensation succeeded", idAttr, rnAttr)
+	}
+	return nil
+}
+
+func (s *service) journal(ctx context.Context, entry JournalEntry) error {
+	entry.At = time.Now()
+	return s.operator.Explicit(ctx, func(ds data.Source) error {
+		return s.pools.InsertJournal(ds, entry)
+	})
+}
@@ -0,0 +1,151 @@
+package exec
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+	typedef "orglang/orglang/aat/type/def"
+)
+
+func TestCancelRevokesLiability(t *testing.T) {
+	s, pools := newServiceStub()
+	procID := newProcStub(s, pools)
+	poolID := pools.procs[procID].PoolID
+	pools.comps = []CompRec{{PoolID: poolID, ProcID: procID, PoolRN: 2, CompTS: procdef.CloseSpec{CommPH: sym.New("x")}}}
+	err := s.Cancel(context.Background(), CancelSpec{PoolID: poolID, ProcID: procID, Reason: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools.comps) != 0 {
+		t.Fatalf("want no comps, got %v", pools.comps)
+	}
+	// the compensation step, then the revocation
+	want := procexec.Liab{PoolID: poolID, ProcID: procID, PoolRN: -3}
+	if got := pools.liabs[len(pools.liabs)-1]; got != want {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	kinds := journalKinds(pools.journal)
+	if kinds != "step compensate cancel" {
+		t.Fatalf("want step, compensate and cancel, got %v", kinds)
+	}
+}
+
+func TestCancelTwiceRevokesOnce(t *testing.T) {
+	s, pools := newServiceStub()
+	procID := newProcStub(s, pools)
+	poolID := pools.procs[procID].PoolID
+	spec := CancelSpec{PoolID: poolID, ProcID: procID, Reason: "test"}
+	for range 2 {
+		err := s.Cancel(context.Background(), spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(pools.liabs) != 1 || pools.liabs[0].PoolRN >= 0 {
+		t.Fatalf("want a single revocation, got %v", pools.liabs)
+	}
+	kinds := journalKinds(pools.journal)
+	if kinds != "cancel" {
+		t.Fatalf("want a single cancel, got %v", kinds)
+	}
+}
+
+func TestCancelRejectsForeignPool(t *testing.T) {
+	s, pools := newServiceStub()
+	procID := newProcStub(s, pools)
+	foreign := pools.create()
+	err := s.Cancel(context.Background(), CancelSpec{PoolID: foreign.ExecID, ProcID: procID})
+	if !fault.Is(err, fault.NotFound) {
+		t.Fatalf("want not found, got %v", err)
+	}
+	if len(pools.liabs) != 0 || len(pools.journal) != 0 {
+		t.Fatalf("want nothing written, got %v and %v", pools.liabs, pools.journal)
+	}
+}
+
+func TestCancelStopsOnFailedComp(t *testing.T) {
+	s, pools := newServiceStub()
+	procID := newProcStub(s, pools)
+	poolID := pools.procs[procID].PoolID
+	// latest first, the second one refers to an unknown channel
+	earlier := CompRec{PoolID: poolID, ProcID: procID, PoolRN: 1, CompTS: procdef.CloseSpec{CommPH: sym.New("y")}}
+	latest := CompRec{PoolID: poolID, ProcID: procID, PoolRN: 2, CompTS: procdef.CloseSpec{CommPH: sym.New("x")}}
+	pools.comps = []CompRec{earlier, latest}
+	err := s.Cancel(context.Background(), CancelSpec{PoolID: poolID, ProcID: procID})
+	if !fault.Is(err, fault.StateCorruption) {
+		t.Fatalf("want state corruption, got %v", err)
+	}
+	if len(pools.comps) != 1 || pools.comps[0] != earlier {
+		t.Fatalf("want %v kept, got %v", earlier, pools.comps)
+	}
+	kinds := journalKinds(pools.journal)
+	if kinds != "step compensate compensate-failed" {
+		t.Fatalf("want the failure journaled, got %v", kinds)
+	}
+	// the process stays in the pool for another attempt
+	for _, liab := range pools.liabs {
+		if liab.PoolRN < 0 {
+			t.Fatalf("revoked: %v", liab)
+		}
+	}
+}
+
+func TestSelectCompsMem(t *testing.T) {
+	r := newDaoMem(slog.Default())
+	operator := data.NewOperatorMem()
+	procID := id.New()
+	var got []CompRec
+	err := operator.Explicit(context.Background(), func(ds data.Source) (err error) {
+		for _, poolRN := range []rn.ADT{2, 5, 3} {
+			err = r.InsertComp(ds, CompRec{PoolID: id.New(), ProcID: procID, PoolRN: poolRN, CompTS: procdef.CloseSpec{CommPH: sym.New("x")}})
+			if err != nil {
+				return err
+			}
+		}
+		got, err = r.SelectComps(ds, procID)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].PoolRN != 5 || got[1].PoolRN != 3 || got[2].PoolRN != 2 {
+		t.Fatalf("want revisions 5, 3 and 2, got %v", got)
+	}
+}
+
+// a process providing x of type one in a fresh pool
+func newProcStub(s *service, pools *repoStub) id.ADT {
+	pool := pools.create()
+	procID, termID := id.New(), id.New()
+	pools.procs = map[id.ADT]procexec.Cfg{
+		procID: {
+			ProcID: procID,
+			PoolID: pool.ExecID,
+			Chnls: map[sym.ADT]procexec.EP{
+				sym.New("x"): {ChnlPH: sym.New("x"), ChnlID: id.New(), TermID: termID, PoolID: pool.ExecID},
+			},
+		},
+	}
+	s.types.(*typesStub).terms = map[id.ADT]typedef.TermRec{termID: typedef.OneRec{}}
+	return procID
+}
+
+func journalKinds(entries []JournalEntry) string {
+	var kinds string
+	for i, entry := range entries {
+		if i > 0 {
+			kinds += " "
+		}
+		kinds += string(entry.Kind)
+	}
+	return kinds
+}
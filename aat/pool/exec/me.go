@@ -1,6 +1,8 @@
 package exec
 
 import (
+	"time"
+
 	procdef "orglang/orglang/aat/proc/def"
 )
 
@@ -26,7 +28,58 @@ type PoolSnapME struct {
 }
 
 type StepSpecME struct {
-	PoolID string             `json:"pool_id"`
+	PoolID string             `json:"pool_id" param:"id"`
 	ProcID string             `json:"proc_id"`
 	Term   procdef.TermSpecME `json:"term"`
+	// aka Idempotency-Key header
+	IdemKey string `json:"idem_key,omitempty"`
+	// compensation of the step
+	Comp *procdef.TermSpecME `json:"comp,omitempty"`
+	// credited in the ledger
+	AgentQN string `json:"agent_qn,omitempty"`
+}
+
+type ProcIdentME struct {
+	ProcID string `param:"id"`
+}
+
+type CancelSpecME struct {
+	PoolID string `json:"pool_id" param:"id"`
+	ProcID string `json:"proc_id" param:"proc_id"`
+	Reason string `json:"reason"`
+}
+
+type QuotaME struct {
+	PoolID    string `json:"pool_id" param:"id"`
+	LiveProcs int    `json:"live_procs"`
+	Spawns    RateME `json:"spawns"`
+	Steps     RateME `json:"steps"`
+}
+
+type RateME struct {
+	Limit      int   `json:"limit"`
+	IntervalMS int64 `json:"interval_ms"`
+}
+
+type JournalEntryME struct {
+	PoolID string    `json:"pool_id"`
+	ProcID string    `json:"proc_id"`
+	Kind   string    `json:"kind"`
+	PoolRN int64     `json:"rev"`
+	Detail string    `json:"detail,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+type WatchSpecME struct {
+	PoolID string `json:"pool_id" param:"id"`
+	Seq    int64  `json:"seq" query:"seq"`
+}
+
+type EventME struct {
+	PoolID string `json:"pool_id"`
+	ProcID string `json:"proc_id"`
+	ChnlID string `json:"chnl_id,omitempty"`
+	Kind   string `json:"kind"`
+	PoolRN int64  `json:"rev"`
+	Seq    int64  `json:"seq,omitempty"`
 }
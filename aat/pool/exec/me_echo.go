@@ -1,9 +1,12 @@
 package exec
 
 import (
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -44,7 +47,7 @@ func (h *handlerEcho) PostOne(c echo.Context) error {
 		h.log.Error("mapping failed", qnAttr)
 		return err
 	}
-	ref, err := h.api.Create(spec)
+	ref, err := h.api.Create(c.Request().Context(), spec)
 	if err != nil {
 		return err
 	}
@@ -61,7 +64,7 @@ func (h *handlerEcho) GetOne(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	snap, err := h.api.Retrieve(id)
+	snap, err := h.api.Retrieve(c.Request().Context(), id)
 	if err != nil {
 		return err
 	}
@@ -69,28 +72,199 @@ func (h *handlerEcho) GetOne(c echo.Context) error {
 }
 
 func (h *handlerEcho) PostProc(c echo.Context) error {
-	var dto PoolSpecME
+	var dto procexec.SpecME
+	err := (&echo.DefaultBinder{}).BindBody(c, &dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	dto.PoolID = c.Param("id")
+	idemKey := c.Request().Header.Get(msg.HeaderIdempotencyKey)
+	if idemKey != "" {
+		dto.IdemKey = idemKey
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec, err := procexec.MsgToSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	ref, err := h.api.Spawn(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, procexec.MsgFromRef(ref))
+}
+
+func (h *handlerEcho) GetEvents(c echo.Context) error {
+	var dto WatchSpecME
 	err := c.Bind(&dto)
 	if err != nil {
 		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
 		return err
 	}
-	qnAttr := slog.Any("sigQN", dto.SigQN)
+	// reconnecting clients resume from the last seen event
+	lastID := c.Request().Header.Get("Last-Event-ID")
+	if lastID != "" {
+		dto.Seq, err = strconv.ParseInt(lastID, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "malformed Last-Event-ID")
+		}
+	}
 	err = dto.Validate()
 	if err != nil {
-		h.log.Error("validation failed", qnAttr)
+		h.log.Error("validation failed", slog.Any("dto", dto))
 		return err
 	}
-	spec, err := MsgToPoolSpec(dto)
+	spec, err := MsgToWatchSpec(dto)
 	if err != nil {
-		h.log.Error("mapping failed", qnAttr)
+		h.log.Error("mapping failed", slog.Any("dto", dto))
 		return err
 	}
-	ref, err := h.api.Create(spec)
+	sub, err := h.api.Watch(c.Request().Context(), spec)
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusCreated, MsgFromPoolRef(ref))
+	defer sub.Cancel()
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, msg.MIMETextEventStream)
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+	ctx := c.Request().Context()
+	keepalive := time.NewTicker(15 * time.Second)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepalive.C:
+			err = msg.WriteSSEComment(w, "keepalive")
+			if err != nil {
+				return nil
+			}
+			w.Flush()
+		case ev, ok := <-sub.Events:
+			if !ok {
+				// dropped as too slow, the client resumes by sequence number
+				return nil
+			}
+			data, err := json.Marshal(MsgFromEvent(ev))
+			if err != nil {
+				h.log.Error("encoding failed", slog.Any("event", ev))
+				return nil
+			}
+			err = msg.WriteSSE(w, msg.SSE{
+				ID:    strconv.FormatInt(ev.Seq, 10),
+				Event: string(ev.Kind),
+				Data:  data,
+			})
+			if err != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
+
+func (h *handlerEcho) PostCancel(c echo.Context) error {
+	var dto CancelSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec, err := MsgToCancelSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	err = h.api.Cancel(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *handlerEcho) GetJournal(c echo.Context) error {
+	var dto ProcIdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	procID, err := id.ConvertFromString(dto.ProcID)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	entries, err := h.api.RetrieveJournal(c.Request().Context(), procID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromJournal(entries))
+}
+
+func (h *handlerEcho) GetQuota(c echo.Context) error {
+	var dto IdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	quota, err := h.api.RetrieveQuota(c.Request().Context(), poolID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromQuotaSpec(QuotaSpec{PoolID: poolID, Quota: quota}))
+}
+
+func (h *handlerEcho) PutQuota(c echo.Context) error {
+	var dto QuotaME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec, err := MsgToQuotaSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	err = h.api.SetQuota(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromQuotaSpec(spec))
 }
 
 // Adapter
@@ -106,12 +280,16 @@ func newStepHandlerEcho(a API, r msg.Renderer, l *slog.Logger) *stepHandlerEcho
 }
 
 func (h *stepHandlerEcho) PostOne(c echo.Context) error {
-	var dto procexec.SpecME
+	var dto StepSpecME
 	err := c.Bind(&dto)
 	if err != nil {
-		h.log.Error("binding failed")
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
 		return err
 	}
+	idemKey := c.Request().Header.Get(msg.HeaderIdempotencyKey)
+	if idemKey != "" {
+		dto.IdemKey = idemKey
+	}
 	ctx := c.Request().Context()
 	h.log.Log(ctx, core.LevelTrace, "posting started", slog.Any("dto", dto))
 	err = dto.Validate()
@@ -119,14 +297,14 @@ func (h *stepHandlerEcho) PostOne(c echo.Context) error {
 		h.log.Error("validation failed", slog.Any("dto", dto))
 		return err
 	}
-	spec, err := procexec.MsgToSpec(dto)
+	spec, err := MsgToStepSpec(dto)
 	if err != nil {
 		h.log.Error("mapping failed", slog.Any("dto", dto))
 		return err
 	}
-	ref, err := h.api.Spawn(spec)
+	err = h.api.Take(ctx, spec)
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, procexec.MsgFromRef(ref))
+	return c.NoContent(http.StatusOK)
 }
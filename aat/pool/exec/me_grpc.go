@@ -0,0 +1,252 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"google.golang.org/grpc"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/msg"
+	"orglang/orglang/rpc"
+
+	procexec "orglang/orglang/aat/proc/exec"
+)
+
+// Adapter
+type handlerGrpc struct {
+	rpc.UnimplementedPoolServiceServer
+	api API
+	log *slog.Logger
+}
+
+func newHandlerGrpc(a API, l *slog.Logger) *handlerGrpc {
+	name := slog.String("name", "poolHandlerGrpc")
+	return &handlerGrpc{api: a, log: l.With(name)}
+}
+
+func (h *handlerGrpc) CreatePool(ctx context.Context, req *rpc.CreatePoolRequest) (*rpc.PoolRef, error) {
+	dto := msgFromPoolSpecPB(req)
+	qnAttr := slog.Any("sigQN", dto.SigQN)
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", qnAttr)
+		return nil, err
+	}
+	spec, err := MsgToPoolSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", qnAttr)
+		return nil, err
+	}
+	ref, err := h.api.Create(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return pbFromPoolRefMsg(MsgFromPoolRef(ref)), nil
+}
+
+func (h *handlerGrpc) GetPool(ctx context.Context, req *rpc.GetPoolRequest) (*rpc.PoolSnap, error) {
+	dto := IdentME{PoolID: req.GetId()}
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := h.api.Retrieve(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	return pbFromPoolSnapMsg(MsgFromPoolSnap(snap)), nil
+}
+
+func (h *handlerGrpc) SpawnProc(ctx context.Context, req *rpc.SpawnProcRequest) (*rpc.ProcRef, error) {
+	dto := msgFromSpawnSpecPB(req)
+	idemKey := msg.IdemKeyGrpc(ctx)
+	if idemKey != "" {
+		dto.IdemKey = idemKey
+	}
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	spec, err := procexec.MsgToSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	ref, err := h.api.Spawn(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ProcRef{ProcId: procexec.MsgFromRef(ref).ProcID}, nil
+}
+
+func (h *handlerGrpc) CancelProc(ctx context.Context, req *rpc.CancelProcRequest) (*rpc.CancelProcResponse, error) {
+	dto := CancelSpecME{PoolID: req.GetPoolId(), ProcID: req.GetProcId(), Reason: req.GetReason()}
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	spec, err := MsgToCancelSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	err = h.api.Cancel(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.CancelProcResponse{}, nil
+}
+
+func (h *handlerGrpc) GetJournal(ctx context.Context, req *rpc.GetJournalRequest) (*rpc.GetJournalResponse, error) {
+	dto := ProcIdentME{ProcID: req.GetProcId()}
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	procID, err := id.ConvertFromString(dto.ProcID)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	entries, err := h.api.RetrieveJournal(ctx, procID)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.GetJournalResponse{Entries: pbFromJournalMsg(MsgFromJournal(entries))}, nil
+}
+
+func (h *handlerGrpc) WatchEvents(req *rpc.WatchEventsRequest, stream grpc.ServerStreamingServer[rpc.PoolEvent]) error {
+	dto := WatchSpecME{PoolID: req.GetPoolId(), Seq: req.GetSeq()}
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec, err := MsgToWatchSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	ctx := stream.Context()
+	sub, err := h.api.Watch(ctx, spec)
+	if err != nil {
+		return err
+	}
+	defer sub.Cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-sub.Events:
+			if !ok {
+				return errWatcherDropped(spec.PoolID)
+			}
+			err = stream.Send(pbFromEventMsg(MsgFromEvent(ev)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// no more than one process per request, so workers control the pace
+func (h *handlerGrpc) PollProcs(stream grpc.BidiStreamingServer[rpc.PollProcsRequest, rpc.ProcRef]) error {
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		dto := IdentME{PoolID: req.GetPoolId()}
+		err = dto.Validate()
+		if err != nil {
+			h.log.Error("validation failed", slog.Any("dto", dto))
+			return err
+		}
+		poolID, err := id.ConvertFromString(dto.PoolID)
+		if err != nil {
+			return err
+		}
+		ref, err := h.poll(ctx, poolID)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		err = stream.Send(&rpc.ProcRef{ProcId: procexec.MsgFromRef(ref).ProcID})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// polls over and over since a single poll gives up on the timeout
+func (h *handlerGrpc) poll(ctx context.Context, poolID id.ADT) (procexec.ProcRef, error) {
+	for {
+		ref, err := h.api.Poll(ctx, PollSpec{PoolID: poolID})
+		if err != nil {
+			return procexec.ProcRef{}, err
+		}
+		if !ref.ExecID.IsEmpty() || ctx.Err() != nil {
+			return ref, nil
+		}
+		h.log.Log(ctx, core.LevelTrace, "polling renewed", slog.Any("poolID", poolID))
+	}
+}
+
+// Adapter
+type stepHandlerGrpc struct {
+	rpc.UnimplementedStepServiceServer
+	api API
+	log *slog.Logger
+}
+
+func newStepHandlerGrpc(a API, l *slog.Logger) *stepHandlerGrpc {
+	name := slog.String("name", "stepHandlerGrpc")
+	return &stepHandlerGrpc{api: a, log: l.With(name)}
+}
+
+func (h *stepHandlerGrpc) TakeStep(ctx context.Context, req *rpc.TakeStepRequest) (*rpc.TakeStepResponse, error) {
+	dto := msgFromStepSpecPB(req)
+	idemKey := msg.IdemKeyGrpc(ctx)
+	if idemKey != "" {
+		dto.IdemKey = idemKey
+	}
+	h.log.Log(ctx, core.LevelTrace, "taking started", slog.Any("dto", dto))
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	spec, err := MsgToStepSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return nil, err
+	}
+	err = h.api.Take(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.TakeStepResponse{}, nil
+}
+
+func errWatcherDropped(poolID id.ADT) error {
+	return fault.New(fault.Unavailable, "watcher dropped as too slow, resume by sequence number: %v", poolID)
+}
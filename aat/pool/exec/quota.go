@@ -0,0 +1,148 @@
+package exec
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+)
+
+type CounterKind int8
+
+const (
+	noncounter CounterKind = iota
+	SpawnCounter
+	StepCounter
+)
+
+var counterNames = map[CounterKind]string{
+	SpawnCounter: "spawn",
+	StepCounter:  "step",
+}
+
+func (k CounterKind) String() string {
+	name, ok := counterNames[k]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// zero values mean unlimited
+type Quota struct {
+	LiveProcs int
+	Spawns    Rate
+	Steps     Rate
+}
+
+type Rate struct {
+	Limit    int
+	Interval time.Duration
+}
+
+// per pool override of the configured defaults
+type QuotaSpec struct {
+	PoolID id.ADT
+	Quota  Quota
+}
+
+// fixed window counter
+type CounterRec struct {
+	PoolID id.ADT
+	Kind   CounterKind
+	Window time.Time
+}
+
+func (r Rate) window(now time.Time) time.Time {
+	return now.Truncate(r.Interval)
+}
+
+func (s *service) SetQuota(ctx context.Context, spec QuotaSpec) error {
+	idAttr := slog.Any("poolID", spec.PoolID)
+	s.log.Debug("setting started", idAttr, slog.Any("quota", spec.Quota))
+	err := s.operator.Explicit(ctx, func(ds data.Source) error {
+		_, err := s.pools.SelectRec(ds, spec.PoolID)
+		if err != nil {
+			return err
+		}
+		return s.pools.UpsertQuota(ds, spec)
+	})
+	if err != nil {
+		s.log.Error("setting failed", idAttr)
+		return err
+	}
+	s.log.Debug("setting succeeded", idAttr)
+	return nil
+}
+
+// the override of the pool or the defaults
+func (s *service) RetrieveQuota(ctx context.Context, poolID id.ADT) (quota Quota, err error) {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		_, err := s.pools.SelectRec(ds, poolID)
+		if err != nil {
+			return err
+		}
+		quota, err = s.selectQuota(ds, poolID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("retrieval failed", slog.Any("poolID", poolID))
+		return Quota{}, err
+	}
+	return quota, nil
+}
+
+// resolves per pool overrides against configured defaults
+func (s *service) selectQuota(ds data.Source, poolID id.ADT) (Quota, error) {
+	quota, err := s.pools.SelectQuota(ds, poolID)
+	if fault.Is(err, fault.NotFound) {
+		return s.quota, nil
+	}
+	if err != nil {
+		return Quota{}, err
+	}
+	return quota, nil
+}
+
+// must run within the transaction of the limited change so that rollbacks release the counters
+func (s *service) enforceRate(ds data.Source, poolID id.ADT, kind CounterKind, rate Rate) error {
+	if rate.Limit <= 0 || rate.Interval <= 0 {
+		return nil
+	}
+	counter := CounterRec{PoolID: poolID, Kind: kind, Window: rate.window(time.Now())}
+	hits, err := s.pools.IncrementCounter(ds, counter)
+	if err != nil {
+		return err
+	}
+	if hits > rate.Limit {
+		metrics.Add("quota_rejections", 1)
+		return errRateExceeded(poolID, kind, rate)
+	}
+	return nil
+}
+
+func (s *service) enforceLive(ds data.Source, poolID id.ADT, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	live, err := s.pools.CountLive(ds, poolID)
+	if err != nil {
+		return err
+	}
+	if live >= limit {
+		metrics.Add("quota_rejections", 1)
+		return errLiveExceeded(poolID, limit)
+	}
+	return nil
+}
+
+func errRateExceeded(poolID id.ADT, kind CounterKind, rate Rate) error {
+	return fault.New(fault.QuotaExceeded, "pool rate exceeded: %v, kind %v, limit %v per %v", poolID, kind, rate.Limit, rate.Interval)
+}
+
+func errLiveExceeded(poolID id.ADT, limit int) error {
+	return fault.New(fault.QuotaExceeded, "pool live processes exceeded: %v, limit %v", poolID, limit)
+}
@@ -0,0 +1,107 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+)
+
+func TestQuotaOverrideMem(t *testing.T) {
+	r := newDaoMem(slog.Default())
+	operator := data.NewOperatorMem()
+	ctx := context.Background()
+	spec := QuotaSpec{
+		PoolID: id.New(),
+		Quota:  Quota{LiveProcs: 3, Steps: Rate{Limit: 10, Interval: time.Minute}},
+	}
+	var got Quota
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		err := r.UpsertQuota(ds, QuotaSpec{PoolID: spec.PoolID})
+		if err != nil {
+			return err
+		}
+		err = r.UpsertQuota(ds, spec)
+		if err != nil {
+			return err
+		}
+		got, err = r.SelectQuota(ds, spec.PoolID)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != spec.Quota {
+		t.Fatalf("want %v, got %v", spec.Quota, got)
+	}
+}
+
+func TestDeleteCountersMem(t *testing.T) {
+	r := newDaoMem(slog.Default())
+	operator := data.NewOperatorMem()
+	ctx := context.Background()
+	poolID := id.New()
+	now := time.Now().Truncate(time.Minute)
+	counters := []CounterRec{
+		{PoolID: poolID, Kind: StepCounter, Window: now.Add(-2 * time.Minute)},
+		{PoolID: poolID, Kind: StepCounter, Window: now.Add(-time.Minute)},
+		{PoolID: poolID, Kind: StepCounter, Window: now},
+		{PoolID: poolID, Kind: SpawnCounter, Window: now.Add(-time.Hour)},
+	}
+	var deleted int64
+	var hits int
+	err := operator.Explicit(ctx, func(ds data.Source) (err error) {
+		for _, counter := range counters {
+			_, err = r.IncrementCounter(ds, counter)
+			if err != nil {
+				return err
+			}
+		}
+		deleted, err = r.DeleteCounters(ds)
+		if err != nil {
+			return err
+		}
+		// the current windows keep counting
+		hits, err = r.IncrementCounter(ds, counters[2])
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 || hits != 2 {
+		t.Fatalf("want 2 deleted and 2 hits, got %v and %v", deleted, hits)
+	}
+}
+
+func TestEnforceRateNamesCounter(t *testing.T) {
+	s := &service{pools: newDaoMem(slog.Default())}
+	operator := data.NewOperatorMem()
+	poolID := id.New()
+	rate := Rate{Limit: 1, Interval: time.Hour}
+	var errs []error
+	err := operator.Explicit(context.Background(), func(ds data.Source) error {
+		for range 2 {
+			errs = append(errs, s.enforceRate(ds, poolID, StepCounter, rate))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs[0] != nil {
+		t.Fatal(errs[0])
+	}
+	if !fault.Is(errs[1], fault.QuotaExceeded) {
+		t.Fatalf("want quota exceeded, got %v", errs[1])
+	}
+	want := fmt.Sprintf("pool rate exceeded: %v, kind step, limit 1 per 1h0m0s", poolID)
+	if !strings.Contains(errs[1].Error(), want) {
+		t.Fatalf("want %q, got %q", want, errs[1])
+	}
+}
@@ -0,0 +1,94 @@
+package exec
+
+import (
+	"sync"
+	"time"
+
+	"orglang/orglang/avt/id"
+)
+
+// pending step eligible for polling
+type Pending struct {
+	PoolID   id.ADT
+	ProcID   id.ADT
+	Priority int
+	Since    time.Time
+}
+
+// exclusive right of a poller to step the process
+type PollClaim struct {
+	ProcID id.ADT
+	// expired claims get taken over
+	ClaimedAt time.Time
+	Until     time.Time
+}
+
+// schedule picks the pending step with the highest effective priority.
+// Waiting lifts the priority by one level per aging interval so that
+// nothing starves. Ties go to the sub-pool served least, then to the oldest.
+func schedule(cands []Pending, served map[id.ADT]int, aging time.Duration, now time.Time) (Pending, bool) {
+	if len(cands) == 0 {
+		return Pending{}, false
+	}
+	best := cands[0]
+	for _, cand := range cands[1:] {
+		if precedes(cand, best, served, aging, now) {
+			best = cand
+		}
+	}
+	return best, true
+}
+
+func precedes(a, b Pending, served map[id.ADT]int, aging time.Duration, now time.Time) bool {
+	aPrio, bPrio := effective(a, aging, now), effective(b, aging, now)
+	if aPrio != bPrio {
+		return aPrio > bPrio
+	}
+	if served[a.PoolID] != served[b.PoolID] {
+		return served[a.PoolID] < served[b.PoolID]
+	}
+	return a.Since.Before(b.Since)
+}
+
+func effective(p Pending, aging time.Duration, now time.Time) int {
+	if aging <= 0 {
+		return p.Priority
+	}
+	return p.Priority + int(now.Sub(p.Since)/aging)
+}
+
+// dispatch counts per sub-pool within a supervisor
+type fairShare struct {
+	mu     sync.Mutex
+	served map[id.ADT]map[id.ADT]int
+}
+
+func newFairShare() *fairShare {
+	return &fairShare{served: make(map[id.ADT]map[id.ADT]int)}
+}
+
+func (f *fairShare) pick(supID id.ADT, cands []Pending, aging time.Duration) (Pending, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	served := f.served[supID]
+	if served == nil {
+		served = make(map[id.ADT]int)
+		f.served[supID] = served
+	}
+	best, ok := schedule(cands, served, aging, time.Now())
+	if !ok {
+		return Pending{}, false
+	}
+	served[best.PoolID]++
+	// keep the counts relative so that new sub-pools don't get flooded
+	low := served[best.PoolID]
+	for _, cand := range cands {
+		low = min(low, served[cand.PoolID])
+	}
+	if low > 0 {
+		for poolID := range served {
+			served[poolID] -= low
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,134 @@
+package exec
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+func TestSchedule(t *testing.T) {
+	now := time.Now()
+	poolA, poolB := id.New(), id.New()
+	older := Pending{PoolID: poolA, ProcID: id.New(), Since: now.Add(-2 * time.Minute)}
+	newer := Pending{PoolID: poolA, ProcID: id.New(), Since: now.Add(-time.Second)}
+	urgent := Pending{PoolID: poolA, ProcID: id.New(), Priority: 2, Since: now}
+	starved := Pending{PoolID: poolB, ProcID: id.New(), Since: now.Add(-3 * time.Minute)}
+	peer := Pending{PoolID: poolB, ProcID: id.New(), Since: now.Add(-time.Second)}
+	tests := []struct {
+		name   string
+		cands  []Pending
+		served map[id.ADT]int
+		aging  time.Duration
+		want   Pending
+	}{
+		{"priority", []Pending{older, urgent}, nil, 0, urgent},
+		{"oldest", []Pending{newer, older}, nil, 0, older},
+		{"aging", []Pending{urgent, starved}, nil, time.Minute, starved},
+		{"served", []Pending{newer, peer}, map[id.ADT]int{poolA: 1}, 0, peer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := schedule(tt.cands, tt.served, tt.aging, now)
+			if !ok || got != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+	if _, ok := schedule(nil, nil, 0, now); ok {
+		t.Fatal("picked from nothing")
+	}
+}
+
+func TestFairShareAlternates(t *testing.T) {
+	f := newFairShare()
+	supID, poolA, poolB := id.New(), id.New(), id.New()
+	since := time.Now()
+	cands := []Pending{
+		{PoolID: poolA, ProcID: id.New(), Since: since},
+		{PoolID: poolB, ProcID: id.New(), Since: since.Add(time.Millisecond)},
+	}
+	var got []id.ADT
+	for range 4 {
+		next, _ := f.pick(supID, cands, 0)
+		got = append(got, next.PoolID)
+	}
+	want := []id.ADT{poolA, poolB, poolA, poolB}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+	}
+	// the counts stay relative
+	if f.served[supID][poolA] != 0 || f.served[supID][poolB] != 0 {
+		t.Fatalf("want zero counts, got %v", f.served[supID])
+	}
+}
+
+func TestClaimNextSkipsClaimed(t *testing.T) {
+	s, pools := newServiceStub()
+	ctx := context.Background()
+	poolID := id.New()
+	taken := Pending{PoolID: poolID, ProcID: id.New(), Priority: 1, Since: time.Now()}
+	free := Pending{PoolID: poolID, ProcID: id.New(), Since: time.Now()}
+	// another poller got there first
+	pools.claims = map[id.ADT]time.Time{taken.ProcID: time.Now().Add(time.Minute)}
+	next, ok, err := s.claimNext(ctx, poolID, []Pending{taken, free})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || next.ProcID != free.ProcID {
+		t.Fatalf("want %v, got %v", free.ProcID, next.ProcID)
+	}
+	_, ok, err = s.claimNext(ctx, poolID, []Pending{taken, free})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("claimed twice")
+	}
+}
+
+func TestPollClaimMem(t *testing.T) {
+	r := newDaoMem(slog.Default())
+	operator := data.NewOperatorMem()
+	ctx := context.Background()
+	procID := id.New()
+	now := time.Now()
+	claims := []PollClaim{
+		{ProcID: procID, ClaimedAt: now, Until: now.Add(time.Minute)},
+		// lease still running
+		{ProcID: procID, ClaimedAt: now.Add(time.Second), Until: now.Add(time.Minute)},
+		// lease ran out
+		{ProcID: procID, ClaimedAt: now.Add(time.Minute), Until: now.Add(2 * time.Minute)},
+	}
+	var got []bool
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		for _, claim := range claims {
+			claimed, err := r.InsertClaim(ds, claim)
+			if err != nil {
+				return err
+			}
+			got = append(got, claimed)
+		}
+		err := r.DeleteClaim(ds, procID)
+		if err != nil {
+			return err
+		}
+		claimed, err := r.InsertClaim(ds, claims[1])
+		got = append(got, claimed)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bool{true, false, true, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+	}
+}
@@ -1,9 +1,16 @@
 package exec
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
 	"github.com/go-resty/resty/v2"
 
 	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/msg"
 
 	procexec "orglang/orglang/aat/proc/exec"
 )
@@ -22,10 +29,11 @@ func NewAPI() API {
 	return newClientResty()
 }
 
-func (cl *clientResty) Create(spec PoolSpec) (PoolRef, error) {
+func (cl *clientResty) Create(ctx context.Context, spec PoolSpec) (PoolRef, error) {
 	req := MsgFromPoolSpec(spec)
 	var res PoolRefME
 	_, err := cl.resty.R().
+		SetContext(ctx).
 		SetResult(&res).
 		SetBody(&req).
 		Post("/pools")
@@ -35,13 +43,14 @@ func (cl *clientResty) Create(spec PoolSpec) (PoolRef, error) {
 	return MsgToPoolRef(res)
 }
 
-func (cl *clientResty) Poll(spec PollSpec) (procexec.ProcRef, error) {
+func (cl *clientResty) Poll(ctx context.Context, spec PollSpec) (procexec.ProcRef, error) {
 	return procexec.ProcRef{}, nil
 }
 
-func (cl *clientResty) Retrieve(poolID id.ADT) (PoolSnap, error) {
+func (cl *clientResty) Retrieve(ctx context.Context, poolID id.ADT) (PoolSnap, error) {
 	var res PoolSnapME
 	_, err := cl.resty.R().
+		SetContext(ctx).
 		SetResult(&res).
 		SetPathParam("id", poolID.String()).
 		Get("/pools/{id}")
@@ -51,15 +60,16 @@ func (cl *clientResty) Retrieve(poolID id.ADT) (PoolSnap, error) {
 	return MsgToPoolSnap(res)
 }
 
-func (cl *clientResty) RetreiveRefs() ([]PoolRef, error) {
+func (cl *clientResty) RetreiveRefs(ctx context.Context) ([]PoolRef, error) {
 	refs := []PoolRef{}
 	return refs, nil
 }
 
-func (cl *clientResty) Spawn(spec procexec.ProcSpec) (procexec.ProcRef, error) {
+func (cl *clientResty) Spawn(ctx context.Context, spec procexec.ProcSpec) (procexec.ProcRef, error) {
 	req := procexec.MsgFromSpec(spec)
 	var res procexec.RefME
 	_, err := cl.resty.R().
+		SetContext(ctx).
 		SetResult(&res).
 		SetBody(&req).
 		SetPathParam("poolID", spec.PoolID.String()).
@@ -70,17 +80,126 @@ func (cl *clientResty) Spawn(spec procexec.ProcSpec) (procexec.ProcRef, error) {
 	return procexec.MsgToRef(res)
 }
 
-func (cl *clientResty) Take(spec StepSpec) error {
+func (cl *clientResty) Take(ctx context.Context, spec StepSpec) error {
 	req := MsgFromStepSpec(spec)
-	var res procexec.RefME
+	resp, err := cl.resty.R().
+		SetContext(ctx).
+		SetBody(&req).
+		SetPathParam("poolID", spec.PoolID.String()).
+		Post("/pools/{poolID}/steps")
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("received: %v", string(resp.Body()))
+	}
+	return nil
+}
+
+func (cl *clientResty) Watch(ctx context.Context, spec WatchSpec) (Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	resp, err := cl.resty.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetHeader("Accept", msg.MIMETextEventStream).
+		SetPathParam("poolID", spec.PoolID.String()).
+		SetQueryParam("seq", strconv.FormatInt(spec.Seq, 10)).
+		Get("/pools/{poolID}/events")
+	if err != nil {
+		cancel()
+		return Subscription{}, err
+	}
+	body := resp.RawBody()
+	if resp.IsError() {
+		defer body.Close()
+		cancel()
+		raw, _ := io.ReadAll(body)
+		return Subscription{}, fmt.Errorf("received: %v", string(raw))
+	}
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		defer body.Close()
+		msg.ReadSSE(body, func(e msg.SSE) bool {
+			var dto EventME
+			err := json.Unmarshal(e.Data, &dto)
+			if err != nil {
+				return false
+			}
+			ev, err := MsgToEvent(dto)
+			if err != nil {
+				return false
+			}
+			select {
+			case events <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return Subscription{Events: events, Cancel: cancel}, nil
+}
+
+func (cl *clientResty) Cancel(ctx context.Context, spec CancelSpec) error {
+	req := MsgFromCancelSpec(spec)
+	resp, err := cl.resty.R().
+		SetContext(ctx).
+		SetBody(&req).
+		SetPathParam("poolID", spec.PoolID.String()).
+		SetPathParam("procID", spec.ProcID.String()).
+		Post("/pools/{poolID}/procs/{procID}/cancel")
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("received: %v", string(resp.Body()))
+	}
+	return nil
+}
+
+func (cl *clientResty) RetrieveJournal(ctx context.Context, procID id.ADT) ([]JournalEntry, error) {
+	var res []JournalEntryME
 	_, err := cl.resty.R().
+		SetContext(ctx).
 		SetResult(&res).
+		SetPathParam("procID", procID.String()).
+		Get("/procs/{procID}/journal")
+	if err != nil {
+		return nil, err
+	}
+	return MsgToJournal(res)
+}
+
+func (cl *clientResty) SetQuota(ctx context.Context, spec QuotaSpec) error {
+	req := MsgFromQuotaSpec(spec)
+	resp, err := cl.resty.R().
+		SetContext(ctx).
 		SetBody(&req).
 		SetPathParam("poolID", spec.PoolID.String()).
-		SetPathParam("procID", spec.ProcID.String()).
-		Post("/pools/{poolID}/procs/{procID}/steps")
+		Put("/pools/{poolID}/quota")
 	if err != nil {
 		return err
 	}
+	if resp.IsError() {
+		return fmt.Errorf("received: %v", string(resp.Body()))
+	}
 	return nil
 }
+
+func (cl *clientResty) RetrieveQuota(ctx context.Context, poolID id.ADT) (Quota, error) {
+	var res QuotaME
+	_, err := cl.resty.R().
+		SetContext(ctx).
+		SetResult(&res).
+		SetPathParam("poolID", poolID.String()).
+		Get("/pools/{poolID}/quota")
+	if err != nil {
+		return Quota{}, err
+	}
+	spec, err := MsgToQuotaSpec(res)
+	if err != nil {
+		return Quota{}, err
+	}
+	return spec.Quota, nil
+}
@@ -0,0 +1,84 @@
+package exec
+
+import (
+	"time"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+
+	procdef "orglang/orglang/aat/proc/def"
+)
+
+func dataFromComp(rec CompRec) compDS {
+	return compDS{
+		PoolID: rec.PoolID.String(),
+		ProcID: rec.ProcID.String(),
+		PoolRN: rn.ConvertToInt(rec.PoolRN),
+		CompTS: procdef.MsgFromTermSpec(rec.CompTS),
+	}
+}
+
+func dataToComp(dto compDS) (CompRec, error) {
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		return CompRec{}, err
+	}
+	procID, err := id.ConvertFromString(dto.ProcID)
+	if err != nil {
+		return CompRec{}, err
+	}
+	compTS, err := procdef.MsgToTermSpec(dto.CompTS)
+	if err != nil {
+		return CompRec{}, err
+	}
+	return CompRec{
+		PoolID: poolID,
+		ProcID: procID,
+		PoolRN: rn.ConvertFromInt(dto.PoolRN),
+		CompTS: compTS,
+	}, nil
+}
+
+func dataToComps(dtos []compDS) ([]CompRec, error) {
+	recs := make([]CompRec, 0, len(dtos))
+	for _, dto := range dtos {
+		rec, err := dataToComp(dto)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+func MsgToQuotaSpec(dto QuotaME) (QuotaSpec, error) {
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		return QuotaSpec{}, err
+	}
+	return QuotaSpec{
+		PoolID: poolID,
+		Quota: Quota{
+			LiveProcs: dto.LiveProcs,
+			Spawns:    msgToRate(dto.Spawns),
+			Steps:     msgToRate(dto.Steps),
+		},
+	}, nil
+}
+
+func MsgFromQuotaSpec(spec QuotaSpec) QuotaME {
+	return QuotaME{
+		PoolID:    spec.PoolID.String(),
+		LiveProcs: spec.Quota.LiveProcs,
+		Spawns:    msgFromRate(spec.Quota.Spawns),
+		Steps:     msgFromRate(spec.Quota.Steps),
+	}
+}
+
+func msgToRate(dto RateME) Rate {
+	return Rate{Limit: dto.Limit, Interval: time.Duration(dto.IntervalMS) * time.Millisecond}
+}
+
+func msgFromRate(rate Rate) RateME {
+	return RateME{Limit: rate.Limit, IntervalMS: rate.Interval.Milliseconds()}
+}
@@ -14,16 +14,24 @@ var (
 // goverter:variables
 // goverter:output:format assign-variable
 // goverter:extend orglang/orglang/avt/id:Convert.*
+// goverter:extend orglang/orglang/avt/rn:Convert.*
 // goverter:extend orglang/orglang/aat/proc/def:Msg.*
 var (
-	MsgToPoolSpec   func(PoolSpecME) (PoolSpec, error)
-	MsgFromPoolSpec func(PoolSpec) PoolSpecME
-	MsgToPoolRef    func(PoolRefME) (PoolRef, error)
-	MsgFromPoolRef  func(PoolRef) PoolRefME
-	MsgToPoolSnap   func(PoolSnapME) (PoolSnap, error)
-	MsgFromPoolSnap func(PoolSnap) PoolSnapME
-	MsgFromStepSpec func(StepSpec) StepSpecME
-	MsgToStepSpec   func(StepSpecME) (StepSpec, error)
+	MsgToPoolSpec     func(PoolSpecME) (PoolSpec, error)
+	MsgFromPoolSpec   func(PoolSpec) PoolSpecME
+	MsgToPoolRef      func(PoolRefME) (PoolRef, error)
+	MsgFromPoolRef    func(PoolRef) PoolRefME
+	MsgToPoolSnap     func(PoolSnapME) (PoolSnap, error)
+	MsgFromPoolSnap   func(PoolSnap) PoolSnapME
+	MsgFromStepSpec   func(StepSpec) StepSpecME
+	MsgToStepSpec     func(StepSpecME) (StepSpec, error)
+	MsgToWatchSpec    func(WatchSpecME) (WatchSpec, error)
+	MsgToEvent        func(EventME) (Event, error)
+	MsgFromEvent      func(Event) EventME
+	MsgToCancelSpec   func(CancelSpecME) (CancelSpec, error)
+	MsgFromCancelSpec func(CancelSpec) CancelSpecME
+	MsgFromJournal    func([]JournalEntry) []JournalEntryME
+	MsgToJournal      func([]JournalEntryME) ([]JournalEntry, error)
 )
 
 // goverter:variables
@@ -41,4 +49,9 @@ var (
 	DataToPoolSnap   func(poolSnapDS) (PoolSnap, error)
 	DataFromPoolSnap func(PoolSnap) poolSnapDS
 	DataToEPs        func([]epDS) ([]procexec.EP, error)
+	DataToPendings   func([]pendingDS) ([]Pending, error)
+	DataToIdemRec    func(idemDS) (IdemRec, error)
+	DataFromJournal  func(JournalEntry) journalDS
+	DataToJournal    func([]journalDS) ([]JournalEntry, error)
+	DataFromIdemRec  func(IdemRec) idemDS
 )
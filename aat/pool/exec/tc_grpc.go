@@ -0,0 +1,73 @@
+package exec
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"orglang/orglang/rpc"
+
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+)
+
+func msgFromPoolSpecPB(pb *rpc.CreatePoolRequest) PoolSpecME {
+	return PoolSpecME{SigQN: pb.GetSigQn(), ProcIDs: pb.GetProcIds(), SupID: pb.GetSupId()}
+}
+
+func pbFromPoolRefMsg(dto PoolRefME) *rpc.PoolRef {
+	return &rpc.PoolRef{PoolId: dto.PoolID, ProcId: dto.ProcID}
+}
+
+func pbFromPoolSnapMsg(dto PoolSnapME) *rpc.PoolSnap {
+	subs := make([]*rpc.PoolRef, 0, len(dto.Subs))
+	for _, sub := range dto.Subs {
+		subs = append(subs, pbFromPoolRefMsg(sub))
+	}
+	return &rpc.PoolSnap{Id: dto.PoolID, Title: dto.Title, Subs: subs}
+}
+
+func msgFromSpawnSpecPB(pb *rpc.SpawnProcRequest) procexec.SpecME {
+	return procexec.SpecME{
+		ProcID:   pb.GetProcId(),
+		PoolID:   pb.GetPoolId(),
+		Term:     procdef.MsgFromCallPB(pb.GetTerm()),
+		Priority: int(pb.GetPriority()),
+		IdemKey:  pb.GetIdemKey(),
+	}
+}
+
+func msgFromStepSpecPB(pb *rpc.TakeStepRequest) StepSpecME {
+	return StepSpecME{
+		PoolID:  pb.GetPoolId(),
+		ProcID:  pb.GetProcId(),
+		Term:    procdef.MsgFromTermPB(pb.GetTerm()),
+		IdemKey: pb.GetIdemKey(),
+		Comp:    procdef.MsgFromTermPBNilable(pb.GetComp()),
+		AgentQN: pb.GetAgentQn(),
+	}
+}
+
+func pbFromJournalMsg(dtos []JournalEntryME) []*rpc.JournalEntry {
+	entries := make([]*rpc.JournalEntry, 0, len(dtos))
+	for _, dto := range dtos {
+		entries = append(entries, &rpc.JournalEntry{
+			PoolId: dto.PoolID,
+			ProcId: dto.ProcID,
+			Kind:   dto.Kind,
+			Rev:    dto.PoolRN,
+			Detail: dto.Detail,
+			At:     timestamppb.New(dto.At),
+		})
+	}
+	return entries
+}
+
+func pbFromEventMsg(dto EventME) *rpc.PoolEvent {
+	return &rpc.PoolEvent{
+		PoolId: dto.PoolID,
+		ProcId: dto.ProcID,
+		ChnlId: dto.ChnlID,
+		Kind:   dto.Kind,
+		Rev:    dto.PoolRN,
+		Seq:    dto.Seq,
+	}
+}
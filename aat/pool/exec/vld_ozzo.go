@@ -0,0 +1,20 @@
+package exec
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func (p props) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Poll),
+	)
+}
+
+func (p poll) Validate() error {
+	return validation.ValidateStruct(&p,
+		// pollers loop on empty polls, zero would spin them
+		validation.Field(&p.Timeout, validation.Required, validation.Min(0)),
+		validation.Field(&p.Aging, validation.Min(0)),
+		validation.Field(&p.Lease, validation.Min(0)),
+	)
+}
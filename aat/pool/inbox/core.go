@@ -0,0 +1,323 @@
+package inbox
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"golang.org/x/exp/maps"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	poolexec "orglang/orglang/aat/pool/exec"
+	procdef "orglang/orglang/aat/proc/def"
+	typedef "orglang/orglang/aat/type/def"
+)
+
+// Port
+type API interface {
+	RetrieveByPool(context.Context, id.ADT) ([]TaskRef, error)
+	RetrieveByAgent(context.Context, sym.ADT) ([]TaskRef, error)
+	Retrieve(context.Context, id.ADT) (TaskSnap, error)
+	Claim(context.Context, ClaimSpec) (TaskRef, error)
+	Complete(context.Context, DoneSpec) error
+}
+
+type TaskKind string
+
+const (
+	// pick one of the offered labels
+	ChoiceTask = TaskKind("choice")
+	// send a value
+	InputTask = TaskKind("input")
+)
+
+type TaskRef struct {
+	// pending step channel
+	TaskID id.ADT
+	PoolID id.ADT
+	// process acting on behalf of the person
+	ProcID id.ADT
+	Kind   TaskKind
+	// claimant, empty while open
+	AgentQN sym.ADT
+}
+
+type TaskSnap struct {
+	TaskID  id.ADT
+	PoolID  id.ADT
+	ProcID  id.ADT
+	Kind    TaskKind
+	AgentQN sym.ADT
+	ChnlPH  sym.ADT
+	Labels  []sym.ADT
+}
+
+// pending half-step joined with the counterpart binding
+type TaskRec struct {
+	TaskID  id.ADT
+	PoolID  id.ADT
+	ProcID  id.ADT
+	ChnlPH  sym.ADT
+	TermID  id.ADT
+	Cont    procdef.TermRec
+	AgentQN sym.ADT
+}
+
+type ClaimSpec struct {
+	TaskID  id.ADT
+	AgentQN sym.ADT
+}
+
+type DoneSpec struct {
+	TaskID  id.ADT
+	AgentQN sym.ADT
+	// for choice tasks
+	Label sym.ADT
+	// for input tasks
+	ValPH sym.ADT
+}
+
+type service struct {
+	tasks    Repo
+	types    typedef.Repo
+	pools    poolexec.API
+	operator data.Operator
+	log      *slog.Logger
+}
+
+// for compilation purposes
+func newAPI() API {
+	return &service{}
+}
+
+func newService(
+	tasks Repo,
+	types typedef.Repo,
+	pools poolexec.API,
+	operator data.Operator,
+	l *slog.Logger,
+) *service {
+	name := slog.String("name", "inboxService")
+	return &service{tasks, types, pools, operator, l.With(name)}
+}
+
+func (s *service) RetrieveByPool(ctx context.Context, poolID id.ADT) (_ []TaskRef, err error) {
+	var recs []TaskRec
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		recs, err = s.tasks.SelectRecsByPool(ds, poolID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("retrieval failed", slog.Any("poolID", poolID))
+		return nil, err
+	}
+	return collectRefs(recs), nil
+}
+
+func (s *service) RetrieveByAgent(ctx context.Context, agentQN sym.ADT) (_ []TaskRef, err error) {
+	var recs []TaskRec
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		recs, err = s.tasks.SelectRecsByAgent(ds, agentQN)
+		return err
+	})
+	if err != nil {
+		s.log.Error("retrieval failed", slog.Any("agentQN", agentQN))
+		return nil, err
+	}
+	return collectRefs(recs), nil
+}
+
+func (s *service) Retrieve(ctx context.Context, taskID id.ADT) (_ TaskSnap, err error) {
+	idAttr := slog.Any("taskID", taskID)
+	var rec TaskRec
+	var term typedef.TermRec
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		rec, err = s.tasks.SelectRecByID(ds, taskID)
+		if err != nil {
+			return err
+		}
+		term, err = s.types.SelectTermRecByID(ds, rec.TermID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("retrieval failed", idAttr)
+		return TaskSnap{}, err
+	}
+	kind, ok := taskKind(rec.Cont)
+	if !ok {
+		return TaskSnap{}, errMissingTask(taskID)
+	}
+	snap := TaskSnap{
+		TaskID:  rec.TaskID,
+		PoolID:  rec.PoolID,
+		ProcID:  rec.ProcID,
+		Kind:    kind,
+		AgentQN: rec.AgentQN,
+		ChnlPH:  rec.ChnlPH,
+	}
+	if kind == ChoiceTask {
+		snap.Labels, err = collectLabels(term)
+		if err != nil {
+			s.log.Error("retrieval failed", idAttr)
+			return TaskSnap{}, err
+		}
+	}
+	return snap, nil
+}
+
+func (s *service) Claim(ctx context.Context, spec ClaimSpec) (_ TaskRef, err error) {
+	idAttr := slog.Any("taskID", spec.TaskID)
+	s.log.Debug("claiming started", idAttr, slog.Any("agentQN", spec.AgentQN))
+	var rec TaskRec
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
+		rec, err = s.tasks.SelectRecByID(ds, spec.TaskID)
+		if err != nil {
+			return err
+		}
+		if rec.AgentQN == spec.AgentQN {
+			return nil
+		}
+		if rec.AgentQN != "" {
+			return errClaimedByOther(spec.TaskID, rec.AgentQN)
+		}
+		return s.tasks.InsertClaim(ds, spec)
+	})
+	if err != nil {
+		s.log.Error("claiming failed", idAttr)
+		return TaskRef{}, err
+	}
+	rec.AgentQN = spec.AgentQN
+	refs := collectRefs([]TaskRec{rec})
+	if len(refs) == 0 {
+		return TaskRef{}, errMissingTask(spec.TaskID)
+	}
+	s.log.Debug("claiming succeeded", idAttr)
+	return refs[0], nil
+}
+
+func (s *service) Complete(ctx context.Context, spec DoneSpec) error {
+	idAttr := slog.Any("taskID", spec.TaskID)
+	s.log.Debug("completion started", idAttr)
+	idemKey := doneKey(spec.TaskID)
+	// the task is gone from the inbox once completed, so the key goes first
+	var done bool
+	err := s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) (err error) {
+		done, err = s.tasks.SelectDone(ds, idemKey)
+		return err
+	})
+	if err != nil {
+		s.log.Error("completion failed", idAttr)
+		return err
+	}
+	if done {
+		s.log.Debug("completion replayed", idAttr)
+		return nil
+	}
+	snap, err := s.Retrieve(ctx, spec.TaskID)
+	if err != nil {
+		return err
+	}
+	if snap.AgentQN != spec.AgentQN {
+		return errClaimedByOther(spec.TaskID, snap.AgentQN)
+	}
+	var termSpec procdef.TermSpec
+	switch snap.Kind {
+	case ChoiceTask:
+		if !slices.Contains(snap.Labels, spec.Label) {
+			return errLabelUnexpected(spec.Label, snap.Labels)
+		}
+		termSpec = procdef.LabSpec{CommPH: snap.ChnlPH, Label: spec.Label}
+	case InputTask:
+		if spec.ValPH == "" {
+			return errMissingValue(spec.TaskID)
+		}
+		termSpec = procdef.SendSpec{CommPH: snap.ChnlPH, ValPH: spec.ValPH}
+	}
+	err = s.pools.Take(ctx, poolexec.StepSpec{
+		PoolID: snap.PoolID,
+		ProcID: snap.ProcID,
+		ProcTS: termSpec,
+		// resubmitting a completion replays the same step
+		IdemKey: idemKey,
+		AgentQN: spec.AgentQN,
+		// the task leaves the inbox with the step
+		TxHook: func(ds data.Source) error {
+			return s.tasks.DeleteClaim(ds, spec.TaskID)
+		},
+	})
+	if err != nil {
+		s.log.Error("completion failed", idAttr)
+		return err
+	}
+	s.log.Debug("completion succeeded", idAttr)
+	return nil
+}
+
+// one completion per task, whoever resubmits it
+func doneKey(taskID id.ADT) string {
+	return "inbox:" + taskID.String()
+}
+
+// only steps waiting on a decision become tasks
+func taskKind(cont procdef.TermRec) (TaskKind, bool) {
+	switch cont.(type) {
+	case procdef.CaseRec:
+		return ChoiceTask, true
+	case procdef.RecvRec:
+		return InputTask, true
+	default:
+		return "", false
+	}
+}
+
+func collectRefs(recs []TaskRec) []TaskRef {
+	refs := make([]TaskRef, 0, len(recs))
+	for _, rec := range recs {
+		kind, ok := taskKind(rec.Cont)
+		if !ok {
+			continue
+		}
+		refs = append(refs, TaskRef{
+			TaskID:  rec.TaskID,
+			PoolID:  rec.PoolID,
+			ProcID:  rec.ProcID,
+			Kind:    kind,
+			AgentQN: rec.AgentQN,
+		})
+	}
+	return refs
+}
+
+func collectLabels(term typedef.TermRec) ([]sym.ADT, error) {
+	var labels []sym.ADT
+	switch rec := term.(type) {
+	case typedef.PlusRec:
+		labels = maps.Keys(rec.Zs)
+	case typedef.WithRec:
+		labels = maps.Keys(rec.Zs)
+	default:
+		return nil, fault.New(fault.StateCorruption, "choice type unexpected: %T", term)
+	}
+	slices.Sort(labels)
+	return labels, nil
+}
+
+func errMissingTask(want id.ADT) error {
+	return fault.New(fault.NotFound, "task missing: %v", want)
+}
+
+func errClaimedByOther(taskID id.ADT, got sym.ADT) error {
+	return fault.New(fault.ConcurrentModification, "task claimed by another agent: %v, agent %q", taskID, got)
+}
+
+func errLabelUnexpected(got sym.ADT, want []sym.ADT) error {
+	return fault.New(fault.ProtocolViolation, "label unexpected: want one of %v, got %v", want, got)
+}
+
+func errMissingValue(taskID id.ADT) error {
+	return fault.New(fault.ProtocolViolation, "value missing for task: %v", taskID)
+}
@@ -0,0 +1,188 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	poolexec "orglang/orglang/aat/pool/exec"
+	procdef "orglang/orglang/aat/proc/def"
+	typedef "orglang/orglang/aat/type/def"
+)
+
+func TestCompleteReleasesClaim(t *testing.T) {
+	s, operator, pools := newServiceStub()
+	ctx := context.Background()
+	spec := DoneSpec{TaskID: s.tasks.(*tasksStub).rec.TaskID, AgentQN: "alice", ValPH: sym.New("v")}
+	claim(t, s, spec)
+	err := s.Complete(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pools.taken != 1 {
+		t.Fatalf("want 1 step, got %v", pools.taken)
+	}
+	if claims(t, operator) != 0 {
+		t.Fatal("claim kept after completion")
+	}
+}
+
+func TestCompleteTwiceStepsOnce(t *testing.T) {
+	s, _, pools := newServiceStub()
+	ctx := context.Background()
+	spec := DoneSpec{TaskID: s.tasks.(*tasksStub).rec.TaskID, AgentQN: "alice", ValPH: sym.New("v")}
+	claim(t, s, spec)
+	for range 2 {
+		err := s.Complete(ctx, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pools.taken != 1 {
+		t.Fatalf("want 1 step, got %v", pools.taken)
+	}
+}
+
+func TestCompleteKeepsClaimOnFailure(t *testing.T) {
+	s, operator, pools := newServiceStub()
+	ctx := context.Background()
+	spec := DoneSpec{TaskID: s.tasks.(*tasksStub).rec.TaskID, AgentQN: "alice", ValPH: sym.New("v")}
+	claim(t, s, spec)
+	// the step fails after the claim got deleted
+	pools.err = errors.New("commit failed")
+	err := s.Complete(ctx, spec)
+	if !errors.Is(err, pools.err) {
+		t.Fatalf("want %v, got %v", pools.err, err)
+	}
+	if claims(t, operator) != 1 {
+		t.Fatal("claim lost with the step")
+	}
+}
+
+func TestCompleteKeepsStepOnReleaseFailure(t *testing.T) {
+	s, _, pools := newServiceStub()
+	ctx := context.Background()
+	tasks := s.tasks.(*tasksStub)
+	spec := DoneSpec{TaskID: tasks.rec.TaskID, AgentQN: "alice", ValPH: sym.New("v")}
+	claim(t, s, spec)
+	tasks.err = errors.New("delete failed")
+	err := s.Complete(ctx, spec)
+	if !errors.Is(err, tasks.err) {
+		t.Fatalf("want %v, got %v", tasks.err, err)
+	}
+	// the task stays claimed and pending
+	if pools.taken != 0 {
+		t.Fatalf("want no steps, got %v", pools.taken)
+	}
+}
+
+func claim(t *testing.T, s *service, spec DoneSpec) {
+	t.Helper()
+	_, err := s.Claim(context.Background(), ClaimSpec{TaskID: spec.TaskID, AgentQN: spec.AgentQN})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func claims(t *testing.T, operator data.Operator) (n int) {
+	t.Helper()
+	err := operator.Implicit(context.Background(), func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		n = len(data.Rows[claimRowMem](ds, claimsMem))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func newServiceStub() (*service, *data.OperatorMem, *poolsStub) {
+	operator := data.NewOperatorMem()
+	tasks := &tasksStub{
+		daoMem: newDaoMem(slog.Default()),
+		rec: TaskRec{
+			TaskID: id.New(),
+			PoolID: id.New(),
+			ProcID: id.New(),
+			ChnlPH: sym.New("x"),
+			TermID: id.New(),
+			Cont:   procdef.RecvRec{X: sym.New("x")},
+		},
+		keys: map[string]bool{},
+	}
+	pools := &poolsStub{operator: operator, keys: tasks.keys}
+	return newService(tasks, typesStub{}, pools, operator, slog.Default()), operator, pools
+}
+
+// a single input task, the claims live in the mem tables
+type tasksStub struct {
+	*daoMem
+	rec TaskRec
+	// idempotency keys of the taken steps
+	keys map[string]bool
+	// fails claim deletion
+	err error
+}
+
+func (r *tasksStub) SelectDone(source data.Source, idemKey string) (bool, error) {
+	return r.keys[idemKey], nil
+}
+
+func (r *tasksStub) DeleteClaim(source data.Source, taskID id.ADT) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.daoMem.DeleteClaim(source, taskID)
+}
+
+func (r *tasksStub) SelectRecByID(source data.Source, taskID id.ADT) (TaskRec, error) {
+	if taskID != r.rec.TaskID {
+		return TaskRec{}, errMissingTask(taskID)
+	}
+	rec := r.rec
+	ds := data.MustConform[data.SourceMem](source)
+	for _, row := range data.Rows[claimRowMem](ds, claimsMem) {
+		if row.ChnlID == taskID.String() {
+			rec.AgentQN = sym.ADT(row.AgentQN)
+		}
+	}
+	return rec, nil
+}
+
+type typesStub struct {
+	typedef.Repo
+}
+
+func (typesStub) SelectTermRecByID(source data.Source, termID id.ADT) (typedef.TermRec, error) {
+	return typedef.OneRec{}, nil
+}
+
+// takes the step in a single transaction, err fails it at commit
+type poolsStub struct {
+	poolexec.API
+	operator data.Operator
+	taken    int
+	keys     map[string]bool
+	err      error
+}
+
+func (p *poolsStub) Take(ctx context.Context, spec poolexec.StepSpec) error {
+	return p.operator.Explicit(ctx, func(ds data.Source) error {
+		err := spec.TxHook(ds)
+		if err != nil {
+			return err
+		}
+		if p.err != nil {
+			return p.err
+		}
+		p.taken++
+		p.keys[spec.IdemKey] = true
+		return nil
+	})
+}
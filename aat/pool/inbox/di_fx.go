@@ -0,0 +1,72 @@
+//go:build !goverter
+
+package inbox
+
+import (
+	"embed"
+	"html/template"
+	"log/slog"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/msg"
+)
+
+var Module = fx.Module("aat/pool/inbox",
+	fx.Provide(
+		fx.Annotate(newService, fx.As(new(API))),
+	),
+	fx.Provide(
+		fx.Private,
+		newHandlerEcho,
+		newPresenterEcho,
+		newRepoByMapping,
+		fx.Annotate(newRenderer, fx.As(new(msg.Renderer))),
+	),
+	fx.Invoke(
+		cfgApiEcho,
+		cfgSsrEcho,
+	),
+)
+
+func newRepoByMapping(m data.Mapping, l *slog.Logger) Repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
+//go:embed *.html
+var viewFs embed.FS
+
+func newRenderer(l *slog.Logger) (*msg.RendererStdlib, error) {
+	t, err := template.New("inbox").Funcs(sprig.FuncMap()).ParseFS(viewFs, "*.html")
+	if err != nil {
+		return nil, err
+	}
+	return msg.NewRendererStdlib(t, l), nil
+}
+
+func cfgApiEcho(e *echo.Echo, h *handlerEcho) error {
+	e.GET("/api/v1/pools/:id/tasks", h.GetByPool)
+	e.GET("/api/v1/agents/:qn/tasks", h.GetByAgent)
+	e.GET("/api/v1/tasks/:id", h.GetOne)
+	e.POST("/api/v1/tasks/:id/claim", h.PostClaim)
+	e.POST("/api/v1/tasks/:id/done", h.PostDone)
+	return nil
+}
+
+func cfgSsrEcho(e *echo.Echo, p *presenterEcho) error {
+	e.GET("/ssr/pools/:id/tasks", p.GetMany)
+	e.GET("/ssr/tasks/:id", p.GetOne)
+	e.POST("/ssr/tasks/:id/claim", p.PostClaim)
+	e.POST("/ssr/tasks/:id/done", p.PostDone)
+	return nil
+}
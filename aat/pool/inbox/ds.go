@@ -0,0 +1,30 @@
+package inbox
+
+import (
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	procdef "orglang/orglang/aat/proc/def"
+)
+
+// Port
+type Repo interface {
+	SelectRecsByPool(data.Source, id.ADT) ([]TaskRec, error)
+	SelectRecsByAgent(data.Source, sym.ADT) ([]TaskRec, error)
+	SelectRecByID(data.Source, id.ADT) (TaskRec, error)
+	InsertClaim(data.Source, ClaimSpec) error
+	DeleteClaim(data.Source, id.ADT) error
+	// whether a step went through under the key in any pool
+	SelectDone(data.Source, string) (bool, error)
+}
+
+type taskRecDS struct {
+	TaskID  string            `db:"task_id"`
+	PoolID  string            `db:"pool_id"`
+	ProcID  string            `db:"proc_id"`
+	ChnlPH  string            `db:"chnl_ph"`
+	TermID  string            `db:"state_id"`
+	Cont    procdef.TermRecDS `db:"spec"`
+	AgentQN string            `db:"agent_qn"`
+}
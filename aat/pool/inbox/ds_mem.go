@@ -0,0 +1,87 @@
+package inbox
+
+import (
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	poolexec "orglang/orglang/aat/pool/exec"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	name := slog.String("name", "inboxDaoMem")
+	return &daoMem{l.With(name)}
+}
+
+const claimsMem = "inbox_claims"
+
+type claimRowMem struct {
+	ChnlID    string
+	AgentQN   string
+	ClaimedAt time.Time
+}
+
+func (r *daoMem) SelectRecsByPool(source data.Source, poolID id.ADT) ([]TaskRec, error) {
+	return r.selectRecs(source, func(dto taskRecDS) bool { return dto.PoolID == poolID.String() })
+}
+
+func (r *daoMem) SelectRecsByAgent(source data.Source, agentQN sym.ADT) ([]TaskRec, error) {
+	return r.selectRecs(source, func(dto taskRecDS) bool { return dto.AgentQN == string(agentQN) })
+}
+
+func (r *daoMem) SelectRecByID(source data.Source, taskID id.ADT) (TaskRec, error) {
+	recs, err := r.selectRecs(source, func(dto taskRecDS) bool { return dto.TaskID == taskID.String() })
+	if err != nil {
+		return TaskRec{}, err
+	}
+	if len(recs) == 0 {
+		return TaskRec{}, errMissingTask(taskID)
+	}
+	return recs[0], nil
+}
+
+func (r *daoMem) selectRecs(source data.Source, match func(taskRecDS) bool) ([]TaskRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	claims := make(map[string]string)
+	for _, row := range data.Rows[claimRowMem](ds, claimsMem) {
+		claims[row.ChnlID] = row.AgentQN
+	}
+	var dtos []taskRecDS
+	for _, svc := range poolexec.SelectServicesMem(ds) {
+		dto := taskRecDS{svc.ChnlID, svc.PoolID, svc.ProcID, svc.ChnlPH, svc.StateID, svc.Cont, claims[svc.ChnlID]}
+		if match(dto) {
+			dtos = append(dtos, dto)
+		}
+	}
+	return dataToTaskRecs(dtos)
+}
+
+func (r *daoMem) InsertClaim(source data.Source, spec ClaimSpec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	for _, row := range data.Rows[claimRowMem](ds, claimsMem) {
+		if row.ChnlID == spec.TaskID.String() {
+			return errClaimedByOther(spec.TaskID, "")
+		}
+	}
+	data.InsertRows(ds, claimsMem, claimRowMem{spec.TaskID.String(), string(spec.AgentQN), time.Now()})
+	return nil
+}
+
+func (r *daoMem) DeleteClaim(source data.Source, taskID id.ADT) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.DeleteRows(ds, claimsMem, func(row claimRowMem) bool { return row.ChnlID == taskID.String() })
+	return nil
+}
+
+func (r *daoMem) SelectDone(source data.Source, idemKey string) (bool, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	return poolexec.IdemTakenMem(ds, idemKey), nil
+}
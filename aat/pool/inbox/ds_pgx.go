@@ -0,0 +1,183 @@
+package inbox
+
+import (
+	"errors"
+	"log/slog"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+)
+
+// Adapter
+type daoPgx struct {
+	log *slog.Logger
+}
+
+func newDaoPgx(l *slog.Logger) *daoPgx {
+	name := slog.String("name", "inboxDaoPgx")
+	return &daoPgx{l.With(name)}
+}
+
+// for compilation purposes
+func newRepo() Repo {
+	return &daoPgx{}
+}
+
+func (r *daoPgx) SelectRecsByPool(source data.Source, poolID id.ADT) ([]TaskRec, error) {
+	return r.selectRecs(source, selectTasks+" and liab.pool_id = $1", poolID.String())
+}
+
+func (r *daoPgx) SelectRecsByAgent(source data.Source, agentQN sym.ADT) ([]TaskRec, error) {
+	return r.selectRecs(source, selectTasks+" and claim.agent_qn = $1", string(agentQN))
+}
+
+func (r *daoPgx) selectRecs(source data.Source, query string, arg string) ([]TaskRec, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	argAttr := slog.String("arg", arg)
+	rows, err := ds.Conn.Query(ds.Ctx, query, arg)
+	if err != nil {
+		r.log.Error("execution failed", argAttr)
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[taskRecDS])
+	if err != nil {
+		r.log.Error("collection failed", argAttr, slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	recs, err := dataToTaskRecs(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", argAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", argAttr, slog.Int("count", len(recs)))
+	return recs, nil
+}
+
+func (r *daoPgx) SelectRecByID(source data.Source, taskID id.ADT) (TaskRec, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("taskID", taskID)
+	rows, err := ds.Conn.Query(ds.Ctx, selectTasks+" and step.chnl_id = $1", taskID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return TaskRec{}, err
+	}
+	defer rows.Close()
+	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[taskRecDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return TaskRec{}, errMissingTask(taskID)
+	}
+	if err != nil {
+		r.log.Error("collection failed", idAttr, slog.Any("struct", reflect.TypeOf(dto)))
+		return TaskRec{}, err
+	}
+	rec, err := dataToTaskRec(dto)
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return TaskRec{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return rec, nil
+}
+
+func (r *daoPgx) InsertClaim(source data.Source, spec ClaimSpec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("taskID", spec.TaskID)
+	args := pgx.NamedArgs{
+		"chnl_id":  spec.TaskID.String(),
+		"agent_qn": string(spec.AgentQN),
+	}
+	ct, err := ds.Conn.Exec(ds.Ctx, insertClaim, args)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return errClaimedByOther(spec.TaskID, "")
+	}
+	r.log.Debug("insertion succeeded", idAttr)
+	return nil
+}
+
+func (r *daoPgx) DeleteClaim(source data.Source, taskID id.ADT) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("taskID", taskID)
+	_, err := ds.Conn.Exec(ds.Ctx, deleteClaim, taskID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return err
+	}
+	r.log.Debug("deletion succeeded", idAttr)
+	return nil
+}
+
+func (r *daoPgx) SelectDone(source data.Source, idemKey string) (bool, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	keyAttr := slog.String("idemKey", idemKey)
+	var done bool
+	err := ds.Conn.QueryRow(ds.Ctx, selectDone, idemKey).Scan(&done)
+	if err != nil {
+		r.log.Error("execution failed", keyAttr)
+		return false, err
+	}
+	return done, nil
+}
+
+const (
+	// pending services joined with the client side bindings
+	selectTasks = `
+		with liabs as not materialized (
+			select distinct on (proc_id)
+				*
+			from pool_liabs
+			order by proc_id, abs(rev) desc
+		), bnds as not materialized (
+			select distinct on (proc_id, chnl_ph)
+				*
+			from proc_bnds
+			order by proc_id, chnl_ph, abs(rev) desc
+		)
+		select
+			step.chnl_id as task_id,
+			liab.pool_id,
+			bnd.proc_id,
+			bnd.chnl_ph,
+			bnd.state_id,
+			step.spec,
+			coalesce(claim.agent_qn, '') as agent_qn
+		from proc_steps step
+		join bnds bnd
+			on bnd.chnl_id = step.chnl_id
+			and bnd.proc_id <> step.proc_id
+			and bnd.rev > 0
+		join liabs liab
+			on liab.proc_id = bnd.proc_id
+			and liab.rev > 0
+		left join inbox_claims claim
+			on claim.chnl_id = step.chnl_id
+		-- services only
+		where step.kind = 2`
+
+	insertClaim = `
+		insert into inbox_claims (
+			chnl_id, agent_qn, claimed_at
+		) values (
+			@chnl_id, @agent_qn, now()
+		)
+		on conflict (chnl_id) do nothing`
+
+	deleteClaim = `
+		delete from inbox_claims
+		where chnl_id = $1`
+
+	selectDone = `
+		select exists (
+			select 1
+			from pool_idems
+			where idem_key = $1
+		)`
+)
@@ -0,0 +1,183 @@
+package inbox
+
+import (
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	procdef "orglang/orglang/aat/proc/def"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	name := slog.String("name", "inboxDaoSql")
+	return &daoSql{l.With(name)}
+}
+
+func (r *daoSql) SelectRecsByPool(source data.Source, poolID id.ADT) ([]TaskRec, error) {
+	return r.selectRecs(source, selectTasksSql+" and liab.pool_id = ?", poolID.String())
+}
+
+func (r *daoSql) SelectRecsByAgent(source data.Source, agentQN sym.ADT) ([]TaskRec, error) {
+	return r.selectRecs(source, selectTasksSql+" and claim.agent_qn = ?", string(agentQN))
+}
+
+func (r *daoSql) selectRecs(source data.Source, query string, arg string) ([]TaskRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	argAttr := slog.String("arg", arg)
+	rows, err := ds.Conn.QueryContext(ds.Ctx, query, arg)
+	if err != nil {
+		r.log.Error("execution failed", argAttr)
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, scanTaskSql)
+	if err != nil {
+		r.log.Error("collection failed", argAttr)
+		return nil, err
+	}
+	recs, err := dataToTaskRecs(dtos)
+	if err != nil {
+		r.log.Error("mapping failed", argAttr)
+		return nil, err
+	}
+	r.log.Debug("selection succeeded", argAttr, slog.Int("count", len(recs)))
+	return recs, nil
+}
+
+func (r *daoSql) SelectRecByID(source data.Source, taskID id.ADT) (TaskRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("taskID", taskID)
+	rows, err := ds.Conn.QueryContext(ds.Ctx, selectTasksSql+" and step.chnl_id = ?", taskID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return TaskRec{}, err
+	}
+	dtos, err := data.CollectRowsSql(rows, scanTaskSql)
+	if err != nil {
+		r.log.Error("collection failed", idAttr)
+		return TaskRec{}, err
+	}
+	if len(dtos) == 0 {
+		return TaskRec{}, errMissingTask(taskID)
+	}
+	rec, err := dataToTaskRec(dtos[0])
+	if err != nil {
+		r.log.Error("mapping failed", idAttr)
+		return TaskRec{}, err
+	}
+	r.log.Debug("selection succeeded", idAttr)
+	return rec, nil
+}
+
+func (r *daoSql) InsertClaim(source data.Source, spec ClaimSpec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("taskID", spec.TaskID)
+	args := data.NamedArgsSql{
+		"chnl_id":    spec.TaskID.String(),
+		"agent_qn":   string(spec.AgentQN),
+		"claimed_at": data.TimeSql{V: time.Now()},
+	}
+	res, err := ds.Conn.ExecContext(ds.Ctx, insertClaimSql, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errClaimedByOther(spec.TaskID, "")
+	}
+	r.log.Debug("insertion succeeded", idAttr)
+	return nil
+}
+
+func (r *daoSql) DeleteClaim(source data.Source, taskID id.ADT) error {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("taskID", taskID)
+	_, err := ds.Conn.ExecContext(ds.Ctx, deleteClaim, taskID.String())
+	if err != nil {
+		r.log.Error("execution failed", idAttr)
+		return err
+	}
+	r.log.Debug("deletion succeeded", idAttr)
+	return nil
+}
+
+func (r *daoSql) SelectDone(source data.Source, idemKey string) (bool, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	keyAttr := slog.String("idemKey", idemKey)
+	var done bool
+	err := ds.Conn.QueryRowContext(ds.Ctx, selectDone, idemKey).Scan(&done)
+	if err != nil {
+		r.log.Error("execution failed", keyAttr)
+		return false, err
+	}
+	return done, nil
+}
+
+func scanTaskSql(rows *sql.Rows) (dto taskRecDS, err error) {
+	cont := data.JsonSql[procdef.TermRecDS]{}
+	err = rows.Scan(&dto.TaskID, &dto.PoolID, &dto.ProcID, &dto.ChnlPH, &dto.TermID, &cont, &dto.AgentQN)
+	dto.Cont = cont.V
+	return dto, err
+}
+
+const (
+	// window functions stand in for distinct on
+	selectTasksSql = `
+		with liabs as (
+			select * from (
+				select
+					liab.*,
+					row_number() over (partition by proc_id order by abs(rev) desc) as pos
+				from pool_liabs liab
+			)
+			where pos = 1
+		), bnds as (
+			select * from (
+				select
+					bnd.*,
+					row_number() over (partition by proc_id, chnl_ph order by abs(rev) desc) as pos
+				from proc_bnds bnd
+			)
+			where pos = 1
+		)
+		select
+			step.chnl_id as task_id,
+			liab.pool_id,
+			bnd.proc_id,
+			bnd.chnl_ph,
+			bnd.state_id,
+			step.spec,
+			coalesce(claim.agent_qn, '') as agent_qn
+		from proc_steps step
+		join bnds bnd
+			on bnd.chnl_id = step.chnl_id
+			and bnd.proc_id <> step.proc_id
+			and bnd.rev > 0
+		join liabs liab
+			on liab.proc_id = bnd.proc_id
+			and liab.rev > 0
+		left join inbox_claims claim
+			on claim.chnl_id = step.chnl_id
+		-- services only
+		where step.kind = 2`
+
+	insertClaimSql = `
+		insert into inbox_claims (
+			chnl_id, agent_qn, claimed_at
+		) values (
+			@chnl_id, @agent_qn, @claimed_at
+		)
+		on conflict (chnl_id) do nothing`
+)
@@ -0,0 +1,42 @@
+package inbox
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+)
+
+func (dto PoolIdentME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.PoolID, id.Required...),
+	)
+}
+
+func (dto AgentIdentME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.AgentQN, sym.Required...),
+	)
+}
+
+func (dto TaskIdentME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.TaskID, id.Required...),
+	)
+}
+
+func (dto ClaimSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.TaskID, id.Required...),
+		validation.Field(&dto.AgentQN, sym.Required...),
+	)
+}
+
+func (dto DoneSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.TaskID, id.Required...),
+		validation.Field(&dto.AgentQN, sym.Required...),
+		validation.Field(&dto.Label, sym.ReqiredWhen(dto.ValPH == "")...),
+		validation.Field(&dto.ValPH, sym.ReqiredWhen(dto.Label == "")...),
+	)
+}
@@ -0,0 +1,43 @@
+package inbox
+
+type PoolIdentME struct {
+	PoolID string `param:"id"`
+}
+
+type AgentIdentME struct {
+	AgentQN string `param:"qn"`
+}
+
+type TaskIdentME struct {
+	TaskID string `param:"id"`
+}
+
+type TaskRefME struct {
+	TaskID  string `json:"task_id"`
+	PoolID  string `json:"pool_id"`
+	ProcID  string `json:"proc_id"`
+	Kind    string `json:"kind"`
+	AgentQN string `json:"agent_qn,omitempty"`
+}
+
+type TaskSnapME struct {
+	TaskID  string   `json:"task_id"`
+	PoolID  string   `json:"pool_id"`
+	ProcID  string   `json:"proc_id"`
+	Kind    string   `json:"kind"`
+	AgentQN string   `json:"agent_qn,omitempty"`
+	ChnlPH  string   `json:"chnl_ph"`
+	Labels  []string `json:"labels,omitempty"`
+}
+
+type ClaimSpecME struct {
+	TaskID  string `json:"task_id" param:"id"`
+	AgentQN string `json:"agent_qn" form:"agent_qn"`
+}
+
+type DoneSpecME struct {
+	TaskID  string `json:"task_id" param:"id"`
+	AgentQN string `json:"agent_qn" form:"agent_qn"`
+	Label   string `json:"label,omitempty" form:"label"`
+	ValPH   string `json:"val_ph,omitempty" form:"val_ph"`
+}
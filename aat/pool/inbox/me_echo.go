@@ -0,0 +1,143 @@
+package inbox
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+)
+
+// Adapter
+type handlerEcho struct {
+	api API
+	log *slog.Logger
+}
+
+func newHandlerEcho(a API, l *slog.Logger) *handlerEcho {
+	name := slog.String("name", "inboxHandlerEcho")
+	return &handlerEcho{a, l.With(name)}
+}
+
+func (h *handlerEcho) GetByPool(c echo.Context) error {
+	var dto PoolIdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	refs, err := h.api.RetrieveByPool(c.Request().Context(), poolID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromTaskRefs(refs))
+}
+
+func (h *handlerEcho) GetByAgent(c echo.Context) error {
+	var dto AgentIdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	agentQN, err := sym.ConvertFromString(dto.AgentQN)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	refs, err := h.api.RetrieveByAgent(c.Request().Context(), agentQN)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromTaskRefs(refs))
+}
+
+func (h *handlerEcho) GetOne(c echo.Context) error {
+	var dto TaskIdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	taskID, err := id.ConvertFromString(dto.TaskID)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	snap, err := h.api.Retrieve(c.Request().Context(), taskID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromTaskSnap(snap))
+}
+
+func (h *handlerEcho) PostClaim(c echo.Context) error {
+	var dto ClaimSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec, err := MsgToClaimSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	ref, err := h.api.Claim(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromTaskRef(ref))
+}
+
+func (h *handlerEcho) PostDone(c echo.Context) error {
+	var dto DoneSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec, err := MsgToDoneSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	err = h.api.Complete(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
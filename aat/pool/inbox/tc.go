@@ -0,0 +1,52 @@
+package inbox
+
+import (
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	procdef "orglang/orglang/aat/proc/def"
+)
+
+func dataToTaskRec(dto taskRecDS) (TaskRec, error) {
+	taskID, err := id.ConvertFromString(dto.TaskID)
+	if err != nil {
+		return TaskRec{}, err
+	}
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		return TaskRec{}, err
+	}
+	procID, err := id.ConvertFromString(dto.ProcID)
+	if err != nil {
+		return TaskRec{}, err
+	}
+	termID, err := id.ConvertFromString(dto.TermID)
+	if err != nil {
+		return TaskRec{}, err
+	}
+	cont, err := procdef.DataToTermRec(dto.Cont)
+	if err != nil {
+		return TaskRec{}, err
+	}
+	return TaskRec{
+		TaskID:  taskID,
+		PoolID:  poolID,
+		ProcID:  procID,
+		ChnlPH:  sym.ADT(dto.ChnlPH),
+		TermID:  termID,
+		Cont:    cont,
+		AgentQN: sym.ADT(dto.AgentQN),
+	}, nil
+}
+
+func dataToTaskRecs(dtos []taskRecDS) ([]TaskRec, error) {
+	recs := make([]TaskRec, 0, len(dtos))
+	for _, dto := range dtos {
+		rec, err := dataToTaskRec(dto)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
@@ -0,0 +1,18 @@
+package inbox
+
+// goverter:variables
+// goverter:output:format assign-variable
+// goverter:extend orglang/orglang/avt/id:Convert.*
+// goverter:extend orglang/orglang/avt/sym:Convert.*
+var (
+	MsgFromTaskRef   func(TaskRef) TaskRefME
+	MsgToTaskRef     func(TaskRefME) (TaskRef, error)
+	MsgFromTaskRefs  func([]TaskRef) []TaskRefME
+	MsgToTaskRefs    func([]TaskRefME) ([]TaskRef, error)
+	MsgFromTaskSnap  func(TaskSnap) TaskSnapME
+	MsgToTaskSnap    func(TaskSnapME) (TaskSnap, error)
+	MsgFromClaimSpec func(ClaimSpec) ClaimSpecME
+	MsgToClaimSpec   func(ClaimSpecME) (ClaimSpec, error)
+	MsgFromDoneSpec  func(DoneSpec) DoneSpecME
+	MsgToDoneSpec    func(DoneSpecME) (DoneSpec, error)
+)
@@ -0,0 +1,33 @@
+package inbox
+
+type TaskRefView struct {
+	TaskID  string `json:"task_id"`
+	ProcID  string `json:"proc_id"`
+	Kind    string `json:"kind"`
+	AgentQN string `json:"agent_qn"`
+}
+
+type TaskListView struct {
+	PoolID string        `json:"pool_id"`
+	Tasks  []TaskRefView `json:"tasks"`
+}
+
+type TaskSnapView struct {
+	TaskID  string   `json:"task_id"`
+	PoolID  string   `json:"pool_id"`
+	ProcID  string   `json:"proc_id"`
+	Kind    string   `json:"kind"`
+	AgentQN string   `json:"agent_qn"`
+	ChnlPH  string   `json:"chnl_ph"`
+	Labels  []string `json:"labels"`
+}
+
+// goverter:variables
+// goverter:output:format assign-variable
+// goverter:extend orglang/orglang/avt/id:Convert.*
+// goverter:extend orglang/orglang/avt/sym:Convert.*
+var (
+	ViewFromTaskRef  func(TaskRef) TaskRefView
+	ViewFromTaskRefs func([]TaskRef) []TaskRefView
+	ViewFromTaskSnap func(TaskSnap) TaskSnapView
+)
@@ -0,0 +1,143 @@
+package inbox
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/msg"
+)
+
+// Adapter
+type presenterEcho struct {
+	api API
+	ssr msg.Renderer
+	log *slog.Logger
+}
+
+func newPresenterEcho(a API, r msg.Renderer, l *slog.Logger) *presenterEcho {
+	name := slog.String("name", "inboxPresenterEcho")
+	return &presenterEcho{a, r, l.With(name)}
+}
+
+func (p *presenterEcho) GetMany(c echo.Context) error {
+	var dto PoolIdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		p.log.Error("dto binding failed")
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		p.log.Error("dto validation failed")
+		return err
+	}
+	poolID, err := id.ConvertFromString(dto.PoolID)
+	if err != nil {
+		p.log.Error("dto mapping failed")
+		return err
+	}
+	refs, err := p.api.RetrieveByPool(c.Request().Context(), poolID)
+	if err != nil {
+		p.log.Error("refs retrieval failed")
+		return err
+	}
+	view := TaskListView{PoolID: dto.PoolID, Tasks: ViewFromTaskRefs(refs)}
+	html, err := p.ssr.Render("view-many", view)
+	if err != nil {
+		p.log.Error("view rendering failed")
+		return err
+	}
+	return c.HTMLBlob(http.StatusOK, html)
+}
+
+func (p *presenterEcho) GetOne(c echo.Context) error {
+	var dto TaskIdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		p.log.Error("dto binding failed")
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		p.log.Error("dto validation failed")
+		return err
+	}
+	taskID, err := id.ConvertFromString(dto.TaskID)
+	if err != nil {
+		p.log.Error("dto mapping failed")
+		return err
+	}
+	return p.renderOne(c, taskID)
+}
+
+func (p *presenterEcho) PostClaim(c echo.Context) error {
+	var dto ClaimSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		p.log.Error("dto binding failed")
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		p.log.Error("dto validation failed")
+		return err
+	}
+	spec, err := MsgToClaimSpec(dto)
+	if err != nil {
+		p.log.Error("dto mapping failed")
+		return err
+	}
+	_, err = p.api.Claim(c.Request().Context(), spec)
+	if err != nil {
+		p.log.Error("task claiming failed")
+		return err
+	}
+	return p.renderOne(c, spec.TaskID)
+}
+
+func (p *presenterEcho) PostDone(c echo.Context) error {
+	var dto DoneSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		p.log.Error("dto binding failed")
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		p.log.Error("dto validation failed")
+		return err
+	}
+	spec, err := MsgToDoneSpec(dto)
+	if err != nil {
+		p.log.Error("dto mapping failed")
+		return err
+	}
+	err = p.api.Complete(c.Request().Context(), spec)
+	if err != nil {
+		p.log.Error("task completion failed")
+		return err
+	}
+	html, err := p.ssr.Render("view-done", dto)
+	if err != nil {
+		p.log.Error("view rendering failed")
+		return err
+	}
+	return c.HTMLBlob(http.StatusOK, html)
+}
+
+func (p *presenterEcho) renderOne(c echo.Context, taskID id.ADT) error {
+	snap, err := p.api.Retrieve(c.Request().Context(), taskID)
+	if err != nil {
+		p.log.Error("snap retrieval failed")
+		return err
+	}
+	html, err := p.ssr.Render("view-one", ViewFromTaskSnap(snap))
+	if err != nil {
+		p.log.Error("view rendering failed")
+		return err
+	}
+	return c.HTMLBlob(http.StatusOK, html)
+}
@@ -0,0 +1,11 @@
+package ledger
+
+type props struct {
+	Weights weightProps `mapstructure:"weights"`
+}
+
+type weightProps struct {
+	Default    int64            `mapstructure:"default"`
+	Signatures map[string]int64 `mapstructure:"signatures"`
+	Labels     map[string]int64 `mapstructure:"labels"`
+}
@@ -0,0 +1,167 @@
+package ledger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+
+	"orglang/orglang/aet/alias"
+)
+
+// Port
+type API interface {
+	Aggregate(context.Context, AggSpec) ([]Contribution, error)
+}
+
+// Port for the executor, runs within the step transaction
+type Writer interface {
+	Write(data.Source, EntrySpec) error
+}
+
+type EntrySpec struct {
+	PoolID id.ADT
+	ProcID id.ADT
+	ChnlID id.ADT
+	PoolRN rn.ADT
+	// optional attribution
+	AgentQN sym.ADT
+	SigID   id.ADT
+	Label   sym.ADT
+}
+
+type EntryRec struct {
+	PoolID  id.ADT
+	ProcID  id.ADT
+	ChnlID  id.ADT
+	PoolRN  rn.ADT
+	AgentQN sym.ADT
+	Weight  int64
+	At      time.Time
+}
+
+type GroupKind string
+
+const (
+	ByPool  = GroupKind("pool")
+	ByAgent = GroupKind("agent")
+)
+
+type AggSpec struct {
+	PoolID id.ADT
+	By     GroupKind
+	From   time.Time
+	To     time.Time
+}
+
+type Contribution struct {
+	// pool id or agent qn depending on grouping
+	Key    string
+	Steps  int64
+	Weight int64
+}
+
+type service struct {
+	entries  Repo
+	aliases  alias.Repo
+	weights  weights
+	operator data.Operator
+	log      *slog.Logger
+}
+
+// for compilation purposes
+func newAPI() API {
+	return &service{}
+}
+
+func newService(entries Repo, aliases alias.Repo, operator data.Operator, p *props, l *slog.Logger) *service {
+	name := slog.String("name", "ledgerService")
+	w := weights{
+		Default:    p.Weights.Default,
+		Signatures: lowerKeys(p.Weights.Signatures),
+		Labels:     lowerKeys(p.Weights.Labels),
+	}
+	return &service{entries, aliases, w, operator, l.With(name)}
+}
+
+func (s *service) Write(ds data.Source, spec EntrySpec) error {
+	var sigQN sym.ADT
+	// the weights name signatures the way people do
+	if !spec.SigID.IsEmpty() && len(s.weights.Signatures) > 0 {
+		entry, err := s.aliases.SelectByID(ds, spec.SigID)
+		if err != nil {
+			s.log.Error("writing failed", slog.Any("sigID", spec.SigID))
+			return err
+		}
+		sigQN = entry.QN
+	}
+	rec := EntryRec{
+		PoolID:  spec.PoolID,
+		ProcID:  spec.ProcID,
+		ChnlID:  spec.ChnlID,
+		PoolRN:  spec.PoolRN,
+		AgentQN: spec.AgentQN,
+		Weight:  s.weights.of(spec.Label, sigQN),
+		At:      time.Now(),
+	}
+	err := s.entries.Insert(ds, rec)
+	if err != nil {
+		s.log.Error("writing failed", slog.Any("procID", spec.ProcID))
+		return err
+	}
+	return nil
+}
+
+func (s *service) Aggregate(ctx context.Context, spec AggSpec) (_ []Contribution, err error) {
+	if spec.To.IsZero() {
+		spec.To = time.Now()
+	}
+	var contribs []Contribution
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		contribs, err = s.entries.SelectAgg(ds, spec)
+		return err
+	})
+	if err != nil {
+		s.log.Error("aggregation failed", slog.Any("spec", spec))
+		return nil, err
+	}
+	return contribs, nil
+}
+
+// keyed by lowercase names, since viper lowercases map keys
+type weights struct {
+	Default int64
+	// by qualified name
+	Signatures map[string]int64
+	Labels     map[string]int64
+}
+
+// labels are more specific than signatures
+func (w weights) of(label sym.ADT, sigQN sym.ADT) int64 {
+	if label != "" {
+		weight, ok := w.Labels[strings.ToLower(string(label))]
+		if ok {
+			return weight
+		}
+	}
+	if sigQN != "" {
+		weight, ok := w.Signatures[strings.ToLower(string(sigQN))]
+		if ok {
+			return weight
+		}
+	}
+	return w.Default
+}
+
+func lowerKeys(m map[string]int64) map[string]int64 {
+	lowered := make(map[string]int64, len(m))
+	for k, v := range m {
+		lowered[strings.ToLower(k)] = v
+	}
+	return lowered
+}
@@ -0,0 +1,138 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+
+	"orglang/orglang/aet/alias"
+)
+
+func TestMain(m *testing.M) {
+	// the generated converters are not checked in
+	if DataFromEntryRec == nil {
+		DataFromEntryRec = func(rec EntryRec) entryRecDS {
+			return entryRecDS{
+				PoolID: rec.PoolID.String(),
+				ProcID: rec.ProcID.String(),
+				ChnlID: sql.NullString{String: rec.ChnlID.String(), Valid: true},
+				PoolRN: rn.ConvertToInt(rec.PoolRN),
+				// unattributed steps come as empty strings, not nulls
+				AgentQN: sql.NullString{String: string(rec.AgentQN), Valid: true},
+				Weight:  rec.Weight,
+				At:      rec.At,
+			}
+		}
+	}
+	if DataToContribs == nil {
+		DataToContribs = func(dtos []contributionDS) ([]Contribution, error) {
+			contribs := make([]Contribution, 0, len(dtos))
+			for _, dto := range dtos {
+				contribs = append(contribs, Contribution(dto))
+			}
+			return contribs, nil
+		}
+	}
+	os.Exit(m.Run())
+}
+
+func TestWeights(t *testing.T) {
+	// the keys come as written when the props skip viper
+	p := &props{Weights: weightProps{
+		Default:    1,
+		Signatures: map[string]int64{"Billing.Invoice": 5},
+		Labels:     map[string]int64{"Approve": 10},
+	}}
+	w := newService(nil, nil, nil, p, slog.Default()).weights
+	tests := []struct {
+		name  string
+		label sym.ADT
+		sigQN sym.ADT
+		want  int64
+	}{
+		{"default", "", "", 1},
+		{"signature", "", "billing.invoice", 5},
+		{"mixed case signature", "", "BILLING.Invoice", 5},
+		{"label", "approve", "", 10},
+		{"mixed case label", "APPROVE", "", 10},
+		{"label over signature", "approve", "billing.invoice", 10},
+		{"unknown label", "reject", "billing.invoice", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.of(tt.label, tt.sigQN); got != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteWeighsBySignatureQN(t *testing.T) {
+	operator := data.NewOperatorMem()
+	sigID := id.New()
+	aliases := aliasRepoStub{qns: map[id.ADT]sym.ADT{sigID: "billing.Invoice"}}
+	// viper lowercases the keys on load
+	p := &props{Weights: weightProps{Default: 1, Signatures: map[string]int64{"billing.invoice": 5}}}
+	s := newService(newDaoMem(slog.Default()), aliases, operator, p, slog.Default())
+	ctx := context.Background()
+	poolID := id.New()
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		return s.Write(ds, EntrySpec{PoolID: poolID, ProcID: id.New(), AgentQN: "alice", SigID: sigID})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Aggregate(ctx, AggSpec{PoolID: poolID, By: ByAgent, From: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Weight != 5 {
+		t.Fatalf("want weight 5, got %v", got)
+	}
+}
+
+func TestAggregateByAgentMem(t *testing.T) {
+	operator := data.NewOperatorMem()
+	s := newService(newDaoMem(slog.Default()), aliasRepoStub{}, operator, &props{Weights: weightProps{Default: 1}}, slog.Default())
+	ctx := context.Background()
+	poolID := id.New()
+	agents := []sym.ADT{"alice", "", "alice", "bob"}
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		for _, agentQN := range agents {
+			err := s.Write(ds, EntrySpec{PoolID: poolID, ProcID: id.New(), AgentQN: agentQN})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Aggregate(ctx, AggSpec{PoolID: poolID, By: ByAgent, From: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Contribution{{"alice", 2, 2}, {"bob", 1, 1}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+// current names by entity id, the rest of alias.Repo stays unimplemented
+type aliasRepoStub struct {
+	alias.Repo
+	qns map[id.ADT]sym.ADT
+}
+
+func (r aliasRepoStub) SelectByID(source data.Source, entityID id.ADT) (alias.Entry, error) {
+	return alias.Entry{ID: entityID, QN: r.qns[entityID], Kind: alias.ProcDecKind}, nil
+}
@@ -0,0 +1,55 @@
+//go:build !goverter
+
+package ledger
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
+)
+
+var Module = fx.Module("aat/pool/ledger",
+	fx.Provide(
+		newService,
+		fx.Annotate(func(s *service) API { return s }),
+		fx.Annotate(func(s *service) Writer { return s }),
+	),
+	fx.Provide(
+		fx.Private,
+		newHandlerEcho,
+		newRepoByMapping,
+		newCfg,
+	),
+	fx.Invoke(
+		cfgEcho,
+	),
+)
+
+func newRepoByMapping(m data.Mapping, l *slog.Logger) Repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
+func newCfg(k core.Keeper) (*props, error) {
+	props := &props{Weights: weightProps{Default: 1}}
+	err := k.Load("ledger", props)
+	if err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func cfgEcho(e *echo.Echo, h *handlerEcho) error {
+	e.GET("/api/v1/pools/:id/contributions", h.GetMany)
+	return nil
+}
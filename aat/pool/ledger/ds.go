@@ -0,0 +1,30 @@
+package ledger
+
+import (
+	"database/sql"
+	"time"
+
+	"orglang/orglang/avt/data"
+)
+
+// Port
+type Repo interface {
+	Insert(data.Source, EntryRec) error
+	SelectAgg(data.Source, AggSpec) ([]Contribution, error)
+}
+
+type entryRecDS struct {
+	PoolID  string         `db:"pool_id"`
+	ProcID  string         `db:"proc_id"`
+	ChnlID  sql.NullString `db:"chnl_id"`
+	PoolRN  int64          `db:"rev"`
+	AgentQN sql.NullString `db:"agent_qn"`
+	Weight  int64          `db:"weight"`
+	At      time.Time      `db:"created_at"`
+}
+
+type contributionDS struct {
+	Key    string `db:"key"`
+	Steps  int64  `db:"steps"`
+	Weight int64  `db:"weight"`
+}
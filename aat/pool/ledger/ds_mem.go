@@ -0,0 +1,60 @@
+package ledger
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+
+	"orglang/orglang/avt/data"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	name := slog.String("name", "ledgerDaoMem")
+	return &daoMem{l.With(name)}
+}
+
+const entriesMem = "ledger_entries"
+
+func (r *daoMem) Insert(source data.Source, rec EntryRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.InsertRows(ds, entriesMem, DataFromEntryRec(rec))
+	return nil
+}
+
+// sub-pools are out of reach here, the pool counts on its own
+func (r *daoMem) SelectAgg(source data.Source, spec AggSpec) ([]Contribution, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	groups := make(map[string]*contributionDS)
+	var order []string
+	for _, row := range data.Rows[entryRecDS](ds, entriesMem) {
+		if row.PoolID != spec.PoolID.String() || row.At.Before(spec.From) || !row.At.Before(spec.To) {
+			continue
+		}
+		key := row.PoolID
+		if spec.By == ByAgent {
+			if !row.AgentQN.Valid || row.AgentQN.String == "" {
+				continue
+			}
+			key = row.AgentQN.String
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &contributionDS{Key: key}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Steps++
+		group.Weight += row.Weight
+	}
+	dtos := make([]contributionDS, 0, len(order))
+	for _, key := range order {
+		dtos = append(dtos, *groups[key])
+	}
+	slices.SortStableFunc(dtos, func(a, b contributionDS) int { return cmp.Compare(b.Weight, a.Weight) })
+	return DataToContribs(dtos)
+}
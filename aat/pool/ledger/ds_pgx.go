@@ -0,0 +1,115 @@
+package ledger
+
+import (
+	"log/slog"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+
+	"orglang/orglang/avt/data"
+)
+
+// Adapter
+type daoPgx struct {
+	log *slog.Logger
+}
+
+func newDaoPgx(l *slog.Logger) *daoPgx {
+	name := slog.String("name", "ledgerDaoPgx")
+	return &daoPgx{l.With(name)}
+}
+
+// for compilation purposes
+func newRepo() Repo {
+	return &daoPgx{}
+}
+
+func (r *daoPgx) Insert(source data.Source, rec EntryRec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	dto := DataFromEntryRec(rec)
+	args := pgx.NamedArgs{
+		"pool_id":    dto.PoolID,
+		"proc_id":    dto.ProcID,
+		"chnl_id":    dto.ChnlID,
+		"rev":        dto.PoolRN,
+		"agent_qn":   dto.AgentQN,
+		"weight":     dto.Weight,
+		"created_at": dto.At,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, insertEntry, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dto", dto))
+		return err
+	}
+	r.log.Debug("insertion succeeded", slog.Any("procID", rec.ProcID))
+	return nil
+}
+
+func (r *daoPgx) SelectAgg(source data.Source, spec AggSpec) ([]Contribution, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	query := selectByPool
+	if spec.By == ByAgent {
+		query = selectByAgent
+	}
+	args := pgx.NamedArgs{
+		"pool_id": spec.PoolID.String(),
+		"from":    spec.From,
+		"to":      spec.To,
+	}
+	rows, err := ds.Conn.Query(ds.Ctx, query, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("spec", spec))
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[contributionDS])
+	if err != nil {
+		r.log.Error("collection failed", slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	contribs, err := DataToContribs(dtos)
+	if err != nil {
+		r.log.Error("mapping failed")
+		return nil, err
+	}
+	return contribs, nil
+}
+
+const (
+	insertEntry = `
+		insert into ledger_entries (
+			pool_id, proc_id, chnl_id, rev, agent_qn, weight, created_at
+		) values (
+			@pool_id, @proc_id, @chnl_id, @rev, @agent_qn, @weight, @created_at
+		)`
+
+	// the pool itself and its direct sub-pools
+	selectByPool = `
+		select
+			entry.pool_id as key,
+			count(*) as steps,
+			sum(entry.weight) as weight
+		from ledger_entries entry
+		join pool_roots pool
+			on pool.pool_id = entry.pool_id
+		where (pool.pool_id = @pool_id or pool.sup_pool_id = @pool_id)
+			and entry.created_at >= @from
+			and entry.created_at < @to
+		group by entry.pool_id
+		order by weight desc`
+
+	selectByAgent = `
+		select
+			entry.agent_qn as key,
+			count(*) as steps,
+			sum(entry.weight) as weight
+		from ledger_entries entry
+		join pool_roots pool
+			on pool.pool_id = entry.pool_id
+		where (pool.pool_id = @pool_id or pool.sup_pool_id = @pool_id)
+			and entry.agent_qn <> ''
+			and entry.created_at >= @from
+			and entry.created_at < @to
+		group by entry.agent_qn
+		order by weight desc`
+)
@@ -0,0 +1,72 @@
+package ledger
+
+import (
+	"database/sql"
+	"log/slog"
+
+	"orglang/orglang/avt/data"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	name := slog.String("name", "ledgerDaoSql")
+	return &daoSql{l.With(name)}
+}
+
+func (r *daoSql) Insert(source data.Source, rec EntryRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := DataFromEntryRec(rec)
+	args := data.NamedArgsSql{
+		"pool_id":    dto.PoolID,
+		"proc_id":    dto.ProcID,
+		"chnl_id":    dto.ChnlID,
+		"rev":        dto.PoolRN,
+		"agent_qn":   dto.AgentQN,
+		"weight":     dto.Weight,
+		"created_at": data.TimeSql{V: dto.At},
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, insertEntry, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dto", dto))
+		return err
+	}
+	r.log.Debug("insertion succeeded", slog.Any("procID", rec.ProcID))
+	return nil
+}
+
+// the queries carry over as is, only the timestamps need the text form
+func (r *daoSql) SelectAgg(source data.Source, spec AggSpec) ([]Contribution, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	query := selectByPool
+	if spec.By == ByAgent {
+		query = selectByAgent
+	}
+	args := data.NamedArgsSql{
+		"pool_id": spec.PoolID.String(),
+		"from":    data.TimeSql{V: spec.From},
+		"to":      data.TimeSql{V: spec.To},
+	}
+	rows, err := ds.Conn.QueryContext(ds.Ctx, query, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("spec", spec))
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto contributionDS, err error) {
+		err = rows.Scan(&dto.Key, &dto.Steps, &dto.Weight)
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("collection failed")
+		return nil, err
+	}
+	contribs, err := DataToContribs(dtos)
+	if err != nil {
+		r.log.Error("mapping failed")
+		return nil, err
+	}
+	return contribs, nil
+}
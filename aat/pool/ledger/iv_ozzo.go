@@ -0,0 +1,15 @@
+package ledger
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"orglang/orglang/avt/id"
+)
+
+func (dto AggSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.PoolID, id.Required...),
+		validation.Field(&dto.By, validation.Required, validation.In(string(ByPool), string(ByAgent))),
+		validation.Field(&dto.To, validation.When(!dto.To.IsZero(), validation.Min(dto.From))),
+	)
+}
@@ -0,0 +1,18 @@
+package ledger
+
+import (
+	"time"
+)
+
+type AggSpecME struct {
+	PoolID string    `json:"pool_id" param:"id"`
+	By     string    `json:"by" query:"by"`
+	From   time.Time `json:"from" query:"from"`
+	To     time.Time `json:"to" query:"to"`
+}
+
+type ContributionME struct {
+	Key    string `json:"key"`
+	Steps  int64  `json:"steps"`
+	Weight int64  `json:"weight"`
+}
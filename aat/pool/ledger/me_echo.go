@@ -0,0 +1,44 @@
+package ledger
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Adapter
+type handlerEcho struct {
+	api API
+	log *slog.Logger
+}
+
+func newHandlerEcho(a API, l *slog.Logger) *handlerEcho {
+	name := slog.String("name", "ledgerHandlerEcho")
+	return &handlerEcho{a, l.With(name)}
+}
+
+func (h *handlerEcho) GetMany(c echo.Context) error {
+	dto := AggSpecME{By: string(ByPool)}
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec, err := MsgToAggSpec(dto)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	contribs, err := h.api.Aggregate(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, MsgFromContribs(contribs))
+}
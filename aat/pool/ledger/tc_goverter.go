@@ -0,0 +1,14 @@
+package ledger
+
+// goverter:variables
+// goverter:output:format assign-variable
+// goverter:extend orglang/orglang/avt/id:Convert.*
+// goverter:extend orglang/orglang/avt/rn:Convert.*
+// goverter:extend orglang/orglang/avt/sym:Convert.*
+var (
+	DataFromEntryRec func(EntryRec) entryRecDS
+	DataToContribs   func([]contributionDS) ([]Contribution, error)
+	MsgToAggSpec     func(AggSpecME) (AggSpec, error)
+	MsgFromContribs  func([]Contribution) []ContributionME
+	MsgToContribs    func([]ContributionME) ([]Contribution, error)
+)
@@ -2,10 +2,10 @@ package dec
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 
 	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/rn"
 	"orglang/orglang/avt/sym"
@@ -14,10 +14,10 @@ import (
 )
 
 type API interface {
-	Incept(sym.ADT) (ProcRef, error)
-	Create(ProcSpec) (ProcSnap, error)
-	Retrieve(id.ADT) (ProcSnap, error)
-	RetreiveRefs() ([]ProcRef, error)
+	Incept(context.Context, sym.ADT) (ProcRef, error)
+	Create(context.Context, ProcSpec) (ProcSnap, error)
+	Retrieve(context.Context, id.ADT) (ProcSnap, error)
+	RetreiveRefs(context.Context) ([]ProcRef, error)
 }
 
 type ProcSpec struct {
@@ -74,13 +74,12 @@ func newService(procs Repo, aliases alias.Repo, operator data.Operator, l *slog.
 	return &service{procs, aliases, operator, l}
 }
 
-func (s *service) Incept(procQN sym.ADT) (_ ProcRef, err error) {
-	ctx := context.Background()
+func (s *service) Incept(ctx context.Context, procQN sym.ADT) (_ ProcRef, err error) {
 	qnAttr := slog.Any("procQN", procQN)
 	s.log.Debug("inception started", qnAttr)
-	newAlias := alias.Root{QN: procQN, ID: id.New(), RN: rn.Initial()}
+	newAlias := alias.Root{QN: procQN, ID: id.New(), RN: rn.Initial(), Kind: alias.ProcDecKind}
 	newRec := ProcRec{DecID: newAlias.ID, DecRN: newAlias.RN, Title: newAlias.QN.SN()}
-	s.operator.Explicit(ctx, func(ds data.Source) error {
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
 		err = s.aliases.Insert(ds, newAlias)
 		if err != nil {
 			return err
@@ -99,8 +98,7 @@ func (s *service) Incept(procQN sym.ADT) (_ ProcRef, err error) {
 	return ConvertRecToRef(newRec), nil
 }
 
-func (s *service) Create(spec ProcSpec) (_ ProcSnap, err error) {
-	ctx := context.Background()
+func (s *service) Create(ctx context.Context, spec ProcSpec) (_ ProcSnap, err error) {
 	qnAttr := slog.Any("sigQN", spec.ProcSN)
 	s.log.Debug("creation started", qnAttr, slog.Any("spec", spec))
 	newRec := ProcRec{
@@ -109,7 +107,7 @@ func (s *service) Create(spec ProcSpec) (_ ProcSnap, err error) {
 		Ys:    spec.ReceptionEPs,
 		DecRN: rn.Initial(),
 	}
-	s.operator.Explicit(ctx, func(ds data.Source) error {
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
 		err = s.procs.Insert(ds, newRec)
 		if err != nil {
 			return err
@@ -124,9 +122,8 @@ func (s *service) Create(spec ProcSpec) (_ ProcSnap, err error) {
 	return ConvertRecToSnap(newRec), nil
 }
 
-func (s *service) Retrieve(sigID id.ADT) (snap ProcSnap, err error) {
-	ctx := context.Background()
-	s.operator.Implicit(ctx, func(ds data.Source) error {
+func (s *service) Retrieve(ctx context.Context, sigID id.ADT) (snap ProcSnap, err error) {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
 		snap, err = s.procs.SelectByID(ds, sigID)
 		return err
 	})
@@ -137,9 +134,8 @@ func (s *service) Retrieve(sigID id.ADT) (snap ProcSnap, err error) {
 	return snap, nil
 }
 
-func (s *service) RetreiveRefs() (refs []ProcRef, err error) {
-	ctx := context.Background()
-	s.operator.Implicit(ctx, func(ds data.Source) error {
+func (s *service) RetreiveRefs(ctx context.Context) (refs []ProcRef, err error) {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
 		refs, err = s.procs.SelectAll(ds)
 		return err
 	})
@@ -161,6 +157,10 @@ func CollectEnv(recs []ProcRec) []sym.ADT {
 	return typeQNs
 }
 
+func ErrDoesNotExist(want id.ADT) error {
+	return fault.New(fault.NotFound, "root doesn't exist: %v", want)
+}
+
 func ErrRootMissingInEnv(rid id.ADT) error {
-	return fmt.Errorf("root missing in env: %v", rid)
+	return fault.New(fault.NotFound, "root missing in env: %v", rid)
 }
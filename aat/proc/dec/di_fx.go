@@ -10,27 +10,43 @@ import (
 	"github.com/Masterminds/sprig/v3"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
+	"google.golang.org/grpc"
 
+	"orglang/orglang/avt/data"
 	"orglang/orglang/avt/msg"
+	"orglang/orglang/rpc"
 )
 
 var Module = fx.Module("proc/dec",
 	fx.Provide(
 		fx.Annotate(newService, fx.As(new(API))),
-		fx.Annotate(newDaoPgx, fx.As(new(Repo))),
+		newRepoByMapping,
 	),
 	fx.Provide(
 		fx.Private,
 		newHandlerEcho,
+		newHandlerGrpc,
 		newPresenterEcho,
 		fx.Annotate(newRenderer, fx.As(new(msg.Renderer))),
 	),
 	fx.Invoke(
 		cfgApiEcho,
 		cfgSsrEcho,
+		cfgApiGrpc,
 	),
 )
 
+func newRepoByMapping(m data.Mapping, l *slog.Logger) Repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
 //go:embed *.html
 var viewFs embed.FS
 
@@ -54,3 +70,8 @@ func cfgSsrEcho(e *echo.Echo, p *presenterEcho) error {
 	e.GET("/ssr/signatures/:id", p.GetOne)
 	return nil
 }
+
+func cfgApiGrpc(s *grpc.Server, h *handlerGrpc) error {
+	rpc.RegisterDecServiceServer(s, h)
+	return nil
+}
@@ -0,0 +1,92 @@
+package dec
+
+import (
+	"log/slog"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	return &daoMem{l}
+}
+
+// declarations are kept denormalized
+const sigsMem = "sig_roots"
+
+func (r *daoMem) Insert(source data.Source, rec ProcRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	idAttr := slog.Any("id", rec.DecID)
+	dto, err := DataFromSigRec(rec)
+	if err != nil {
+		r.log.Error("model mapping failed", idAttr)
+		return err
+	}
+	data.InsertRows(ds, sigsMem, dto)
+	return nil
+}
+
+func (r *daoMem) SelectAll(source data.Source) ([]ProcRef, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	rows := data.Rows[sigRecDS](ds, sigsMem)
+	dtos := make([]sigRefDS, 0, len(rows))
+	for _, row := range rows {
+		dtos = append(dtos, sigRefDS{row.SigID, row.SigRN, row.Title})
+	}
+	return DataToSigRefs(dtos)
+}
+
+func (r *daoMem) SelectByID(source data.Source, recID id.ADT) (ProcSnap, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dto, ok := r.selectRow(ds, recID)
+	if !ok {
+		r.log.Error("entity selection failed", slog.Any("id", recID))
+		return ProcSnap{}, ErrDoesNotExist(recID)
+	}
+	return DataToSigSnap(sigSnapDS(dto))
+}
+
+func (r *daoMem) SelectByIDs(source data.Source, recIDs []id.ADT) ([]ProcRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dtos := make([]sigRecDS, 0, len(recIDs))
+	for _, recID := range recIDs {
+		if recID.IsEmpty() {
+			return nil, id.ErrEmpty
+		}
+		dto, ok := r.selectRow(ds, recID)
+		if !ok {
+			r.log.Error("entity selection failed", slog.Any("id", recID))
+			return nil, ErrRootMissingInEnv(recID)
+		}
+		dtos = append(dtos, dto)
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entities selection succeeded", slog.Any("dtos", dtos))
+	return DataToSigRecs(dtos)
+}
+
+func (r *daoMem) SelectEnv(source data.Source, recIDs []id.ADT) (map[id.ADT]ProcRec, error) {
+	recs, err := r.SelectByIDs(source, recIDs)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[id.ADT]ProcRec, len(recs))
+	for _, rec := range recs {
+		env[rec.DecID] = rec
+	}
+	return env, nil
+}
+
+func (r *daoMem) selectRow(ds data.SourceMem, recID id.ADT) (sigRecDS, bool) {
+	for _, row := range data.Rows[sigRecDS](ds, sigsMem) {
+		if row.SigID == recID.String() {
+			return row, true
+		}
+	}
+	return sigRecDS{}, false
+}
@@ -111,6 +111,9 @@ func (r *daoPgx) SelectByID(source data.Source, rid id.ADT) (ProcSnap, error) {
 	}
 	defer rows.Close()
 	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[sigSnapDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ProcSnap{}, ErrDoesNotExist(rid)
+	}
 	if err != nil {
 		r.log.Error("row collection failed", idAttr)
 		return ProcSnap{}, err
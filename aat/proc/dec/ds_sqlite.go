@@ -0,0 +1,183 @@
+package dec
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	return &daoSql{l}
+}
+
+func (r *daoSql) Insert(source data.Source, mod ProcRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("id", mod.DecID)
+	dto, err := DataFromSigRec(mod)
+	if err != nil {
+		r.log.Error("model mapping failed", idAttr)
+		return err
+	}
+	insertRoot := `
+		insert into sig_roots (
+			sig_id, rev, title
+		) values (
+			@sig_id, @rev, @title
+		)`
+	rootArgs := data.NamedArgsSql{
+		"sig_id": dto.SigID,
+		"rev":    dto.SigRN,
+		"title":  dto.Title,
+	}
+	_, err = ds.Conn.ExecContext(ds.Ctx, insertRoot, rootArgs.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", insertRoot))
+		return err
+	}
+	insertPE := `
+		insert into sig_pes (
+			sig_id, rev_from, rev_to, chnl_key, role_fqn
+		) values (
+			@sig_id, @rev_from, @rev_to, @chnl_key, @role_fqn
+		)`
+	peArgs := data.NamedArgsSql{
+		"sig_id":   dto.SigID,
+		"rev_from": dto.SigRN,
+		"rev_to":   int64(math.MaxInt64),
+		"chnl_key": dto.X.ChnlPH,
+		"role_fqn": dto.X.TypeQN,
+	}
+	_, err = ds.Conn.ExecContext(ds.Ctx, insertPE, peArgs.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", insertPE))
+		return err
+	}
+	insertCE := `
+		insert into sig_ces (
+			sig_id, rev_from, rev_to, chnl_key, role_fqn
+		) values (
+			@sig_id, @rev_from, @rev_to, @chnl_key, @role_fqn
+		)`
+	for _, ce := range dto.Ys {
+		args := data.NamedArgsSql{
+			"sig_id":   dto.SigID,
+			"rev_from": dto.SigRN,
+			"rev_to":   int64(math.MaxInt64),
+			"chnl_key": ce.ChnlPH,
+			"role_fqn": ce.TypeQN,
+		}
+		_, err = ds.Conn.ExecContext(ds.Ctx, insertCE, args.List()...)
+		if err != nil {
+			r.log.Error("query execution failed", idAttr, slog.String("q", insertCE))
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *daoSql) SelectByID(source data.Source, rid id.ADT) (ProcSnap, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("id", rid)
+	dto, err := scanSigSql(ds.Conn.QueryRowContext(ds.Ctx, selectByIdSql, rid.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ProcSnap{}, ErrDoesNotExist(rid)
+	}
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", selectByIdSql))
+		return ProcSnap{}, err
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entitiy selection succeeded", slog.Any("dto", dto))
+	return DataToSigSnap(sigSnapDS(dto))
+}
+
+func (r *daoSql) SelectEnv(source data.Source, ids []id.ADT) (map[id.ADT]ProcRec, error) {
+	sigs, err := r.SelectByIDs(source, ids)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[id.ADT]ProcRec, len(sigs))
+	for _, s := range sigs {
+		env[s.DecID] = s
+	}
+	return env, nil
+}
+
+func (r *daoSql) SelectByIDs(source data.Source, ids []id.ADT) ([]ProcRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	dtos := make([]sigRecDS, 0, len(ids))
+	for _, rid := range ids {
+		if rid.IsEmpty() {
+			return nil, id.ErrEmpty
+		}
+		dto, err := scanSigSql(ds.Conn.QueryRowContext(ds.Ctx, selectByIdSql, rid.String()))
+		if err != nil {
+			r.log.Error("query execution failed", slog.Any("id", rid), slog.String("q", selectByIdSql))
+			return nil, err
+		}
+		dtos = append(dtos, dto)
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entities selection succeeded", slog.Any("dtos", dtos))
+	return DataToSigRecs(dtos)
+}
+
+func (r *daoSql) SelectAll(source data.Source) ([]ProcRef, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	query := `
+		select
+			sig_id, rev, title
+		from sig_roots`
+	rows, err := ds.Conn.QueryContext(ds.Ctx, query)
+	if err != nil {
+		r.log.Error("query execution failed", slog.String("q", query))
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto sigRefDS, err error) {
+		err = rows.Scan(&dto.SigID, &dto.SigRN, &dto.Title)
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("rows collection failed")
+		return nil, err
+	}
+	return DataToSigRefs(dtos)
+}
+
+func scanSigSql(row *sql.Row) (sigRecDS, error) {
+	var dto sigRecDS
+	x := data.JsonSql[bndSpecDS]{}
+	ys := data.JsonSql[[]bndSpecDS]{}
+	err := row.Scan(&dto.SigID, &dto.SigRN, &dto.Title, &x, &ys)
+	dto.X, dto.Ys = x.V, ys.V
+	return dto, err
+}
+
+const (
+	selectByIdSql = `
+		select
+			sr.sig_id,
+			sr.rev,
+			sr.title,
+			(select json_object('chnl_ph', sp.chnl_key, 'role_qn', sp.role_fqn)
+				from sig_pes sp
+				where sp.sig_id = sr.sig_id
+				and sp.rev_from >= sr.rev
+				and sp.rev_to > sr.rev
+				limit 1) as x,
+			(select json_group_array(json_object('chnl_ph', sc.chnl_key, 'role_qn', sc.role_fqn))
+				from sig_ces sc
+				where sc.sig_id = sr.sig_id
+				and sc.rev_from >= sr.rev
+				and sc.rev_to > sr.rev) as ys
+		from sig_roots sr
+		where sr.sig_id = ?`
+)
@@ -39,7 +39,7 @@ func (h *handlerEcho) PostOne(c echo.Context) error {
 		h.log.Error("dto conversion failed", slog.Any("reason", err), slog.Any("dto", dto))
 		return err
 	}
-	snap, err := h.api.Create(spec)
+	snap, err := h.api.Create(c.Request().Context(), spec)
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,7 @@ func (h *handlerEcho) GetOne(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	snap, err := h.api.Retrieve(id)
+	snap, err := h.api.Retrieve(c.Request().Context(), id)
 	if err != nil {
 		return err
 	}
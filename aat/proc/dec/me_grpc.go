@@ -0,0 +1,70 @@
+package dec
+
+import (
+	"context"
+	"log/slog"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/rpc"
+)
+
+// Adapter
+type handlerGrpc struct {
+	rpc.UnimplementedDecServiceServer
+	api API
+	log *slog.Logger
+}
+
+func newHandlerGrpc(a API, l *slog.Logger) *handlerGrpc {
+	name := slog.String("name", "sigHandlerGrpc")
+	return &handlerGrpc{api: a, log: l.With(name)}
+}
+
+func (h *handlerGrpc) CreateDec(ctx context.Context, req *rpc.CreateDecRequest) (*rpc.DecSnap, error) {
+	dto := msgFromSpecPB(req)
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("dto validation failed", slog.Any("reason", err), slog.Any("dto", dto))
+		return nil, err
+	}
+	spec, err := MsgToSigSpec(dto)
+	if err != nil {
+		h.log.Error("dto conversion failed", slog.Any("reason", err), slog.Any("dto", dto))
+		return nil, err
+	}
+	snap, err := h.api.Create(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return pbFromSnapMsg(MsgFromSigSnap(snap)), nil
+}
+
+func (h *handlerGrpc) GetDec(ctx context.Context, req *rpc.GetDecRequest) (*rpc.DecSnap, error) {
+	dto := IdentME{SigID: req.GetId()}
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("dto validation failed", slog.Any("reason", err), slog.Any("dto", dto))
+		return nil, err
+	}
+	sigID, err := id.ConvertFromString(dto.SigID)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := h.api.Retrieve(ctx, sigID)
+	if err != nil {
+		return nil, err
+	}
+	return pbFromSnapMsg(MsgFromSigSnap(snap)), nil
+}
+
+func (h *handlerGrpc) ListDecs(ctx context.Context, _ *rpc.ListDecsRequest) (*rpc.ListDecsResponse, error) {
+	refs, err := h.api.RetreiveRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pbs := make([]*rpc.DecRef, 0, len(refs))
+	for _, ref := range refs {
+		pbs = append(pbs, pbFromRefMsg(MsgFromSigRef(ref)))
+	}
+	return &rpc.ListDecsResponse{Refs: pbs}, nil
+}
@@ -1,6 +1,7 @@
 package dec
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-resty/resty/v2"
@@ -23,14 +24,15 @@ func NewAPI() API {
 	return newClientResty()
 }
 
-func (cl *clientResty) Incept(sigQN sym.ADT) (ProcRef, error) {
+func (cl *clientResty) Incept(ctx context.Context, sigQN sym.ADT) (ProcRef, error) {
 	return ProcRef{}, nil
 }
 
-func (cl *clientResty) Create(spec ProcSpec) (ProcSnap, error) {
+func (cl *clientResty) Create(ctx context.Context, spec ProcSpec) (ProcSnap, error) {
 	req := MsgFromSigSpec(spec)
 	var res SigSnapME
 	resp, err := cl.resty.R().
+		SetContext(ctx).
 		SetResult(&res).
 		SetBody(&req).
 		Post("/signatures")
@@ -43,9 +45,10 @@ func (cl *clientResty) Create(spec ProcSpec) (ProcSnap, error) {
 	return MsgToSigSnap(res)
 }
 
-func (c *clientResty) Retrieve(id id.ADT) (ProcSnap, error) {
+func (c *clientResty) Retrieve(ctx context.Context, id id.ADT) (ProcSnap, error) {
 	var res SigSnapME
 	resp, err := c.resty.R().
+		SetContext(ctx).
 		SetResult(&res).
 		SetPathParam("id", id.String()).
 		Get("/signatures/{id}")
@@ -58,7 +61,7 @@ func (c *clientResty) Retrieve(id id.ADT) (ProcSnap, error) {
 	return MsgToSigSnap(res)
 }
 
-func (c *clientResty) RetreiveRefs() ([]ProcRef, error) {
+func (c *clientResty) RetreiveRefs(ctx context.Context) ([]ProcRef, error) {
 	refs := []ProcRef{}
 	return refs, nil
 }
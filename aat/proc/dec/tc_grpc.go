@@ -0,0 +1,47 @@
+package dec
+
+import (
+	"orglang/orglang/rpc"
+)
+
+func msgFromSpecPB(pb *rpc.CreateDecRequest) SigSpecME {
+	return SigSpecME{
+		X:     msgFromBndPB(pb.GetX()),
+		SigQN: pb.GetQn(),
+		Ys:    msgFromBndPBs(pb.GetYs()),
+	}
+}
+
+func msgFromBndPB(pb *rpc.ChnlBnd) BndSpecME {
+	return BndSpecME{ChnlPH: pb.GetChnlPh(), TypeQN: pb.GetTypeQn()}
+}
+
+func msgFromBndPBs(pbs []*rpc.ChnlBnd) []BndSpecME {
+	dtos := make([]BndSpecME, 0, len(pbs))
+	for _, pb := range pbs {
+		dtos = append(dtos, msgFromBndPB(pb))
+	}
+	return dtos
+}
+
+func pbFromBndMsg(dto BndSpecME) *rpc.ChnlBnd {
+	return &rpc.ChnlBnd{ChnlPh: dto.ChnlPH, TypeQn: dto.TypeQN}
+}
+
+func pbFromSnapMsg(dto SigSnapME) *rpc.DecSnap {
+	ys := make([]*rpc.ChnlBnd, 0, len(dto.Ys))
+	for _, y := range dto.Ys {
+		ys = append(ys, pbFromBndMsg(y))
+	}
+	return &rpc.DecSnap{
+		Id:    dto.SigID,
+		Rev:   dto.SigRN,
+		Title: dto.Title,
+		X:     pbFromBndMsg(dto.X),
+		Ys:    ys,
+	}
+}
+
+func pbFromRefMsg(dto SigRefME) *rpc.DecRef {
+	return &rpc.DecRef{Id: dto.SigID, Rev: dto.SigRN, Title: dto.Title}
+}
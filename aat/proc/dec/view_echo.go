@@ -43,7 +43,7 @@ func (p *presenterEcho) PostOne(c echo.Context) error {
 		p.log.Error("dto parsing failed")
 		return err
 	}
-	ref, err := p.api.Incept(ns.New(dto.SigSN))
+	ref, err := p.api.Incept(ctx, ns.New(dto.SigSN))
 	if err != nil {
 		p.log.Error("root creation failed")
 		return err
@@ -58,7 +58,7 @@ func (p *presenterEcho) PostOne(c echo.Context) error {
 }
 
 func (p *presenterEcho) GetMany(c echo.Context) error {
-	refs, err := p.api.RetreiveRefs()
+	refs, err := p.api.RetreiveRefs(c.Request().Context())
 	if err != nil {
 		p.log.Error("refs retrieval failed")
 		return err
@@ -90,7 +90,7 @@ func (p *presenterEcho) GetOne(c echo.Context) error {
 		p.log.Error("dto mapping failed")
 		return err
 	}
-	snap, err := p.api.Retrieve(id)
+	snap, err := p.api.Retrieve(ctx, id)
 	if err != nil {
 		p.log.Error("snap retrieval failed")
 		return err
@@ -1,17 +1,19 @@
 package def
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
 	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/sym"
 )
 
 type API interface {
-	Create(ProcSpec) (ProcRef, error)
-	Retrieve(id.ADT) (ProcRec, error)
+	Create(context.Context, ProcSpec) (ProcRef, error)
+	Retrieve(context.Context, id.ADT) (ProcRec, error)
 }
 
 type ProcSpec struct {
@@ -254,11 +256,11 @@ func newService(
 	return &service{procs, operator, l}
 }
 
-func (s *service) Create(spec ProcSpec) (ProcRef, error) {
+func (s *service) Create(ctx context.Context, spec ProcSpec) (ProcRef, error) {
 	return ProcRef{}, nil
 }
 
-func (s *service) Retrieve(recID id.ADT) (ProcRec, error) {
+func (s *service) Retrieve(ctx context.Context, recID id.ADT) (ProcRec, error) {
 	return ProcRec{}, nil
 }
 
@@ -279,7 +281,7 @@ func collectEnvRec(s TermSpec, env []id.ADT) []id.ADT {
 }
 
 func ErrDoesNotExist(want id.ADT) error {
-	return fmt.Errorf("rec doesn't exist: %v", want)
+	return fault.New(fault.NotFound, "rec doesn't exist: %v", want)
 }
 
 func ErrTermTypeUnexpected(got TermSpec) error {
@@ -291,21 +293,21 @@ func ErrRecTypeUnexpected(got TermRec) error {
 }
 
 func ErrTermTypeMismatch(got, want TermSpec) error {
-	return fmt.Errorf("term spec mismatch: want %T, got %T", want, got)
+	return fault.New(fault.ProtocolViolation, "term spec mismatch: want %T, got %T", want, got)
 }
 
 func ErrTermValueNil(pid id.ADT) error {
-	return fmt.Errorf("proc %q term is nil", pid)
+	return fault.New(fault.ProtocolViolation, "proc %q term is nil", pid)
 }
 
 func ErrMissingInCfg(want sym.ADT) error {
-	return fmt.Errorf("channel missing in cfg: %v", want)
+	return fault.New(fault.ProtocolViolation, "channel missing in cfg: %v", want)
 }
 
 func ErrMissingInCfg2(want id.ADT) error {
-	return fmt.Errorf("channel missing in cfg: %v", want)
+	return fault.New(fault.ProtocolViolation, "channel missing in cfg: %v", want)
 }
 
 func ErrMissingInCtx(want sym.ADT) error {
-	return fmt.Errorf("channel missing in ctx: %v", want)
+	return fault.New(fault.ProtocolViolation, "channel missing in ctx: %v", want)
 }
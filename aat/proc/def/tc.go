@@ -3,6 +3,7 @@ package def
 import (
 	"fmt"
 
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/sym"
 )
@@ -213,16 +214,16 @@ func MsgToTermSpec(dto TermSpecME) (TermSpec, error) {
 		}
 		return FwdSpec{X: x, Y: y}, nil
 	default:
-		panic(ErrUnexpectedTermKind(dto.K))
+		return nil, ErrUnexpectedTermKind(dto.K)
 	}
 }
 
 func ErrUnexpectedTermKind(k TermKind) error {
-	return fmt.Errorf("unexpected term kind: %v", k)
+	return fault.New(fault.ProtocolViolation, "unexpected term kind: %v", k)
 }
 
 func ErrUnexpectedSemKind(k SemKind) error {
-	return fmt.Errorf("unexpected sem kind: %v", k)
+	return fault.New(fault.ProtocolViolation, "unexpected sem kind: %v", k)
 }
 
 func DataFromTermRec(r TermRec) (TermRecDS, error) {
@@ -0,0 +1,60 @@
+package def
+
+import (
+	"orglang/orglang/rpc"
+)
+
+func MsgFromTermPBNilable(pb *rpc.ProcTerm) *TermSpecME {
+	if pb == nil {
+		return nil
+	}
+	dto := MsgFromTermPB(pb)
+	return &dto
+}
+
+func MsgFromTermPB(pb *rpc.ProcTerm) TermSpecME {
+	switch term := pb.GetTerm().(type) {
+	case *rpc.ProcTerm_Close:
+		return TermSpecME{K: Close, Close: &CloseSpecME{X: term.Close.GetX()}}
+	case *rpc.ProcTerm_Wait:
+		return TermSpecME{K: Wait, Wait: &WaitSpecME{
+			X:    term.Wait.GetX(),
+			Cont: MsgFromTermPB(term.Wait.GetCont()),
+		}}
+	case *rpc.ProcTerm_Send:
+		return TermSpecME{K: Send, Send: &SendSpecME{X: term.Send.GetX(), Y: term.Send.GetY()}}
+	case *rpc.ProcTerm_Recv:
+		return TermSpecME{K: Recv, Recv: &RecvSpecME{
+			X:    term.Recv.GetX(),
+			Y:    term.Recv.GetY(),
+			Cont: MsgFromTermPB(term.Recv.GetCont()),
+		}}
+	case *rpc.ProcTerm_Lab:
+		return TermSpecME{K: Lab, Lab: &LabSpecME{X: term.Lab.GetX(), Label: term.Lab.GetLabel()}}
+	case *rpc.ProcTerm_Case:
+		brs := make([]BranchSpecME, 0, len(term.Case.GetBranches()))
+		for _, br := range term.Case.GetBranches() {
+			brs = append(brs, BranchSpecME{Label: br.GetLabel(), Cont: MsgFromTermPB(br.GetCont())})
+		}
+		return TermSpecME{K: Case, Case: &CaseSpecME{X: term.Case.GetX(), Brs: brs}}
+	case *rpc.ProcTerm_Spawn:
+		return TermSpecME{K: Spawn, Spawn: &SpawnSpecME{
+			X:     term.Spawn.GetX(),
+			SigID: term.Spawn.GetSigId(),
+			Ys:    term.Spawn.GetYs(),
+			Cont:  MsgFromTermPBNilable(term.Spawn.GetCont()),
+		}}
+	case *rpc.ProcTerm_Fwd:
+		return TermSpecME{K: Fwd, Fwd: &FwdSpecME{X: term.Fwd.GetX(), Y: term.Fwd.GetY()}}
+	case *rpc.ProcTerm_Call:
+		call := MsgFromCallPB(term.Call)
+		return TermSpecME{K: Call, Call: &call}
+	default:
+		// left to the validation
+		return TermSpecME{}
+	}
+}
+
+func MsgFromCallPB(pb *rpc.CallProc) CallSpecME {
+	return CallSpecME{X: pb.GetX(), SigPH: pb.GetSigPh(), Ys: pb.GetYs()}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/rn"
 	"orglang/orglang/avt/sym"
@@ -16,8 +17,8 @@ import (
 )
 
 type API interface {
-	Run(ProcSpec) error
-	Retrieve(id.ADT) (ProcSnap, error)
+	Run(context.Context, ProcSpec) error
+	Retrieve(context.Context, id.ADT) (ProcSnap, error)
 }
 
 type SemRec interface {
@@ -51,6 +52,10 @@ type ProcSpec struct {
 	PoolID id.ADT
 	ExecID id.ADT
 	ProcTS procdef.TermSpec
+	// higher goes first
+	Priority int
+	// optional, makes replays return the original result
+	IdemKey string
 }
 
 type ProcRef struct {
@@ -77,6 +82,8 @@ type Cfg struct {
 	PoolID id.ADT
 	PoolRN rn.ADT
 	ProcRN rn.ADT
+	// inherited by child spawns
+	Priority int
 }
 
 type Env struct {
@@ -115,6 +122,8 @@ type Liab struct {
 	// позитивное значение при вручении
 	// негативное значение при лишении
 	PoolRN rn.ADT
+	// higher goes first
+	Priority int
 }
 
 type Mod struct {
@@ -157,12 +166,11 @@ func newService(
 	return &service{procs, operator, l}
 }
 
-func (s *service) Run(spec ProcSpec) (err error) {
+func (s *service) Run(ctx context.Context, spec ProcSpec) (err error) {
 	idAttr := slog.Any("procID", spec.ExecID)
 	s.log.Debug("creation started", idAttr)
-	ctx := context.Background()
 	var mainCfg MainCfg
-	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+	err = s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) error {
 		mainCfg, err = s.procs.SelectMain(ds, spec.ExecID)
 		return err
 	})
@@ -196,7 +204,7 @@ func (s *service) Run(spec ProcSpec) (err error) {
 	return nil
 }
 
-func (s *service) Retrieve(procID id.ADT) (_ ProcSnap, err error) {
+func (s *service) Retrieve(ctx context.Context, procID id.ADT) (_ ProcSnap, err error) {
 	return ProcSnap{}, nil
 }
 
@@ -278,19 +286,19 @@ func (s *service) createWith(
 }
 
 func ErrMissingChnl(want sym.ADT) error {
-	return fmt.Errorf("channel missing in cfg: %v", want)
+	return fault.New(fault.ProtocolViolation, "channel missing in cfg: %v", want)
 }
 
 func errMissingPool(want sym.ADT) error {
-	return fmt.Errorf("pool missing in env: %v", want)
+	return fault.New(fault.NotFound, "pool missing in env: %v", want)
 }
 
 func errMissingSig(want id.ADT) error {
-	return fmt.Errorf("sig missing in env: %v", want)
+	return fault.New(fault.NotFound, "sig missing in env: %v", want)
 }
 
 func errMissingRole(want sym.ADT) error {
-	return fmt.Errorf("role missing in env: %v", want)
+	return fault.New(fault.NotFound, "role missing in env: %v", want)
 }
 
 func ErrRootTypeUnexpected(got SemRec) error {
@@ -298,5 +306,5 @@ func ErrRootTypeUnexpected(got SemRec) error {
 }
 
 func ErrRootTypeMismatch(got, want SemRec) error {
-	return fmt.Errorf("sem rec mismatch: want %T, got %T", want, got)
+	return fault.New(fault.ProtocolViolation, "sem rec mismatch: want %T, got %T", want, got)
 }
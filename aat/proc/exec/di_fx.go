@@ -3,8 +3,12 @@
 package exec
 
 import (
+	"log/slog"
+
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
+
+	"orglang/orglang/avt/data"
 )
 
 var Module = fx.Module("aat/proc",
@@ -14,13 +18,24 @@ var Module = fx.Module("aat/proc",
 	fx.Provide(
 		fx.Private,
 		newHandlerEcho,
-		fx.Annotate(newDaoPgx, fx.As(new(repo))),
+		newRepoByMapping,
 	),
 	fx.Invoke(
 		cfgEcho,
 	),
 )
 
+func newRepoByMapping(m data.Mapping, l *slog.Logger) repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
 func cfgEcho(e *echo.Echo, h *handlerEcho) error {
 	e.GET("/api/v1/procs/:id", h.GetSnap)
 	e.POST("/api/v1/procs/:id/calls", h.PostCall)
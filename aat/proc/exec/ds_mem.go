@@ -0,0 +1,53 @@
+package exec
+
+import (
+	"log/slog"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	return &daoMem{l}
+}
+
+func (r *daoMem) SelectMain(data.Source, id.ADT) (MainCfg, error) {
+	return MainCfg{}, nil
+}
+
+func (r *daoMem) UpdateMain(data.Source, MainMod) error {
+	return nil
+}
+
+// Adapter
+type repoMem2 struct {
+	log *slog.Logger
+}
+
+const stepsMem = "steps"
+
+func (r *repoMem2) InsertSem(source data.Source, recs ...SemRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	dtos, err := DataFromSemRecs(recs)
+	if err != nil {
+		r.log.Error("model mapping failed")
+		return err
+	}
+	data.InsertRows(ds, stepsMem, dtos...)
+	return nil
+}
+
+func (r *repoMem2) SelectSemByID(source data.Source, recID id.ADT) (SemRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	for _, dto := range data.Rows[SemRecDS](ds, stepsMem) {
+		if dto.ID == recID.String() {
+			return dataToSemRec(dto)
+		}
+	}
+	return nil, nil
+}
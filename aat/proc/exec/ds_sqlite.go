@@ -0,0 +1,97 @@
+package exec
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+
+	procdef "orglang/orglang/aat/proc/def"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	return &daoSql{l}
+}
+
+func (r *daoSql) SelectMain(data.Source, id.ADT) (MainCfg, error) {
+	return MainCfg{}, nil
+}
+
+func (r *daoSql) UpdateMain(data.Source, MainMod) error {
+	return nil
+}
+
+// Adapter
+type repoSql2 struct {
+	log *slog.Logger
+}
+
+func (r *repoSql2) InsertSem(source data.Source, recs ...SemRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dtos, err := DataFromSemRecs(recs)
+	if err != nil {
+		r.log.Error("model mapping failed")
+		return err
+	}
+	for _, dto := range dtos {
+		args := data.NamedArgsSql{
+			"id":   dto.ID,
+			"kind": dto.K,
+			"pid":  dto.PID,
+			"vid":  dto.VID,
+			"spec": data.JsonSql[procdef.TermRecDS]{V: dto.TR},
+		}
+		_, err = ds.Conn.ExecContext(ds.Ctx, insertRootSql, args.List()...)
+		if err != nil {
+			r.log.Error("query execution failed", slog.Any("id", dto.ID), slog.String("q", insertRootSql))
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repoSql2) SelectSemByID(source data.Source, recID id.ADT) (SemRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	query := `
+		select
+			id, kind, pid, vid, spec
+		from steps
+		where id = ?`
+	var dto SemRecDS
+	spec := data.JsonSql[procdef.TermRecDS]{}
+	err := ds.Conn.QueryRowContext(ds.Ctx, query, recID.String()).Scan(
+		&dto.ID, &dto.K, &dto.PID, &dto.VID, &spec,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error("query execution failed", slog.String("q", query))
+		return nil, err
+	}
+	dto.TR = spec.V
+	rec, err := dataToSemRec(dto)
+	if err != nil {
+		r.log.Error("model mapping failed")
+		return nil, err
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity selection succeeded", slog.Any("rec", rec))
+	return rec, nil
+}
+
+const (
+	insertRootSql = `
+		insert into steps (
+			id, kind, pid, vid, spec
+		) values (
+			@id, @kind, @pid, @vid, @spec
+		)`
+)
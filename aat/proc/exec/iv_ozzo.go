@@ -4,6 +4,7 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
 	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/msg"
 )
 
 func (dto SpecME) Validate() error {
@@ -11,5 +12,7 @@ func (dto SpecME) Validate() error {
 		validation.Field(&dto.ProcID, id.Required...),
 		validation.Field(&dto.PoolID, id.Required...),
 		validation.Field(&dto.Term, validation.Required),
+		validation.Field(&dto.Priority, validation.Min(-100), validation.Max(100)),
+		validation.Field(&dto.IdemKey, msg.IdemKeyOptional...),
 	)
 }
@@ -8,6 +8,10 @@ type SpecME struct {
 	ProcID string             `json:"proc_id" param:"id"`
 	PoolID string             `json:"pool_id"`
 	Term   procdef.CallSpecME `json:"term"`
+	// higher goes first
+	Priority int `json:"priority,omitempty"`
+	// aka Idempotency-Key header
+	IdemKey string `json:"idem_key,omitempty"`
 }
 
 type IdentME struct {
@@ -31,7 +31,7 @@ func (h *handlerEcho) GetSnap(c echo.Context) error {
 		h.log.Error("mapping failed", idAttr)
 		return err
 	}
-	snap, err := h.api.Retrieve(id)
+	snap, err := h.api.Retrieve(c.Request().Context(), id)
 	if err != nil {
 		return err
 	}
@@ -50,7 +50,7 @@ func (h *handlerEcho) PostCall(c echo.Context) error {
 		h.log.Error("mapping failed", idAttr)
 		return err
 	}
-	err = h.api.Run(spec)
+	err = h.api.Run(c.Request().Context(), spec)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"context"
 	"github.com/go-resty/resty/v2"
 
 	"orglang/orglang/avt/id"
@@ -20,10 +21,11 @@ func NewAPI() API {
 	return newClientResty()
 }
 
-func (cl *clientResty) Run(spec ProcSpec) error {
+func (cl *clientResty) Run(ctx context.Context, spec ProcSpec) error {
 	req := MsgFromSpec(spec)
 	var res RefME
 	_, err := cl.resty.R().
+		SetContext(ctx).
 		SetPathParam("id", spec.ExecID.String()).
 		SetBody(&req).
 		SetResult(&res).
@@ -34,9 +36,10 @@ func (cl *clientResty) Run(spec ProcSpec) error {
 	return nil
 }
 
-func (cl *clientResty) Retrieve(procID id.ADT) (ProcSnap, error) {
+func (cl *clientResty) Retrieve(ctx context.Context, procID id.ADT) (ProcSnap, error) {
 	var res SnapME
 	_, err := cl.resty.R().
+		SetContext(ctx).
 		SetPathParam("id", procID.String()).
 		SetResult(&res).
 		Get("/procs/{id}")
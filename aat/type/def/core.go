@@ -6,7 +6,9 @@ import (
 	"log/slog"
 
 	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/outbox"
 	"orglang/orglang/avt/pol"
 	"orglang/orglang/avt/rn"
 	"orglang/orglang/avt/sym"
@@ -275,21 +277,27 @@ type Context struct {
 }
 
 type API interface {
-	Incept(sym.ADT) (TypeRef, error)
-	Create(TypeSpec) (TypeSnap, error)
-	Modify(TypeSnap) (TypeSnap, error)
-	Retrieve(id.ADT) (TypeSnap, error)
-	retrieveSnap(TypeRec) (TypeSnap, error)
-	RetreiveRefs() ([]TypeRef, error)
+	Incept(context.Context, sym.ADT) (TypeRef, error)
+	Create(context.Context, TypeSpec) (TypeSnap, error)
+	Modify(context.Context, TypeSnap) (TypeSnap, error)
+	Retrieve(context.Context, id.ADT) (TypeSnap, error)
+	retrieveSnap(context.Context, TypeRec) (TypeSnap, error)
+	RetreiveRefs(context.Context) ([]TypeRef, error)
 }
 
 type service struct {
 	types    Repo
 	aliases  alias.Repo
 	operator data.Operator
+	outbox   outbox.Writer
 	log      *slog.Logger
 }
 
+const (
+	RoleCreated  = outbox.Kind("role.created")
+	RoleModified = outbox.Kind("role.modified")
+)
+
 // for compilation purposes
 func newAPI() API {
 	return &service{}
@@ -299,18 +307,18 @@ func newService(
 	types Repo,
 	aliases alias.Repo,
 	operator data.Operator,
+	outbox outbox.Writer,
 	l *slog.Logger,
 ) *service {
-	return &service{types, aliases, operator, l}
+	return &service{types, aliases, operator, outbox, l}
 }
 
-func (s *service) Incept(qn sym.ADT) (_ TypeRef, err error) {
-	ctx := context.Background()
+func (s *service) Incept(ctx context.Context, qn sym.ADT) (_ TypeRef, err error) {
 	qnAttr := slog.Any("roleQN", qn)
 	s.log.Debug("inception started", qnAttr)
-	newAlias := alias.Root{QN: qn, ID: id.New(), RN: rn.Initial()}
+	newAlias := alias.Root{QN: qn, ID: id.New(), RN: rn.Initial(), Kind: alias.RoleKind}
 	newType := TypeRec{TypeID: newAlias.ID, TypeRN: newAlias.RN, Title: newAlias.QN.SN()}
-	s.operator.Explicit(ctx, func(ds data.Source) error {
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
 		err = s.aliases.Insert(ds, newAlias)
 		if err != nil {
 			return err
@@ -319,7 +327,8 @@ func (s *service) Incept(qn sym.ADT) (_ TypeRef, err error) {
 		if err != nil {
 			return err
 		}
-		return nil
+		_, err = s.outbox.Write(ds, roleEvent(RoleCreated, newType))
+		return err
 	})
 	if err != nil {
 		s.log.Error("inception failed", qnAttr)
@@ -329,11 +338,10 @@ func (s *service) Incept(qn sym.ADT) (_ TypeRef, err error) {
 	return ConvertRecToRef(newType), nil
 }
 
-func (s *service) Create(spec TypeSpec) (_ TypeSnap, err error) {
-	ctx := context.Background()
+func (s *service) Create(ctx context.Context, spec TypeSpec) (_ TypeSnap, err error) {
 	qnAttr := slog.Any("typeQN", spec.TypeSN)
 	s.log.Debug("creation started", qnAttr, slog.Any("spec", spec))
-	newAlias := alias.Root{QN: spec.TypeSN, ID: id.New(), RN: rn.Initial()}
+	newAlias := alias.Root{QN: spec.TypeSN, ID: id.New(), RN: rn.Initial(), Kind: alias.RoleKind}
 	newTerm := ConvertSpecToRec(spec.TypeTS)
 	newType := TypeRec{
 		TypeID: newAlias.ID,
@@ -341,7 +349,7 @@ func (s *service) Create(spec TypeSpec) (_ TypeSnap, err error) {
 		Title:  newAlias.QN.SN(),
 		TermID: newTerm.Ident(),
 	}
-	s.operator.Explicit(ctx, func(ds data.Source) error {
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
 		err = s.aliases.Insert(ds, newAlias)
 		if err != nil {
 			return err
@@ -354,7 +362,8 @@ func (s *service) Create(spec TypeSpec) (_ TypeSnap, err error) {
 		if err != nil {
 			return err
 		}
-		return nil
+		_, err = s.outbox.Write(ds, roleEvent(RoleCreated, newType))
+		return err
 	})
 	if err != nil {
 		s.log.Error("creation failed", qnAttr)
@@ -370,12 +379,11 @@ func (s *service) Create(spec TypeSpec) (_ TypeSnap, err error) {
 	}, nil
 }
 
-func (s *service) Modify(snap TypeSnap) (_ TypeSnap, err error) {
-	ctx := context.Background()
+func (s *service) Modify(ctx context.Context, snap TypeSnap) (_ TypeSnap, err error) {
 	idAttr := slog.Any("typeID", snap.TypeID)
 	s.log.Debug("modification started", idAttr)
 	var rec TypeRec
-	s.operator.Implicit(ctx, func(ds data.Source) error {
+	err = s.operator.Implicit(data.WithPrimary(ctx), func(ds data.Source) error {
 		rec, err = s.types.SelectTypeRecByID(ds, snap.TypeID)
 		return err
 	})
@@ -389,12 +397,12 @@ func (s *service) Modify(snap TypeSnap) (_ TypeSnap, err error) {
 	} else {
 		snap.TypeRN = rn.Next(snap.TypeRN)
 	}
-	curSnap, err := s.retrieveSnap(rec)
+	curSnap, err := s.retrieveSnap(ctx, rec)
 	if err != nil {
 		s.log.Error("modification failed", idAttr)
 		return TypeSnap{}, err
 	}
-	s.operator.Explicit(ctx, func(ds data.Source) error {
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
 		if CheckSpec(snap.TypeTS, curSnap.TypeTS) != nil {
 			newTerm := ConvertSpecToRec(snap.TypeTS)
 			err = s.types.InsertTerm(ds, newTerm)
@@ -409,6 +417,8 @@ func (s *service) Modify(snap TypeSnap) (_ TypeSnap, err error) {
 			if err != nil {
 				return err
 			}
+			_, err = s.outbox.Write(ds, roleEvent(RoleModified, rec))
+			return err
 		}
 		return nil
 	})
@@ -420,10 +430,17 @@ func (s *service) Modify(snap TypeSnap) (_ TypeSnap, err error) {
 	return snap, nil
 }
 
-func (s *service) Retrieve(recID id.ADT) (_ TypeSnap, err error) {
-	ctx := context.Background()
+func roleEvent(kind outbox.Kind, rec TypeRec) outbox.EventSpec {
+	return outbox.EventSpec{
+		Kind:      kind,
+		SubjectID: rec.TypeID,
+		Payload:   MsgFromTypeRef(ConvertRecToRef(rec)),
+	}
+}
+
+func (s *service) Retrieve(ctx context.Context, recID id.ADT) (_ TypeSnap, err error) {
 	var root TypeRec
-	s.operator.Implicit(ctx, func(ds data.Source) error {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
 		root, err = s.types.SelectTypeRecByID(ds, recID)
 		return err
 	})
@@ -431,13 +448,12 @@ func (s *service) Retrieve(recID id.ADT) (_ TypeSnap, err error) {
 		s.log.Error("retrieval failed", slog.Any("roleID", recID))
 		return TypeSnap{}, err
 	}
-	return s.retrieveSnap(root)
+	return s.retrieveSnap(ctx, root)
 }
 
-func (s *service) retrieveSnap(typeRec TypeRec) (_ TypeSnap, err error) {
-	ctx := context.Background()
+func (s *service) retrieveSnap(ctx context.Context, typeRec TypeRec) (_ TypeSnap, err error) {
 	var termRec TermRec
-	s.operator.Implicit(ctx, func(ds data.Source) error {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
 		termRec, err = s.types.SelectTermRecByID(ds, typeRec.TermID)
 		return err
 	})
@@ -453,9 +469,8 @@ func (s *service) retrieveSnap(typeRec TypeRec) (_ TypeSnap, err error) {
 	}, nil
 }
 
-func (s *service) RetreiveRefs() (refs []TypeRef, err error) {
-	ctx := context.Background()
-	s.operator.Implicit(ctx, func(ds data.Source) error {
+func (s *service) RetreiveRefs(ctx context.Context) (refs []TypeRef, err error) {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
 		refs, err = s.types.SelectTypeRefs(ds)
 		return err
 	})
@@ -500,15 +515,15 @@ var (
 )
 
 func ErrSymMissingInEnv(want sym.ADT) error {
-	return fmt.Errorf("root missing in env: %v", want)
+	return fault.New(fault.NotFound, "root missing in env: %v", want)
 }
 
 func errConcurrentModification(got rn.ADT, want rn.ADT) error {
-	return fmt.Errorf("entity concurrent modification: want revision %v, got revision %v", want, got)
+	return fault.New(fault.ConcurrentModification, "entity concurrent modification: want revision %v, got revision %v", want, got)
 }
 
 func errOptimisticUpdate(got rn.ADT) error {
-	return fmt.Errorf("entity concurrent modification: got revision %v", got)
+	return fault.New(fault.ConcurrentModification, "entity concurrent modification: got revision %v", got)
 }
 
 func ConvertSpecToRec(s TermSpec) TermRec {
@@ -745,19 +760,19 @@ func ErrRefTypeUnexpected(got TermRef) error {
 }
 
 func ErrDoesNotExist(want id.ADT) error {
-	return fmt.Errorf("root doesn't exist: %v", want)
+	return fault.New(fault.NotFound, "root doesn't exist: %v", want)
 }
 
 func ErrMissingInEnv(want id.ADT) error {
-	return fmt.Errorf("root missing in env: %v", want)
+	return fault.New(fault.NotFound, "root missing in env: %v", want)
 }
 
 func ErrMissingInCfg(want id.ADT) error {
-	return fmt.Errorf("root missing in cfg: %v", want)
+	return fault.New(fault.ProtocolViolation, "root missing in cfg: %v", want)
 }
 
 func ErrMissingInCtx(want sym.ADT) error {
-	return fmt.Errorf("root missing in ctx: %v", want)
+	return fault.New(fault.ProtocolViolation, "root missing in ctx: %v", want)
 }
 
 func ErrRecTypeUnexpected(got TermRec) error {
@@ -765,11 +780,11 @@ func ErrRecTypeUnexpected(got TermRec) error {
 }
 
 func ErrSpecTypeMismatch(got, want TermSpec) error {
-	return fmt.Errorf("spec type mismatch: want %T, got %T", want, got)
+	return fault.New(fault.ProtocolViolation, "spec type mismatch: want %T, got %T", want, got)
 }
 
 func ErrSnapTypeMismatch(got, want TermRec) error {
-	return fmt.Errorf("root type mismatch: want %T, got %T", want, got)
+	return fault.New(fault.ProtocolViolation, "root type mismatch: want %T, got %T", want, got)
 }
 
 func ErrPolarityUnexpected(got TermRec) error {
@@ -777,5 +792,5 @@ func ErrPolarityUnexpected(got TermRec) error {
 }
 
 func ErrPolarityMismatch(a, b TermRec) error {
-	return fmt.Errorf("root polarity mismatch: %v != %v", a.Pol(), b.Pol())
+	return fault.New(fault.ProtocolViolation, "root polarity mismatch: %v != %v", a.Pol(), b.Pol())
 }
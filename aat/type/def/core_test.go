@@ -2,18 +2,36 @@ package def
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"testing"
 
 	"orglang/orglang/avt/data"
 	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/outbox"
 	"orglang/orglang/avt/sym"
 
 	"orglang/orglang/aet/alias"
 )
 
 func TestKinshipEstalish(t *testing.T) {
-	newService(&roleRepoStub{}, &aliasRepoStub{}, &operatorStub{}, slog.Default())
+	newService(&roleRepoStub{}, &aliasRepoStub{}, &operatorStub{}, &outboxStub{}, slog.Default())
+}
+
+func TestInceptSurfacesCommitFailure(t *testing.T) {
+	failure := errors.New("serialization failure")
+	s := newService(&roleRepoStub{}, &aliasRepoStub{}, &operatorStub{err: failure}, &outboxStub{}, slog.Default())
+	_, err := s.Incept(context.Background(), sym.New("a.b"))
+	if !errors.Is(err, failure) {
+		t.Fatalf("want %v, got %v", failure, err)
+	}
+}
+
+type outboxStub struct {
+}
+
+func (w *outboxStub) Write(source data.Source, spec outbox.EventSpec) (outbox.Event, error) {
+	return outbox.Event{}, nil
 }
 
 type roleRepoStub struct {
@@ -71,12 +89,32 @@ type aliasRepoStub struct {
 func (r *aliasRepoStub) Insert(ds data.Source, ar alias.Root) error {
 	return nil
 }
+func (r *aliasRepoStub) SelectByQN(ds data.Source, qn sym.ADT, kind alias.Kind) ([]alias.Entry, error) {
+	return nil, nil
+}
+func (r *aliasRepoStub) SelectByID(ds data.Source, id id.ADT) (alias.Entry, error) {
+	return alias.Entry{}, nil
+}
+func (r *aliasRepoStub) SelectTree(ds data.Source, spec alias.TreeSpec) ([]alias.Entry, error) {
+	return nil, nil
+}
+func (r *aliasRepoStub) SelectByPrefix(ds data.Source, spec alias.PrefixSpec) ([]alias.Entry, error) {
+	return nil, nil
+}
+func (r *aliasRepoStub) SelectFormer(ds data.Source, qn sym.ADT, kind alias.Kind) (alias.Entry, error) {
+	return alias.Entry{}, nil
+}
+func (r *aliasRepoStub) Rename(ds data.Source, cur alias.Entry, next alias.Entry) error {
+	return nil
+}
 
+// err fails explicit operations at commit
 type operatorStub struct {
+	err error
 }
 
 func (o *operatorStub) Explicit(ctx context.Context, op func(data.Source) error) error {
-	return nil
+	return o.err
 }
 func (o *operatorStub) Implicit(ctx context.Context, op func(data.Source) error) error {
 	return nil
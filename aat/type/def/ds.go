@@ -17,6 +17,12 @@ type typeRecDS struct {
 	TypeRN int64  `db:"rev"`
 }
 
+// resolved by a former name unless current
+type typeRecQnDS struct {
+	typeRecDS
+	Current bool `db:"current"`
+}
+
 type termKind int
 
 const (
@@ -0,0 +1,245 @@
+package def
+
+import (
+	"log/slog"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	"orglang/orglang/aet/alias"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	return &daoMem{l}
+}
+
+const (
+	// roles are kept along with their current state
+	rolesMem  = "role_roots"
+	statesMem = "states"
+)
+
+func (r *daoMem) InsertType(source data.Source, rec TypeRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	idAttr := slog.Any("id", rec.TypeID)
+	dto, err := DataFromTypeRec(rec)
+	if err != nil {
+		r.log.Error("model mapping failed", idAttr)
+		return err
+	}
+	data.InsertRows(ds, rolesMem, dto)
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity insertion succeeded", idAttr)
+	return nil
+}
+
+func (r *daoMem) UpdateType(source data.Source, rec TypeRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	idAttr := slog.Any("id", rec.TypeID)
+	dto, err := DataFromTypeRec(rec)
+	if err != nil {
+		r.log.Error("model mapping failed", idAttr)
+		return err
+	}
+	updated := data.UpdateRows(ds, rolesMem, func(row *typeRecDS) bool {
+		if row.TypeID != dto.TypeID || row.TypeRN != dto.TypeRN-1 {
+			return false
+		}
+		row.TypeRN = dto.TypeRN
+		row.TermID = dto.TermID
+		return true
+	})
+	if updated == 0 {
+		r.log.Error("entity update failed", idAttr)
+		return errOptimisticUpdate(rec.TypeRN - 1)
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity update succeeded", idAttr)
+	return nil
+}
+
+func (r *daoMem) SelectTypeRefs(source data.Source) ([]TypeRef, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	rows := data.Rows[typeRecDS](ds, rolesMem)
+	dtos := make([]typeRefDS, 0, len(rows))
+	for _, row := range rows {
+		dtos = append(dtos, typeRefDS{row.TypeID, row.TypeRN, row.Title})
+	}
+	return DataToTypeRefs(dtos)
+}
+
+func (r *daoMem) SelectTypeRecByID(source data.Source, recID id.ADT) (TypeRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dto, ok := r.selectType(ds, recID.String())
+	if !ok {
+		r.log.Error("entity selection failed", slog.Any("id", recID))
+		return TypeRec{}, ErrDoesNotExist(recID)
+	}
+	return DataToTypeRec(dto)
+}
+
+func (r *daoMem) SelectTypeRecsByIDs(source data.Source, recIDs []id.ADT) ([]TypeRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dtos := make([]typeRecDS, 0, len(recIDs))
+	for _, recID := range recIDs {
+		if recID.IsEmpty() {
+			return nil, id.ErrEmpty
+		}
+		dto, ok := r.selectType(ds, recID.String())
+		if !ok {
+			r.log.Error("entity selection failed", slog.Any("id", recID))
+			return nil, ErrDoesNotExist(recID)
+		}
+		dtos = append(dtos, dto)
+	}
+	return DataToTypeRecs(dtos)
+}
+
+func (r *daoMem) SelectTypeRecByQN(source data.Source, recQN sym.ADT) (TypeRec, error) {
+	recs, err := r.SelectTypeRecsByQNs(source, []sym.ADT{recQN})
+	if err != nil {
+		return TypeRec{}, err
+	}
+	return recs[0], nil
+}
+
+func (r *daoMem) SelectTypeRecsByQNs(source data.Source, recQNs []sym.ADT) ([]TypeRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	dtos := make([]typeRecDS, 0, len(recQNs))
+	for _, recQN := range recQNs {
+		recID, current, ok := alias.LookupMem(ds, sym.ConvertToString(recQN), alias.RoleKind)
+		if !ok {
+			r.log.Error("entity selection failed", slog.Any("qn", recQN))
+			return nil, ErrSymMissingInEnv(recQN)
+		}
+		if !current {
+			r.log.Warn("deprecated alias referenced", slog.Any("qn", recQN))
+		}
+		dto, ok := r.selectType(ds, recID)
+		if !ok {
+			r.log.Error("entity selection failed", slog.Any("qn", recQN))
+			return nil, ErrSymMissingInEnv(recQN)
+		}
+		dtos = append(dtos, dto)
+	}
+	return DataToTypeRecs(dtos)
+}
+
+func (r *daoMem) SelectTypeEnv(source data.Source, recQNs []sym.ADT) (map[sym.ADT]TypeRec, error) {
+	recs, err := r.SelectTypeRecsByQNs(source, recQNs)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[sym.ADT]TypeRec, len(recs))
+	for i, rec := range recs {
+		env[recQNs[i]] = rec
+	}
+	return env, nil
+}
+
+func (r *daoMem) InsertTerm(source data.Source, rec TermRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	dto := dataFromTermRec(rec)
+	data.InsertRows(ds, statesMem, dto.States...)
+	return nil
+}
+
+func (r *daoMem) SelectTermRecByID(source data.Source, recID id.ADT) (TermRec, error) {
+	recs, err := r.SelectTermRecsByIDs(source, []id.ADT{recID})
+	if err != nil {
+		return nil, err
+	}
+	return recs[0], nil
+}
+
+func (r *daoMem) SelectTermRecsByIDs(source data.Source, recIDs []id.ADT) ([]TermRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	rows := data.Rows[stateDS](ds, statesMem)
+	recs := make([]TermRec, 0, len(recIDs))
+	for _, recID := range recIDs {
+		idAttr := slog.Any("id", recID)
+		dtos := stateTree(rows, recID.String())
+		if len(dtos) == 0 {
+			r.log.Error("entity selection failed", idAttr)
+			return nil, ErrDoesNotExist(recID)
+		}
+		rec, err := dataToTermRec(&termRecDS{recID.String(), dtos})
+		if err != nil {
+			r.log.Error("model mapping failed", idAttr)
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+func (r *daoMem) SelectTermEnv(source data.Source, recIDs []id.ADT) (map[id.ADT]TermRec, error) {
+	recs, err := r.SelectTermRecsByIDs(source, recIDs)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[id.ADT]TermRec, len(recs))
+	for _, rec := range recs {
+		env[rec.Ident()] = rec
+	}
+	return env, nil
+}
+
+func (r *daoMem) selectType(ds data.SourceMem, recID string) (typeRecDS, bool) {
+	for _, row := range data.Rows[typeRecDS](ds, rolesMem) {
+		if row.TypeID == recID {
+			return row, true
+		}
+	}
+	return typeRecDS{}, false
+}
+
+// the same walk as the recursive query of the pgx adapter
+func stateTree(rows []stateDS, rootID string) []stateDS {
+	var tree []stateDS
+	parents := map[string]bool{rootID: true}
+	for _, row := range rows {
+		if row.ID == rootID {
+			tree = append(tree, row)
+		}
+	}
+	for len(parents) > 0 {
+		children := make(map[string]bool)
+		for _, row := range rows {
+			if row.FromID.Valid && parents[row.FromID.String] {
+				tree = append(tree, row)
+				children[row.ID] = true
+			}
+		}
+		parents = children
+	}
+	return tree
+}
+
+// for the archiver of the in-memory pool repo,
+// the states reachable from roles and bindings survive with their subtrees
+func DeleteOrphansMem(ds data.SourceMem, bound map[string]bool) int64 {
+	roots := make(map[string]bool, len(bound))
+	for stateID := range bound {
+		roots[stateID] = true
+	}
+	for _, row := range data.Rows[typeRecDS](ds, rolesMem) {
+		roots[row.TermID] = true
+	}
+	rows := data.Rows[stateDS](ds, statesMem)
+	live := make(map[string]bool, len(rows))
+	for rootID := range roots {
+		for _, row := range stateTree(rows, rootID) {
+			live[row.ID] = true
+		}
+	}
+	deleted := data.DeleteRows(ds, statesMem, func(row stateDS) bool {
+		return !live[row.ID]
+	})
+	return int64(deleted)
+}
@@ -0,0 +1,52 @@
+package def
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+
+	"orglang/orglang/avt/data"
+)
+
+func TestDeleteOrphansMemKeepsLiveTrees(t *testing.T) {
+	operator := data.NewOperatorMem()
+	ctx := context.Background()
+	from := func(id string) sql.NullString { return sql.NullString{String: id, Valid: true} }
+	states := []stateDS{
+		// role tree
+		{ID: "a"}, {ID: "b", FromID: from("a")}, {ID: "c", FromID: from("b")},
+		// bound tree
+		{ID: "x"}, {ID: "y", FromID: from("x")}, {ID: "z", FromID: from("y")},
+		// dropped tree
+		{ID: "o"}, {ID: "p", FromID: from("o")}, {ID: "q", FromID: from("p")},
+	}
+	var deleted []int64
+	err := operator.Explicit(ctx, func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		data.InsertRows(ds, rolesMem, typeRecDS{TypeID: "r", TermID: "a"})
+		data.InsertRows(ds, statesMem, states...)
+		for range 3 {
+			deleted = append(deleted, DeleteOrphansMem(ds, map[string]bool{"x": true}))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(deleted, []int64{3, 0, 0}) {
+		t.Fatalf("want 3 deleted at once, got %v", deleted)
+	}
+	var ids []string
+	_ = operator.Implicit(ctx, func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		for _, row := range data.Rows[stateDS](ds, statesMem) {
+			ids = append(ids, row.ID)
+		}
+		return nil
+	})
+	want := []string{"a", "b", "c", "x", "y", "z"}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("want %v, got %v", want, ids)
+	}
+}
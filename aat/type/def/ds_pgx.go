@@ -2,7 +2,6 @@ package def
 
 import (
 	"errors"
-	"fmt"
 	"log/slog"
 	"math"
 
@@ -12,6 +11,8 @@ import (
 	"orglang/orglang/avt/data"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/sym"
+
+	"orglang/orglang/aet/alias"
 )
 
 // Adapter
@@ -150,6 +151,9 @@ func (r *daoPgx) SelectTypeRecByID(source data.Source, recID id.ADT) (TypeRec, e
 	}
 	defer rows.Close()
 	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[typeRecDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return TypeRec{}, ErrDoesNotExist(recID)
+	}
 	if err != nil {
 		r.log.Error("row collection failed", idAttr)
 		return TypeRec{}, err
@@ -161,19 +165,22 @@ func (r *daoPgx) SelectTypeRecByID(source data.Source, recID id.ADT) (TypeRec, e
 func (r *daoPgx) SelectTypeRecByQN(source data.Source, recQN sym.ADT) (TypeRec, error) {
 	ds := data.MustConform[data.SourcePgx](source)
 	fqnAttr := slog.Any("qn", recQN)
-	rows, err := ds.Conn.Query(ds.Ctx, selectByFQN, sym.ConvertToString(recQN))
+	rows, err := ds.Conn.Query(ds.Ctx, selectByFQN, sym.ConvertToString(recQN), int16(alias.RoleKind))
 	if err != nil {
 		r.log.Error("query execution failed", fqnAttr, slog.String("q", selectByFQN))
 		return TypeRec{}, err
 	}
 	defer rows.Close()
-	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[typeRecDS])
+	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[typeRecQnDS])
 	if err != nil {
 		r.log.Error("row collection failed", fqnAttr)
 		return TypeRec{}, err
 	}
+	if !dto.Current {
+		r.log.Warn("deprecated alias referenced", fqnAttr)
+	}
 	r.log.Log(ds.Ctx, core.LevelTrace, "entity selection succeeded", fqnAttr)
-	return DataToTypeRec(dto)
+	return DataToTypeRec(dto.typeRecDS)
 }
 
 func (r *daoPgx) SelectTypeRecsByIDs(source data.Source, recIDs []id.ADT) (_ []TypeRec, err error) {
@@ -236,7 +243,7 @@ func (r *daoPgx) SelectTypeRecsByQNs(source data.Source, recQNs []sym.ADT) (_ []
 	}
 	batch := pgx.Batch{}
 	for _, fqn := range recQNs {
-		batch.Queue(selectByFQN, sym.ConvertToString(fqn))
+		batch.Queue(selectByFQN, sym.ConvertToString(fqn), int16(alias.RoleKind))
 	}
 	br := ds.Conn.SendBatch(ds.Ctx, &batch)
 	defer func() {
@@ -249,11 +256,14 @@ func (r *daoPgx) SelectTypeRecsByQNs(source data.Source, recQNs []sym.ADT) (_ []
 			r.log.Error("query execution failed", slog.Any("fqn", fqn), slog.String("q", selectByFQN))
 		}
 		defer rows.Close()
-		dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[typeRecDS])
+		dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[typeRecQnDS])
 		if err != nil {
 			r.log.Error("row collection failed", slog.Any("fqn", fqn))
 		}
-		dtos = append(dtos, dto)
+		if err == nil && !dto.Current {
+			r.log.Warn("deprecated alias referenced", slog.Any("fqn", fqn))
+		}
+		dtos = append(dtos, dto.typeRecDS)
 	}
 	if err != nil {
 		return nil, err
@@ -326,7 +336,7 @@ func (r *daoPgx) SelectTermRecByID(source data.Source, recID id.ADT) (TermRec, e
 	}
 	if len(dtos) == 0 {
 		r.log.Error("entity selection failed", idAttr)
-		return nil, fmt.Errorf("no rows selected")
+		return nil, ErrDoesNotExist(recID)
 	}
 	r.log.Log(ds.Ctx, core.LevelTrace, "entity selection succeeded", slog.Any("dtos", dtos))
 	states := make(map[string]stateDS, len(dtos))
@@ -386,23 +396,29 @@ func (r *daoPgx) SelectTermRecsByIDs(source data.Source, recIDs []id.ADT) (_ []T
 }
 
 const (
+	// former names keep resolving, the current one goes first
 	selectByFQN = `
+		with resolved as (
+			select id, rev_to = 9223372036854775807 as current
+			from aliases
+			where sym = $1
+				and kind = $2
+			order by rev_to desc
+			limit 1
+		)
 		select
 			rr.role_id,
 			rr.rev,
 			rr.title,
 			rs.state_id,
-			null as whole_id
-		from role_roots rr
-		left join aliases a
-			on a.id = rr.role_id
-			and a.rev_from >= rr.rev
-			and a.rev_to > rr.rev
+			a.current
+		from resolved a
+		join role_roots rr
+			on rr.role_id = a.id
 		left join role_states rs
 			on rs.role_id = rr.role_id
 			and rs.rev_from >= rr.rev
-			and rs.rev_to > rr.rev
-		where a.sym = $1`
+			and rs.rev_to > rr.rev`
 
 	selectById = `
 		select
@@ -0,0 +1,373 @@
+package def
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+
+	"orglang/orglang/aet/alias"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	return &daoSql{l}
+}
+
+func (r *daoSql) InsertType(source data.Source, rec TypeRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("id", rec.TypeID)
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity insertion started", idAttr)
+	dto, err := DataFromTypeRec(rec)
+	if err != nil {
+		r.log.Error("model mapping failed", idAttr)
+		return err
+	}
+	insertRoot := `
+		insert into role_roots (
+			role_id, rev, title
+		) values (
+			@role_id, @rev, @title
+		)`
+	rootArgs := data.NamedArgsSql{
+		"role_id": dto.TypeID,
+		"rev":     dto.TypeRN,
+		"title":   dto.Title,
+	}
+	_, err = ds.Conn.ExecContext(ds.Ctx, insertRoot, rootArgs.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", insertRoot))
+		return err
+	}
+	stateArgs := data.NamedArgsSql{
+		"role_id":  dto.TypeID,
+		"rev_from": dto.TypeRN,
+		"rev_to":   int64(math.MaxInt64),
+		"state_id": dto.TermID,
+	}
+	_, err = ds.Conn.ExecContext(ds.Ctx, insertStateSql, stateArgs.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", insertStateSql))
+		return err
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity insertion succeeded", idAttr)
+	return nil
+}
+
+// closes the current state revision and opens the next one
+func (r *daoSql) UpdateType(source data.Source, rec TypeRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("id", rec.TypeID)
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity update started", idAttr)
+	dto, err := DataFromTypeRec(rec)
+	if err != nil {
+		r.log.Error("model mapping failed", idAttr)
+		return err
+	}
+	updateRoot := `
+		update role_roots
+		set rev = @rev
+		where role_id = @role_id
+			and rev = @rev - 1`
+	closeState := `
+		update role_states
+		set rev_to = @rev
+		where role_id = @role_id
+			and rev_to = @rev_max`
+	args := data.NamedArgsSql{
+		"role_id":  dto.TypeID,
+		"rev":      dto.TypeRN,
+		"rev_from": dto.TypeRN,
+		"rev_to":   int64(math.MaxInt64),
+		"rev_max":  int64(math.MaxInt64),
+		"state_id": dto.TermID,
+	}
+	res, err := ds.Conn.ExecContext(ds.Ctx, updateRoot, args.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", updateRoot))
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		r.log.Error("entity update failed", idAttr)
+		return errOptimisticUpdate(rec.TypeRN - 1)
+	}
+	for _, query := range []string{closeState, insertStateSql} {
+		_, err = ds.Conn.ExecContext(ds.Ctx, query, args.List()...)
+		if err != nil {
+			r.log.Error("query execution failed", idAttr, slog.String("q", query))
+			return err
+		}
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity update succeeded", idAttr)
+	return nil
+}
+
+func (r *daoSql) SelectTypeRefs(source data.Source) ([]TypeRef, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	query := `
+		select
+			role_id, rev, title
+		from role_roots`
+	rows, err := ds.Conn.QueryContext(ds.Ctx, query)
+	if err != nil {
+		r.log.Error("query execution failed", slog.String("q", query))
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (dto typeRefDS, err error) {
+		err = rows.Scan(&dto.TypeID, &dto.TypeRN, &dto.Title)
+		return dto, err
+	})
+	if err != nil {
+		r.log.Error("rows collection failed")
+		return nil, err
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entities selection succeeded", slog.Any("dtos", dtos))
+	return DataToTypeRefs(dtos)
+}
+
+func (r *daoSql) SelectTypeRecByID(source data.Source, recID id.ADT) (TypeRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("id", recID)
+	dto, err := scanTypeSql(ds.Conn.QueryRowContext(ds.Ctx, selectByIdSql, recID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return TypeRec{}, ErrDoesNotExist(recID)
+	}
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", selectByIdSql))
+		return TypeRec{}, err
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity selection succeeded", idAttr)
+	return DataToTypeRec(dto)
+}
+
+func (r *daoSql) SelectTypeRecByQN(source data.Source, recQN sym.ADT) (TypeRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	qnAttr := slog.Any("qn", recQN)
+	dto, err := r.selectByQN(ds, recQN)
+	if err != nil {
+		return TypeRec{}, err
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entity selection succeeded", qnAttr)
+	return DataToTypeRec(dto)
+}
+
+func (r *daoSql) SelectTypeRecsByIDs(source data.Source, recIDs []id.ADT) ([]TypeRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	dtos := make([]typeRecDS, 0, len(recIDs))
+	for _, recID := range recIDs {
+		if recID.IsEmpty() {
+			return nil, id.ErrEmpty
+		}
+		dto, err := scanTypeSql(ds.Conn.QueryRowContext(ds.Ctx, selectByIdSql, recID.String()))
+		if err != nil {
+			r.log.Error("query execution failed", slog.Any("id", recID), slog.String("q", selectByIdSql))
+			return nil, err
+		}
+		dtos = append(dtos, dto)
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entities selection succeeded", slog.Any("dtos", dtos))
+	return DataToTypeRecs(dtos)
+}
+
+func (r *daoSql) SelectTypeEnv(source data.Source, recQNs []sym.ADT) (map[sym.ADT]TypeRec, error) {
+	recs, err := r.SelectTypeRecsByQNs(source, recQNs)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[sym.ADT]TypeRec, len(recs))
+	for i, rec := range recs {
+		env[recQNs[i]] = rec
+	}
+	return env, nil
+}
+
+func (r *daoSql) SelectTypeRecsByQNs(source data.Source, recQNs []sym.ADT) ([]TypeRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	dtos := make([]typeRecDS, 0, len(recQNs))
+	for _, recQN := range recQNs {
+		dto, err := r.selectByQN(ds, recQN)
+		if err != nil {
+			return nil, err
+		}
+		dtos = append(dtos, dto)
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entities selection succeeded", slog.Any("dtos", dtos))
+	return DataToTypeRecs(dtos)
+}
+
+func (r *daoSql) InsertTerm(source data.Source, rec TermRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := dataFromTermRec(rec)
+	query := `
+		insert into states (
+			id, kind, from_id, spec
+		) values (
+			@id, @kind, @from_id, @spec
+		)`
+	for _, st := range dto.States {
+		args := data.NamedArgsSql{
+			"id":      st.ID,
+			"kind":    st.K,
+			"from_id": st.FromID,
+			"spec":    data.JsonSql[specDS]{V: st.Spec},
+		}
+		_, err := ds.Conn.ExecContext(ds.Ctx, query, args.List()...)
+		if err != nil {
+			r.log.Error("query execution failed", slog.Any("id", rec.Ident()), slog.String("q", query))
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *daoSql) SelectTermRecByID(source data.Source, recID id.ADT) (TermRec, error) {
+	recs, err := r.SelectTermRecsByIDs(source, []id.ADT{recID})
+	if err != nil {
+		return nil, err
+	}
+	return recs[0], nil
+}
+
+func (r *daoSql) SelectTermEnv(source data.Source, recIDs []id.ADT) (map[id.ADT]TermRec, error) {
+	recs, err := r.SelectTermRecsByIDs(source, recIDs)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[id.ADT]TermRec, len(recs))
+	for _, rec := range recs {
+		env[rec.Ident()] = rec
+	}
+	return env, nil
+}
+
+func (r *daoSql) SelectTermRecsByIDs(source data.Source, recIDs []id.ADT) ([]TermRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	recs := make([]TermRec, 0, len(recIDs))
+	for _, recID := range recIDs {
+		idAttr := slog.Any("id", recID)
+		rows, err := ds.Conn.QueryContext(ds.Ctx, selectTreeSql, recID.String())
+		if err != nil {
+			r.log.Error("query execution failed", idAttr, slog.String("q", selectTreeSql))
+			return nil, err
+		}
+		dtos, err := data.CollectRowsSql(rows, scanStateSql)
+		if err != nil {
+			r.log.Error("rows collection failed", idAttr)
+			return nil, err
+		}
+		if len(dtos) == 0 {
+			r.log.Error("entity selection failed", idAttr)
+			return nil, ErrDoesNotExist(recID)
+		}
+		rec, err := dataToTermRec(&termRecDS{recID.String(), dtos})
+		if err != nil {
+			r.log.Error("model mapping failed", idAttr)
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	r.log.Log(ds.Ctx, core.LevelTrace, "entities selection succeeded", slog.Any("recs", recs))
+	return recs, nil
+}
+
+func (r *daoSql) selectByQN(ds data.SourceSql, recQN sym.ADT) (typeRecDS, error) {
+	qnAttr := slog.Any("qn", recQN)
+	row := ds.Conn.QueryRowContext(ds.Ctx, selectByQNSql, sym.ConvertToString(recQN), int16(alias.RoleKind))
+	var dto typeRecQnDS
+	var termID sql.NullString
+	err := row.Scan(&dto.TypeID, &dto.TypeRN, &dto.Title, &termID, &dto.Current)
+	if err != nil {
+		r.log.Error("query execution failed", qnAttr, slog.String("q", selectByQNSql))
+		return typeRecDS{}, err
+	}
+	if !dto.Current {
+		r.log.Warn("deprecated alias referenced", qnAttr)
+	}
+	dto.TermID = termID.String
+	return dto.typeRecDS, nil
+}
+
+func scanTypeSql(row *sql.Row) (dto typeRecDS, err error) {
+	var termID sql.NullString
+	err = row.Scan(&dto.TypeID, &dto.TypeRN, &dto.Title, &termID)
+	dto.TermID = termID.String
+	return dto, err
+}
+
+func scanStateSql(rows *sql.Rows) (dto stateDS, err error) {
+	spec := data.JsonSql[specDS]{}
+	err = rows.Scan(&dto.ID, &dto.K, &dto.FromID, &spec)
+	dto.Spec = spec.V
+	return dto, err
+}
+
+const (
+	insertStateSql = `
+		insert into role_states (
+			role_id, state_id, rev_from, rev_to
+		) values (
+			@role_id, @state_id, @rev_from, @rev_to
+		)`
+
+	// former names keep resolving, the current one goes first
+	selectByQNSql = `
+		with resolved as (
+			select id, rev_to = 9223372036854775807 as current
+			from aliases
+			where sym = ?
+				and kind = ?
+			order by rev_to desc
+			limit 1
+		)
+		select
+			rr.role_id,
+			rr.rev,
+			rr.title,
+			rs.state_id,
+			a.current
+		from resolved a
+		join role_roots rr
+			on rr.role_id = a.id
+		left join role_states rs
+			on rs.role_id = rr.role_id
+			and rs.rev_from >= rr.rev
+			and rs.rev_to > rr.rev`
+
+	selectByIdSql = `
+		select
+			rr.role_id,
+			rr.rev,
+			rr.title,
+			rs.state_id
+		from role_roots rr
+		left join role_states rs
+			on rs.role_id = rr.role_id
+			and rs.rev_from >= rr.rev
+			and rs.rev_to > rr.rev
+		where rr.role_id = ?`
+
+	selectTreeSql = `
+		with recursive state_tree as (
+			select root.id, root.kind, root.from_id, root.spec
+			from states root
+			where root.id = ?
+			union all
+			select child.id, child.kind, child.from_id, child.spec
+			from states child, state_tree parent
+			where child.from_id = parent.id
+		)
+		select * from state_tree`
+)
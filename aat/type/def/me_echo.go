@@ -40,7 +40,7 @@ func (h *handlerEcho) PostOne(c echo.Context) error {
 		h.log.Error("dto mapping failed")
 		return err
 	}
-	snap, err := h.api.Create(spec)
+	snap, err := h.api.Create(ctx, spec)
 	if err != nil {
 		h.log.Error("role creation failed")
 		return err
@@ -66,7 +66,7 @@ func (h *handlerEcho) GetOne(c echo.Context) error {
 		h.log.Error("dto mapping failed")
 		return err
 	}
-	snap, err := h.api.Retrieve(id)
+	snap, err := h.api.Retrieve(c.Request().Context(), id)
 	if err != nil {
 		h.log.Error("root retrieval failed")
 		return err
@@ -93,7 +93,7 @@ func (h *handlerEcho) PatchOne(c echo.Context) error {
 		h.log.Error("dto mapping failed")
 		return err
 	}
-	resSnap, err := h.api.Modify(reqSnap)
+	resSnap, err := h.api.Modify(ctx, reqSnap)
 	if err != nil {
 		h.log.Error("role modification failed")
 		return err
@@ -0,0 +1,65 @@
+package def
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/msg"
+
+	sqlitedb "orglang/orglang/db/sqlite"
+)
+
+func TestGetOneRendersMissingRole(t *testing.T) {
+	operator := data.NewOperatorSql(newSqliteStub(t))
+	s := newService(newDaoSql(slog.Default()), &aliasRepoStub{}, operator, &outboxStub{}, slog.Default())
+	e := echo.New()
+	e.HTTPErrorHandler = msg.NewErrorHandlerEcho(slog.Default())
+	e.GET("/api/v1/roles/:id", newHandlerEcho(s, slog.Default()).GetOne)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/roles/"+id.New().String(), nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("want %v, got %v: %v", http.StatusNotFound, rec.Code, rec.Body)
+	}
+	var dto msg.ProblemME
+	err := json.Unmarshal(rec.Body.Bytes(), &dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dto.Code != "not-found" {
+		t.Fatalf("want not-found, got %v", dto.Code)
+	}
+}
+
+func newSqliteStub(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	names, err := fs.Glob(sqlitedb.Migrations, "migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		text, err := fs.ReadFile(sqlitedb.Migrations, name)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		_, err = db.Exec(string(text))
+		if err != nil {
+			t.Fatal(name, err)
+		}
+	}
+	return db
+}
@@ -0,0 +1,89 @@
+package def
+
+import (
+	"context"
+	"log/slog"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/rpc"
+)
+
+// Adapter
+type handlerGrpc struct {
+	rpc.UnimplementedRoleServiceServer
+	api API
+	log *slog.Logger
+}
+
+func newHandlerGrpc(a API, l *slog.Logger) *handlerGrpc {
+	name := slog.String("name", "roleHandlerGrpc")
+	return &handlerGrpc{api: a, log: l.With(name)}
+}
+
+func (h *handlerGrpc) CreateRole(ctx context.Context, req *rpc.CreateRoleRequest) (*rpc.RoleSnap, error) {
+	dto := TypeSpecME{TypeQN: req.GetQn(), TypeTS: msgFromTermPB(req.GetState())}
+	h.log.Log(ctx, core.LevelTrace, "role creation started", slog.Any("dto", dto))
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("dto validation failed")
+		return nil, err
+	}
+	spec, err := MsgToTypeSpec(dto)
+	if err != nil {
+		h.log.Error("dto mapping failed")
+		return nil, err
+	}
+	snap, err := h.api.Create(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return pbFromSnapMsg(MsgFromTypeSnap(snap)), nil
+}
+
+func (h *handlerGrpc) GetRole(ctx context.Context, req *rpc.GetRoleRequest) (*rpc.RoleSnap, error) {
+	dto := IdentME{ID: req.GetId()}
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("dto validation failed")
+		return nil, err
+	}
+	roleID, err := id.ConvertFromString(dto.ID)
+	if err != nil {
+		h.log.Error("dto mapping failed")
+		return nil, err
+	}
+	snap, err := h.api.Retrieve(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return pbFromSnapMsg(MsgFromTypeSnap(snap)), nil
+}
+
+func (h *handlerGrpc) ModifyRole(ctx context.Context, req *rpc.RoleSnap) (*rpc.RoleSnap, error) {
+	dto := msgFromSnapPB(req)
+	h.log.Log(ctx, core.LevelTrace, "role modification started", slog.Any("dto", dto))
+	err := dto.Validate()
+	if err != nil {
+		h.log.Error("dto validation failed")
+		return nil, err
+	}
+	reqSnap, err := MsgToTypeSnap(dto)
+	if err != nil {
+		h.log.Error("dto mapping failed")
+		return nil, err
+	}
+	resSnap, err := h.api.Modify(ctx, reqSnap)
+	if err != nil {
+		return nil, err
+	}
+	return pbFromSnapMsg(MsgFromTypeSnap(resSnap)), nil
+}
+
+func (h *handlerGrpc) ListRoles(ctx context.Context, _ *rpc.ListRolesRequest) (*rpc.ListRolesResponse, error) {
+	refs, err := h.api.RetreiveRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ListRolesResponse{Refs: pbFromRefMsgs(MsgFromTypeRefs(refs))}, nil
+}
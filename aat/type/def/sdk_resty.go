@@ -1,6 +1,7 @@
 package def
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-resty/resty/v2"
@@ -23,14 +24,15 @@ func NewAPI() API {
 	return newClientResty()
 }
 
-func (cl *clientResty) Incept(roleQN sym.ADT) (TypeRef, error) {
+func (cl *clientResty) Incept(ctx context.Context, roleQN sym.ADT) (TypeRef, error) {
 	return TypeRef{}, nil
 }
 
-func (cl *clientResty) Create(spec TypeSpec) (TypeSnap, error) {
+func (cl *clientResty) Create(ctx context.Context, spec TypeSpec) (TypeSnap, error) {
 	req := MsgFromTypeSpec(spec)
 	var res TypeSnapME
 	resp, err := cl.resty.R().
+		SetContext(ctx).
 		SetResult(&res).
 		SetBody(&req).
 		Post("/roles")
@@ -43,18 +45,18 @@ func (cl *clientResty) Create(spec TypeSpec) (TypeSnap, error) {
 	return MsgToTypeSnap(res)
 }
 
-func (c *clientResty) Modify(snap TypeSnap) (TypeSnap, error) {
+func (c *clientResty) Modify(ctx context.Context, snap TypeSnap) (TypeSnap, error) {
 	return TypeSnap{}, nil
 }
 
-func (c *clientResty) Retrieve(rid id.ADT) (TypeSnap, error) {
+func (c *clientResty) Retrieve(ctx context.Context, rid id.ADT) (TypeSnap, error) {
 	return TypeSnap{}, nil
 }
 
-func (c *clientResty) retrieveSnap(entity TypeRec) (TypeSnap, error) {
+func (c *clientResty) retrieveSnap(ctx context.Context, entity TypeRec) (TypeSnap, error) {
 	return TypeSnap{}, nil
 }
 
-func (c *clientResty) RetreiveRefs() ([]TypeRef, error) {
+func (c *clientResty) RetreiveRefs(ctx context.Context) ([]TypeRef, error) {
 	return []TypeRef{}, nil
 }
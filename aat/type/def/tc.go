@@ -6,6 +6,7 @@ import (
 
 	"golang.org/x/exp/maps"
 
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/sym"
 )
@@ -102,7 +103,7 @@ func MsgToTermSpec(dto TermSpecME) (TermSpec, error) {
 		}
 		return WithSpec{Zs: choices}, nil
 	default:
-		panic(errKindUnexpected(dto.K))
+		return nil, errKindUnexpected(dto.K)
 	}
 }
 
@@ -145,12 +146,12 @@ func MsgToTermRef(dto TermRefME) (TermRef, error) {
 	case WithKind:
 		return WithRef{rid}, nil
 	default:
-		panic(errKindUnexpected(dto.K))
+		return nil, errKindUnexpected(dto.K)
 	}
 }
 
 func errKindUnexpected(got TermKind) error {
-	return fmt.Errorf("kind unexpected: %v", got)
+	return fault.New(fault.ProtocolViolation, "kind unexpected: %v", got)
 }
 
 func DataFromTermRef(ref TermRef) *TermRefDS {
@@ -0,0 +1,102 @@
+package def
+
+import (
+	"orglang/orglang/rpc"
+)
+
+func msgFromTermPB(pb *rpc.TypeTerm) TermSpecME {
+	switch term := pb.GetTerm().(type) {
+	case *rpc.TypeTerm_One:
+		return TermSpecME{K: OneKind}
+	case *rpc.TypeTerm_Link:
+		return TermSpecME{K: LinkKind, Link: &LinkSpecME{QN: term.Link.GetQn()}}
+	case *rpc.TypeTerm_Tensor:
+		return TermSpecME{K: TensorKind, Tensor: msgFromProdPB(term.Tensor)}
+	case *rpc.TypeTerm_Lolli:
+		return TermSpecME{K: LolliKind, Lolli: msgFromProdPB(term.Lolli)}
+	case *rpc.TypeTerm_Plus:
+		return TermSpecME{K: PlusKind, Plus: msgFromSumPB(term.Plus)}
+	case *rpc.TypeTerm_With:
+		return TermSpecME{K: WithKind, With: msgFromSumPB(term.With)}
+	default:
+		// left to the validation
+		return TermSpecME{}
+	}
+}
+
+func msgFromProdPB(pb *rpc.ProdType) *ProdSpecME {
+	return &ProdSpecME{
+		Value: msgFromTermPB(pb.GetValue()),
+		Cont:  msgFromTermPB(pb.GetCont()),
+	}
+}
+
+func msgFromSumPB(pb *rpc.SumType) *SumSpecME {
+	choices := make([]ChoiceSpecME, 0, len(pb.GetChoices()))
+	for _, choice := range pb.GetChoices() {
+		choices = append(choices, ChoiceSpecME{Label: choice.GetLabel(), Cont: msgFromTermPB(choice.GetCont())})
+	}
+	return &SumSpecME{Choices: choices}
+}
+
+func pbFromTermMsg(dto TermSpecME) *rpc.TypeTerm {
+	switch dto.K {
+	case OneKind:
+		return &rpc.TypeTerm{Term: &rpc.TypeTerm_One{One: &rpc.OneType{}}}
+	case LinkKind:
+		return &rpc.TypeTerm{Term: &rpc.TypeTerm_Link{Link: &rpc.LinkType{Qn: dto.Link.QN}}}
+	case TensorKind:
+		return &rpc.TypeTerm{Term: &rpc.TypeTerm_Tensor{Tensor: pbFromProdMsg(dto.Tensor)}}
+	case LolliKind:
+		return &rpc.TypeTerm{Term: &rpc.TypeTerm_Lolli{Lolli: pbFromProdMsg(dto.Lolli)}}
+	case PlusKind:
+		return &rpc.TypeTerm{Term: &rpc.TypeTerm_Plus{Plus: pbFromSumMsg(dto.Plus)}}
+	case WithKind:
+		return &rpc.TypeTerm{Term: &rpc.TypeTerm_With{With: pbFromSumMsg(dto.With)}}
+	default:
+		panic(errKindUnexpected(dto.K))
+	}
+}
+
+func pbFromProdMsg(dto *ProdSpecME) *rpc.ProdType {
+	return &rpc.ProdType{
+		Value: pbFromTermMsg(dto.Value),
+		Cont:  pbFromTermMsg(dto.Cont),
+	}
+}
+
+func pbFromSumMsg(dto *SumSpecME) *rpc.SumType {
+	choices := make([]*rpc.ChoiceType, 0, len(dto.Choices))
+	for _, choice := range dto.Choices {
+		choices = append(choices, &rpc.ChoiceType{Label: choice.Label, Cont: pbFromTermMsg(choice.Cont)})
+	}
+	return &rpc.SumType{Choices: choices}
+}
+
+func msgFromSnapPB(pb *rpc.RoleSnap) TypeSnapME {
+	return TypeSnapME{
+		TypeID: pb.GetId(),
+		TypeRN: pb.GetRev(),
+		Title:  pb.GetTitle(),
+		TypeQN: pb.GetQn(),
+		TypeTS: msgFromTermPB(pb.GetState()),
+	}
+}
+
+func pbFromSnapMsg(dto TypeSnapME) *rpc.RoleSnap {
+	return &rpc.RoleSnap{
+		Id:    dto.TypeID,
+		Rev:   dto.TypeRN,
+		Title: dto.Title,
+		Qn:    dto.TypeQN,
+		State: pbFromTermMsg(dto.TypeTS),
+	}
+}
+
+func pbFromRefMsgs(dtos []TypeRefME) []*rpc.RoleRef {
+	refs := make([]*rpc.RoleRef, 0, len(dtos))
+	for _, dto := range dtos {
+		refs = append(refs, &rpc.RoleRef{Id: dto.TypeID, Rev: dto.TypeRN, Title: dto.Title})
+	}
+	return refs
+}
@@ -43,7 +43,7 @@ func (p *presenterEcho) PostOne(c echo.Context) error {
 		p.log.Error("dto parsing failed")
 		return err
 	}
-	snap, err := p.api.Create(TypeSpec{TypeSN: ns.New(dto.Name), TypeTS: OneSpec{}})
+	snap, err := p.api.Create(ctx, TypeSpec{TypeSN: ns.New(dto.Name), TypeTS: OneSpec{}})
 	if err != nil {
 		p.log.Error("role creation failed")
 		return err
@@ -58,7 +58,7 @@ func (p *presenterEcho) PostOne(c echo.Context) error {
 }
 
 func (p *presenterEcho) GetMany(c echo.Context) error {
-	refs, err := p.api.RetreiveRefs()
+	refs, err := p.api.RetreiveRefs(c.Request().Context())
 	if err != nil {
 		p.log.Error("refs retrieval failed")
 		return err
@@ -90,7 +90,7 @@ func (p *presenterEcho) GetOne(c echo.Context) error {
 		p.log.Error("dto mapping failed")
 		return err
 	}
-	snap, err := p.api.Retrieve(id)
+	snap, err := p.api.Retrieve(ctx, id)
 	if err != nil {
 		p.log.Error("root retrieval failed")
 		return err
@@ -1,13 +1,277 @@
 package alias
 
 import (
+	"context"
+	"log/slog"
+	"math"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
 	"orglang/orglang/avt/id"
 	"orglang/orglang/avt/rn"
 	"orglang/orglang/avt/sym"
 )
 
+// Port
+type API interface {
+	// qualified name to the entity id, zero kind matches any
+	Resolve(context.Context, sym.ADT, Kind) (Entry, error)
+	// entity id to its current qualified name
+	Lookup(context.Context, id.ADT) (Entry, error)
+	// names under the namespace
+	Browse(context.Context, TreeSpec) ([]Entry, error)
+	// names completing the last label of the prefix
+	Search(context.Context, PrefixSpec) ([]Entry, error)
+	// renames the entity or the whole namespace subtree
+	Move(context.Context, MoveSpec) ([]Entry, error)
+}
+
 type Root struct {
-	ID id.ADT
-	RN rn.ADT
-	QN sym.ADT
+	ID   id.ADT
+	RN   rn.ADT
+	QN   sym.ADT
+	Kind Kind
+}
+
+// kind of the aliased entity, names are unique per kind
+type Kind int8
+
+const (
+	nonkind Kind = iota
+	RoleKind
+	ProcDecKind
+	ProcDefKind
+	PoolDecKind
+	PoolKind
+)
+
+var kindNames = map[Kind]string{
+	RoleKind:    "role",
+	ProcDecKind: "proc-dec",
+	ProcDefKind: "proc-def",
+	PoolDecKind: "pool-dec",
+	PoolKind:    "pool",
+}
+
+func (k Kind) String() string {
+	name, ok := kindNames[k]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// current name of an aliased entity
+type Entry struct {
+	ID   id.ADT
+	RN   rn.ADT
+	QN   sym.ADT
+	Kind Kind
+	// deprecated name the entry got resolved by
+	Former sym.ADT
+}
+
+type TreeSpec struct {
+	NS sym.ADT
+	// levels below the namespace, zero means the whole subtree
+	Depth int
+	Limit int
+}
+
+type PrefixSpec struct {
+	Prefix string
+	Limit  int
+}
+
+type MoveSpec struct {
+	From sym.ADT
+	To   sym.ADT
+	// disambiguates the moved entity, zero matches any
+	Kind Kind
+	// descendants follow the namespace
+	Subtree bool
+}
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type service struct {
+	aliases  Repo
+	operator data.Operator
+	log      *slog.Logger
+}
+
+func newService(aliases Repo, operator data.Operator, l *slog.Logger) *service {
+	name := slog.String("name", "aliasService")
+	return &service{aliases, operator, l.With(name)}
+}
+
+// former names keep resolving to the current ones
+func (s *service) Resolve(ctx context.Context, qn sym.ADT, kind Kind) (entry Entry, err error) {
+	qnAttr := slog.Any("qn", qn)
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		entry, err = s.selectOne(ds, qn, kind)
+		if !fault.Is(err, fault.NotFound) {
+			return err
+		}
+		former, err := s.aliases.SelectFormer(ds, qn, kind)
+		if err != nil {
+			return err
+		}
+		entry, err = s.aliases.SelectByID(ds, former.ID)
+		entry.Former = qn
+		return err
+	})
+	if err != nil {
+		s.log.Error("resolution failed", qnAttr, slog.Any("kind", kind))
+		return Entry{}, err
+	}
+	if entry.Former != "" {
+		s.log.Warn("deprecated alias resolved", qnAttr, slog.Any("currentQN", entry.QN))
+	}
+	return entry, nil
+}
+
+func (s *service) Lookup(ctx context.Context, entityID id.ADT) (entry Entry, err error) {
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		entry, err = s.aliases.SelectByID(ds, entityID)
+		return err
+	})
+	if err != nil {
+		s.log.Error("lookup failed", slog.Any("id", entityID))
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+func (s *service) Browse(ctx context.Context, spec TreeSpec) (entries []Entry, err error) {
+	spec.Limit = clampLimit(spec.Limit)
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		entries, err = s.aliases.SelectTree(ds, spec)
+		return err
+	})
+	if err != nil {
+		s.log.Error("browsing failed", slog.Any("spec", spec))
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *service) Search(ctx context.Context, spec PrefixSpec) (entries []Entry, err error) {
+	spec.Limit = clampLimit(spec.Limit)
+	err = s.operator.Implicit(ctx, func(ds data.Source) error {
+		entries, err = s.aliases.SelectByPrefix(ds, spec)
+		return err
+	})
+	if err != nil {
+		s.log.Error("search failed", slog.Any("spec", spec))
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *service) Move(ctx context.Context, spec MoveSpec) (moved []Entry, err error) {
+	specAttr := slog.Any("spec", spec)
+	s.log.Debug("moving started", specAttr)
+	if spec.Subtree && spec.To.Within(spec.From) {
+		return nil, errMoveIntoItself(spec)
+	}
+	err = s.operator.Explicit(ctx, func(ds data.Source) error {
+		var entries []Entry
+		root, err := s.selectOne(ds, spec.From, spec.Kind)
+		// a bare namespace has no alias of its own
+		if err != nil && !(spec.Subtree && fault.Is(err, fault.NotFound)) {
+			return err
+		}
+		if err == nil {
+			entries = append(entries, root)
+		}
+		if spec.Subtree {
+			subs, err := s.aliases.SelectTree(ds, TreeSpec{NS: spec.From, Limit: math.MaxInt32})
+			if err != nil {
+				return err
+			}
+			entries = append(entries, subs...)
+		}
+		if len(entries) == 0 {
+			return errMissingQN(spec.From)
+		}
+		moved = make([]Entry, 0, len(entries))
+		for _, entry := range entries {
+			newQN := spec.To.Join(entry.QN.Rel(spec.From))
+			taken, err := s.aliases.SelectByQN(ds, newQN, entry.Kind)
+			if err != nil {
+				return err
+			}
+			if len(taken) > 0 {
+				return errAliasTaken(newQN, entry.Kind)
+			}
+			newEntry := Entry{ID: entry.ID, RN: entry.RN.Next(), QN: newQN, Kind: entry.Kind}
+			err = s.aliases.Rename(ds, entry, newEntry)
+			if err != nil {
+				return err
+			}
+			moved = append(moved, newEntry)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("moving failed", specAttr)
+		return nil, err
+	}
+	s.log.Debug("moving succeeded", specAttr, slog.Int("moved", len(moved)))
+	return moved, nil
+}
+
+// the same name may stand for entities of different kinds
+func (s *service) selectOne(ds data.Source, qn sym.ADT, kind Kind) (Entry, error) {
+	entries, err := s.aliases.SelectByQN(ds, qn, kind)
+	if err != nil {
+		return Entry{}, err
+	}
+	switch len(entries) {
+	case 0:
+		return Entry{}, errMissingQN(qn)
+	case 1:
+		return entries[0], nil
+	default:
+		return Entry{}, errAmbiguousQN(qn, entries)
+	}
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
+
+func errMissingQN(want sym.ADT) error {
+	return fault.New(fault.NotFound, "alias missing: %v", want)
+}
+
+func errMissingID(want id.ADT) error {
+	return fault.New(fault.NotFound, "alias missing: %v", want)
+}
+
+func errConcurrentMove(cur Entry) error {
+	return fault.New(fault.ConcurrentModification, "alias moved concurrently: %v", cur.QN)
+}
+
+func errAliasTaken(got sym.ADT, kind Kind) error {
+	return fault.New(fault.AlreadyExists, "alias taken: %v, kind %v", got, kind)
+}
+
+func errAmbiguousQN(got sym.ADT, entries []Entry) error {
+	kinds := make([]Kind, 0, len(entries))
+	for _, entry := range entries {
+		kinds = append(kinds, entry.Kind)
+	}
+	return fault.New(fault.ProtocolViolation, "alias ambiguous: %v, kinds %v", got, kinds)
+}
+
+func errMoveIntoItself(spec MoveSpec) error {
+	return fault.New(fault.ProtocolViolation, "namespace moved into itself: %v -> %v", spec.From, spec.To)
 }
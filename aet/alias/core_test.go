@@ -0,0 +1,284 @@
+package alias
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"testing"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+)
+
+func TestLookupTakesCurrentName(t *testing.T) {
+	s, ids := newAliasesStub(t, "acme.sales.lead")
+	ctx := context.Background()
+	entry, err := s.Lookup(ctx, ids["acme.sales.lead"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.QN != "acme.sales.lead" {
+		t.Fatalf("want acme.sales.lead, got %v", entry.QN)
+	}
+	_, err = s.Lookup(ctx, id.New())
+	if !fault.Is(err, fault.NotFound) {
+		t.Fatalf("want not found, got %v", err)
+	}
+}
+
+func TestBrowseByDepth(t *testing.T) {
+	s, _ := newAliasesStub(t, "acme.sales", "acme.sales.lead", "acme.sales.lead.deal", "acme.salesforce")
+	tests := []struct {
+		name string
+		spec TreeSpec
+		want []sym.ADT
+	}{
+		{"subtree", TreeSpec{NS: "acme.sales"}, []sym.ADT{"acme.sales.lead", "acme.sales.lead.deal"}},
+		{"children", TreeSpec{NS: "acme.sales", Depth: 1}, []sym.ADT{"acme.sales.lead"}},
+		{"limited", TreeSpec{NS: "acme", Limit: 2}, []sym.ADT{"acme.sales", "acme.sales.lead"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := s.Browse(context.Background(), tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := qns(entries); !slices.Equal(got, tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSearchCompletesLastLabel(t *testing.T) {
+	s, _ := newAliasesStub(t, "acme.sales", "acme.sales.lead", "acme.salesforce", "acme.crm")
+	entries, err := s.Search(context.Background(), PrefixSpec{Prefix: "acme.sa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sym.ADT{"acme.sales", "acme.salesforce"}
+	if got := qns(entries); !slices.Equal(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestClampLimit(t *testing.T) {
+	tests := []struct {
+		limit, want int
+	}{
+		{0, defaultLimit},
+		{-1, defaultLimit},
+		{10, 10},
+		{maxLimit + 1, maxLimit},
+	}
+	for _, tt := range tests {
+		if got := clampLimit(tt.limit); got != tt.want {
+			t.Errorf("limit %v: want %v, got %v", tt.limit, tt.want, got)
+		}
+	}
+}
+
+func TestResolveFormerName(t *testing.T) {
+	s, roots := newServiceStub(t, Root{QN: "acme.sales.lead", Kind: RoleKind})
+	ctx := context.Background()
+	_, err := s.Move(ctx, MoveSpec{From: "acme.sales.lead", To: "acme.crm.lead"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := s.Resolve(ctx, "acme.sales.lead", nonkind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.ID != roots[0].ID || entry.QN != "acme.crm.lead" || entry.Former != "acme.sales.lead" {
+		t.Fatalf("want the current name, got %v", entry)
+	}
+	entry, err = s.Resolve(ctx, "acme.crm.lead", RoleKind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Former != "" {
+		t.Fatalf("want no former name, got %v", entry.Former)
+	}
+	_, err = s.Resolve(ctx, "acme.sales.deal", nonkind)
+	if !fault.Is(err, fault.NotFound) {
+		t.Fatalf("want not found, got %v", err)
+	}
+}
+
+func TestResolveAmbiguousName(t *testing.T) {
+	s, _ := newServiceStub(t,
+		Root{QN: "acme.lead", Kind: RoleKind},
+		Root{QN: "acme.lead", Kind: ProcDecKind},
+	)
+	ctx := context.Background()
+	_, err := s.Resolve(ctx, "acme.lead", nonkind)
+	if !fault.Is(err, fault.ProtocolViolation) {
+		t.Fatalf("want ambiguity, got %v", err)
+	}
+	entry, err := s.Resolve(ctx, "acme.lead", ProcDecKind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Kind != ProcDecKind {
+		t.Fatalf("want %v, got %v", ProcDecKind, entry.Kind)
+	}
+}
+
+func TestMoveSubtree(t *testing.T) {
+	tests := []struct {
+		name  string
+		roots []Root
+		want  []sym.ADT
+	}{
+		{"with root", []Root{
+			{QN: "acme.sales", Kind: PoolKind},
+			{QN: "acme.sales.lead", Kind: RoleKind},
+			// shares the prefix only
+			{QN: "acme.salesforce", Kind: RoleKind},
+		}, []sym.ADT{"acme.crm", "acme.crm.lead"}},
+		// the namespace is bare
+		{"without root", []Root{
+			{QN: "acme.sales.lead", Kind: RoleKind},
+			{QN: "acme.sales.lead.deal", Kind: ProcDecKind},
+		}, []sym.ADT{"acme.crm.lead", "acme.crm.lead.deal"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newServiceStub(t, tt.roots...)
+			ctx := context.Background()
+			moved, err := s.Move(ctx, MoveSpec{From: "acme.sales", To: "acme.crm", Subtree: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := qns(moved); !slices.Equal(got, tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+			_, err = s.Move(ctx, MoveSpec{From: "acme.sales", To: "acme.crm", Subtree: true})
+			if !fault.Is(err, fault.NotFound) {
+				t.Fatalf("want nothing to move, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMoveRejectsCollisions(t *testing.T) {
+	s, _ := newServiceStub(t,
+		Root{QN: "acme.sales.lead", Kind: RoleKind},
+		Root{QN: "acme.sales.deal", Kind: RoleKind},
+		Root{QN: "acme.crm.deal", Kind: RoleKind},
+		// other kinds do not collide
+		Root{QN: "acme.crm.lead", Kind: ProcDecKind},
+	)
+	ctx := context.Background()
+	_, err := s.Move(ctx, MoveSpec{From: "acme.sales", To: "acme.crm", Subtree: true})
+	if !fault.Is(err, fault.AlreadyExists) {
+		t.Fatalf("want the name taken, got %v", err)
+	}
+	// nothing moved partially
+	entry, err := s.Resolve(ctx, "acme.sales.lead", RoleKind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Former != "" {
+		t.Fatalf("want the name kept, got %v", entry)
+	}
+	_, err = s.Move(ctx, MoveSpec{From: "acme.sales.lead", To: "acme.crm.lead", Kind: RoleKind})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Move(ctx, MoveSpec{From: "acme.sales", To: "acme.sales.old", Subtree: true})
+	if !fault.Is(err, fault.ProtocolViolation) {
+		t.Fatalf("want the move into itself rejected, got %v", err)
+	}
+}
+
+func TestRenameMemDetectsConcurrentMoves(t *testing.T) {
+	operator := data.NewOperatorMem()
+	r := newDaoMem(slog.Default())
+	root := Root{ID: id.New(), RN: rn.Initial(), QN: "acme.lead", Kind: RoleKind}
+	cur := Entry{ID: root.ID, RN: root.RN, QN: root.QN, Kind: root.Kind}
+	next := Entry{ID: root.ID, RN: root.RN.Next(), QN: "acme.deal", Kind: root.Kind}
+	ctx := context.Background()
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		err := r.Insert(ds, root)
+		if err != nil {
+			return err
+		}
+		return r.Rename(ds, cur, next)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = operator.Explicit(ctx, func(ds data.Source) error {
+		return r.Rename(ds, cur, Entry{ID: root.ID, RN: root.RN.Next(), QN: "acme.other", Kind: root.Kind})
+	})
+	if !fault.Is(err, fault.ConcurrentModification) {
+		t.Fatalf("want concurrent modification, got %v", err)
+	}
+	var former Entry
+	err = operator.Implicit(ctx, func(ds data.Source) (err error) {
+		former, err = r.SelectFormer(ds, "acme.lead", RoleKind)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if former.ID != root.ID {
+		t.Fatalf("want %v, got %v", root.ID, former.ID)
+	}
+}
+
+func newServiceStub(t *testing.T, roots ...Root) (*service, []Root) {
+	t.Helper()
+	operator := data.NewOperatorMem()
+	r := newDaoMem(slog.Default())
+	for i := range roots {
+		roots[i].ID = id.New()
+		roots[i].RN = rn.Initial()
+	}
+	err := operator.Explicit(context.Background(), func(ds data.Source) error {
+		for _, root := range roots {
+			err := r.Insert(ds, root)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newService(r, operator, slog.Default()), roots
+}
+
+// current aliases in the mem tables, the entity ids by name
+func newAliasesStub(t *testing.T, names ...sym.ADT) (*service, map[sym.ADT]id.ADT) {
+	t.Helper()
+	operator := data.NewOperatorMem()
+	ids := make(map[sym.ADT]id.ADT, len(names))
+	err := operator.Explicit(context.Background(), func(source data.Source) error {
+		ds := data.MustConform[data.SourceMem](source)
+		for _, name := range names {
+			ids[name] = id.New()
+			data.InsertRows(ds, aliasesMem, aliasRowMem{ID: ids[name].String(), Sym: string(name), RevFrom: 1, RevTo: math.MaxInt64})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newService(newDaoMem(slog.Default()), operator, slog.Default()), ids
+}
+
+func qns(entries []Entry) []sym.ADT {
+	var qns []sym.ADT
+	for _, entry := range entries {
+		qns = append(qns, entry.QN)
+	}
+	slices.Sort(qns)
+	return qns
+}
@@ -3,11 +3,44 @@
 package alias
 
 import (
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
+
+	"orglang/orglang/avt/data"
 )
 
 var Module = fx.Module("aet/alias",
 	fx.Provide(
-		fx.Annotate(newDaoPgx, fx.As(new(Repo))),
+		newRepoByMapping,
+		fx.Annotate(newService, fx.As(new(API))),
+	),
+	fx.Provide(
+		fx.Private,
+		newHandlerEcho,
+	),
+	fx.Invoke(
+		cfgEcho,
 	),
 )
+
+func newRepoByMapping(m data.Mapping, l *slog.Logger) Repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
+func cfgEcho(e *echo.Echo, h *handlerEcho) error {
+	e.GET("/api/v1/aliases", h.GetMany)
+	e.GET("/api/v1/aliases/:qn", h.GetOne)
+	e.GET("/api/v1/aliases/:qn/tree", h.GetTree)
+	e.POST("/api/v1/aliases/:qn/move", h.PostMove)
+	e.GET("/api/v1/entities/:id/alias", h.GetByID)
+	return nil
+}
@@ -1,15 +1,37 @@
 package alias
 
 import (
+	"database/sql"
+
 	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
 )
 
+// Port
 type Repo interface {
 	Insert(data.Source, Root) error
+	// current revisions only, one per kind at most, zero kind matches any
+	SelectByQN(data.Source, sym.ADT, Kind) ([]Entry, error)
+	SelectByID(data.Source, id.ADT) (Entry, error)
+	SelectTree(data.Source, TreeSpec) ([]Entry, error)
+	SelectByPrefix(data.Source, PrefixSpec) ([]Entry, error)
+	// the latest closed revision with the name
+	SelectFormer(data.Source, sym.ADT, Kind) (Entry, error)
+	// closes the current revision and opens the next one
+	Rename(source data.Source, cur Entry, next Entry) error
 }
 
 type rootDS struct {
-	ID  string
-	RN  int64
-	Sym string
+	ID   string
+	RN   int64
+	Sym  string
+	Kind int16
+}
+
+type entryDS struct {
+	ID   string        `db:"id"`
+	RN   int64         `db:"rev_from"`
+	Sym  string        `db:"sym"`
+	Kind sql.NullInt16 `db:"kind"`
 }
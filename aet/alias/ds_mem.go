@@ -0,0 +1,174 @@
+package alias
+
+import (
+	"database/sql"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	name := slog.String("name", "aliasRepoMem")
+	return &daoMem{l.With(name)}
+}
+
+const aliasesMem = "aliases"
+
+type aliasRowMem struct {
+	ID      string
+	Sym     string
+	RevFrom int64
+	RevTo   int64
+	Kind    int16
+}
+
+func (r *daoMem) Insert(source data.Source, root Root) error {
+	ds := data.MustConform[data.SourceMem](source)
+	idAttr := slog.Any("id", root.ID)
+	dto := dataFromRoot(root)
+	taken := slices.ContainsFunc(currentRowsMem(ds), func(row aliasRowMem) bool {
+		return row.Sym == dto.Sym && row.Kind == dto.Kind
+	})
+	if taken {
+		r.log.Error("entity insertion failed", idAttr)
+		return errAliasTaken(root.QN, root.Kind)
+	}
+	data.InsertRows(ds, aliasesMem, aliasRowMem{ID: dto.ID, Sym: dto.Sym, RevFrom: dto.RN, RevTo: math.MaxInt64, Kind: dto.Kind})
+	return nil
+}
+
+// for the in-memory repos of the aliased entities,
+// former names resolve unless the name is current
+func LookupMem(ds data.SourceMem, qn string, kind Kind) (_ string, current bool, ok bool) {
+	var former aliasRowMem
+	for _, row := range data.Rows[aliasRowMem](ds, aliasesMem) {
+		if row.Sym != qn || row.Kind != int16(kind) {
+			continue
+		}
+		if row.RevTo == math.MaxInt64 {
+			return row.ID, true, true
+		}
+		if row.RevTo > former.RevTo {
+			former = row
+		}
+	}
+	return former.ID, false, former.ID != ""
+}
+
+func (r *daoMem) SelectByQN(source data.Source, qn sym.ADT, kind Kind) ([]Entry, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	var dtos []entryDS
+	for _, row := range currentRowsMem(ds) {
+		if row.Sym == string(qn) && row.matches(kind) {
+			dtos = append(dtos, row.entry())
+		}
+	}
+	slices.SortFunc(dtos, func(a, b entryDS) int { return int(a.Kind.Int16 - b.Kind.Int16) })
+	return dataToEntries(dtos)
+}
+
+func (r *daoMem) SelectByID(source data.Source, entityID id.ADT) (Entry, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	for _, row := range currentRowsMem(ds) {
+		if row.ID == entityID.String() {
+			return dataToEntry(row.entry())
+		}
+	}
+	return Entry{}, errMissingID(entityID)
+}
+
+func (r *daoMem) SelectTree(source data.Source, spec TreeSpec) ([]Entry, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	ns := string(spec.NS) + "."
+	levels := strings.Count(ns, ".")
+	return selectSortedMem(ds, spec.Limit, func(row aliasRowMem) bool {
+		if !strings.HasPrefix(row.Sym, ns) {
+			return false
+		}
+		return spec.Depth == 0 || strings.Count(row.Sym, ".")-levels < spec.Depth
+	})
+}
+
+func (r *daoMem) SelectByPrefix(source data.Source, spec PrefixSpec) ([]Entry, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	levels := strings.Count(spec.Prefix, ".")
+	return selectSortedMem(ds, spec.Limit, func(row aliasRowMem) bool {
+		return strings.HasPrefix(row.Sym, spec.Prefix) && strings.Count(row.Sym, ".") == levels
+	})
+}
+
+func (r *daoMem) SelectFormer(source data.Source, qn sym.ADT, kind Kind) (Entry, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	var former *aliasRowMem
+	for _, row := range data.Rows[aliasRowMem](ds, aliasesMem) {
+		if row.Sym == string(qn) && row.matches(kind) && row.RevTo != math.MaxInt64 && (former == nil || row.RevTo > former.RevTo) {
+			former = &row
+		}
+	}
+	if former == nil {
+		return Entry{}, errMissingQN(qn)
+	}
+	return dataToEntry(former.entry())
+}
+
+func (r *daoMem) Rename(source data.Source, cur Entry, next Entry) error {
+	ds := data.MustConform[data.SourceMem](source)
+	closed := data.UpdateRows(ds, aliasesMem, func(row *aliasRowMem) bool {
+		if row.ID != cur.ID.String() || row.RevFrom != rn.ConvertToInt(cur.RN) || row.RevTo != math.MaxInt64 {
+			return false
+		}
+		row.RevTo = rn.ConvertToInt(next.RN)
+		return true
+	})
+	if closed == 0 {
+		return errConcurrentMove(cur)
+	}
+	data.InsertRows(ds, aliasesMem, aliasRowMem{
+		ID:      next.ID.String(),
+		Sym:     string(next.QN),
+		RevFrom: rn.ConvertToInt(next.RN),
+		RevTo:   math.MaxInt64,
+		Kind:    int16(next.Kind),
+	})
+	return nil
+}
+
+func selectSortedMem(ds data.SourceMem, limit int, match func(aliasRowMem) bool) ([]Entry, error) {
+	var dtos []entryDS
+	for _, row := range currentRowsMem(ds) {
+		if match(row) {
+			dtos = append(dtos, row.entry())
+		}
+	}
+	slices.SortFunc(dtos, func(a, b entryDS) int { return strings.Compare(a.Sym, b.Sym) })
+	return dataToEntries(dtos[:min(limit, len(dtos))])
+}
+
+func currentRowsMem(ds data.SourceMem) []aliasRowMem {
+	var rows []aliasRowMem
+	for _, row := range data.Rows[aliasRowMem](ds, aliasesMem) {
+		if row.RevTo == math.MaxInt64 {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (row aliasRowMem) entry() entryDS {
+	return entryDS{ID: row.ID, RN: row.RevFrom, Sym: row.Sym, Kind: sql.NullInt16{Int16: row.Kind, Valid: true}}
+}
+
+func (row aliasRowMem) matches(kind Kind) bool {
+	return kind == nonkind || row.Kind == int16(kind)
+}
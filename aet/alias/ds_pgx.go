@@ -1,11 +1,17 @@
 package alias
 
 import (
+	"errors"
 	"log/slog"
 	"math"
-	"orglang/orglang/avt/data"
+	"reflect"
 
 	"github.com/jackc/pgx/v5"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
 )
 
 // Adapter
@@ -26,27 +32,208 @@ func newRepo() Repo {
 func (r *daoPgx) Insert(source data.Source, root Root) error {
 	ds := data.MustConform[data.SourcePgx](source)
 	idAttr := slog.Any("id", root.ID)
-	dto, err := DataFromRoot(root)
-	if err != nil {
-		r.log.Error("model mapping failed", idAttr)
-		return err
-	}
+	dto := dataFromRoot(root)
 	query := `
 		insert into aliases (
-			id, rev_from, rev_to, sym
+			id, rev_from, rev_to, sym, kind
 		) values (
-			@id, @rev_from, @rev_to, @sym
+			@id, @rev_from, @rev_to, @sym, @kind
 		)`
 	args := pgx.NamedArgs{
 		"id":       dto.ID,
 		"rev_from": dto.RN,
 		"rev_to":   math.MaxInt64,
 		"sym":      dto.Sym,
+		"kind":     dto.Kind,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, query, args)
+	if data.UniqueViolated(err) {
+		return errAliasTaken(root.QN, root.Kind)
 	}
-	_, err = ds.Conn.Exec(ds.Ctx, query, args)
 	if err != nil {
 		r.log.Error("query execution failed", idAttr, slog.String("q", query))
 		return err
 	}
 	return nil
 }
+
+func (r *daoPgx) SelectByQN(source data.Source, qn sym.ADT, kind Kind) ([]Entry, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		"sym":    string(qn),
+		"kind":   int16(kind),
+		"rev_to": int64(math.MaxInt64),
+	}
+	return r.selectMany(ds, selectByQN, args, slog.Any("qn", qn))
+}
+
+func (r *daoPgx) SelectByID(source data.Source, entityID id.ADT) (Entry, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("id", entityID)
+	args := pgx.NamedArgs{
+		"id":     entityID.String(),
+		"rev_to": int64(math.MaxInt64),
+	}
+	rows, err := ds.Conn.Query(ds.Ctx, selectByID, args)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr)
+		return Entry{}, err
+	}
+	defer rows.Close()
+	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[entryDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Entry{}, errMissingID(entityID)
+	}
+	if err != nil {
+		r.log.Error("row collection failed", idAttr, slog.Any("t", reflect.TypeOf(dto)))
+		return Entry{}, err
+	}
+	return dataToEntry(dto)
+}
+
+func (r *daoPgx) SelectTree(source data.Source, spec TreeSpec) ([]Entry, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		"ns":     string(spec.NS),
+		"depth":  spec.Depth,
+		"limit":  spec.Limit,
+		"rev_to": int64(math.MaxInt64),
+	}
+	return r.selectMany(ds, selectTree, args, slog.Any("spec", spec))
+}
+
+func (r *daoPgx) SelectByPrefix(source data.Source, spec PrefixSpec) ([]Entry, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		// the last label matches by its beginning
+		"pattern": spec.Prefix + "*",
+		"limit":   spec.Limit,
+		"rev_to":  int64(math.MaxInt64),
+	}
+	return r.selectMany(ds, selectByPrefix, args, slog.Any("spec", spec))
+}
+
+func (r *daoPgx) SelectFormer(source data.Source, qn sym.ADT, kind Kind) (Entry, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	qnAttr := slog.Any("qn", qn)
+	args := pgx.NamedArgs{
+		"sym":    string(qn),
+		"kind":   int16(kind),
+		"rev_to": int64(math.MaxInt64),
+	}
+	rows, err := ds.Conn.Query(ds.Ctx, selectFormer, args)
+	if err != nil {
+		r.log.Error("query execution failed", qnAttr)
+		return Entry{}, err
+	}
+	defer rows.Close()
+	dto, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[entryDS])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Entry{}, errMissingQN(qn)
+	}
+	if err != nil {
+		r.log.Error("row collection failed", qnAttr, slog.Any("t", reflect.TypeOf(dto)))
+		return Entry{}, err
+	}
+	return dataToEntry(dto)
+}
+
+func (r *daoPgx) Rename(source data.Source, cur Entry, next Entry) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	idAttr := slog.Any("id", cur.ID)
+	args := pgx.NamedArgs{
+		"id":       cur.ID.String(),
+		"rev_from": rn.ConvertToInt(cur.RN),
+		"rev":      rn.ConvertToInt(next.RN),
+		"rev_to":   int64(math.MaxInt64),
+		"sym":      string(next.QN),
+		"kind":     int16(next.Kind),
+	}
+	tag, err := ds.Conn.Exec(ds.Ctx, closeAlias, args)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", closeAlias))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errConcurrentMove(cur)
+	}
+	_, err = ds.Conn.Exec(ds.Ctx, openAlias, args)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", openAlias))
+		return err
+	}
+	return nil
+}
+
+func (r *daoPgx) selectMany(ds data.SourcePgx, query string, args pgx.NamedArgs, specAttr slog.Attr) ([]Entry, error) {
+	rows, err := ds.Conn.Query(ds.Ctx, query, args)
+	if err != nil {
+		r.log.Error("query execution failed", specAttr)
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[entryDS])
+	if err != nil {
+		r.log.Error("rows collection failed", specAttr, slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	return dataToEntries(dtos)
+}
+
+const (
+	selectByQN = `
+		select id, rev_from, sym::text as sym, kind
+		from aliases
+		where sym = @sym::ltree
+			and (@kind = 0 or kind = @kind)
+			and rev_to = @rev_to
+		order by kind`
+
+	selectByID = `
+		select id, rev_from, sym::text as sym, kind
+		from aliases
+		where id = @id
+			and rev_to = @rev_to`
+
+	// strict descendants, served by the gist index
+	selectTree = `
+		select id, rev_from, sym::text as sym, kind
+		from aliases
+		where sym <@ @ns::ltree
+			and sym <> @ns::ltree
+			and (@depth = 0 or nlevel(sym) - nlevel(@ns::ltree) <= @depth)
+			and rev_to = @rev_to
+		order by sym
+		limit @limit`
+
+	selectFormer = `
+		select id, rev_from, sym::text as sym, kind
+		from aliases
+		where sym = @sym::ltree
+			and (@kind = 0 or kind = @kind)
+			and rev_to < @rev_to
+		order by rev_to desc
+		limit 1`
+
+	closeAlias = `
+		update aliases
+		set rev_to = @rev
+		where id = @id
+			and rev_from = @rev_from
+			and rev_to = @rev_to`
+
+	openAlias = `
+		insert into aliases (
+			id, rev_from, rev_to, sym, kind
+		) values (
+			@id, @rev, @rev_to, @sym, @kind
+		)`
+
+	selectByPrefix = `
+		select id, rev_from, sym::text as sym, kind
+		from aliases
+		where sym ~ @pattern::lquery
+			and rev_to = @rev_to
+		order by sym
+		limit @limit`
+)
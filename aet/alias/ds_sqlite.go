@@ -0,0 +1,229 @@
+package alias
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	name := slog.String("name", "aliasRepoSql")
+	return &daoSql{l.With(name)}
+}
+
+func (r *daoSql) Insert(source data.Source, root Root) error {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("id", root.ID)
+	dto := dataFromRoot(root)
+	query := `
+		insert into aliases (
+			id, rev_from, rev_to, sym, kind
+		) values (
+			@id, @rev_from, @rev_to, @sym, @kind
+		)`
+	args := data.NamedArgsSql{
+		"id":       dto.ID,
+		"rev_from": dto.RN,
+		"rev_to":   int64(math.MaxInt64),
+		"sym":      dto.Sym,
+		"kind":     dto.Kind,
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, query, args.List()...)
+	if data.UniqueViolated(err) {
+		return errAliasTaken(root.QN, root.Kind)
+	}
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", query))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) SelectByQN(source data.Source, qn sym.ADT, kind Kind) ([]Entry, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"sym":    string(qn),
+		"kind":   int16(kind),
+		"rev_to": int64(math.MaxInt64),
+	}
+	return r.selectMany(ds, selectByQNSql, args, slog.Any("qn", qn))
+}
+
+func (r *daoSql) SelectByID(source data.Source, entityID id.ADT) (Entry, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"id":     entityID.String(),
+		"rev_to": int64(math.MaxInt64),
+	}
+	dto, err := scanEntrySql(ds.Conn.QueryRowContext(ds.Ctx, selectByIDSql, args.List()...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Entry{}, errMissingID(entityID)
+	}
+	if err != nil {
+		r.log.Error("query execution failed", slog.Any("id", entityID))
+		return Entry{}, err
+	}
+	return dataToEntry(dto)
+}
+
+func (r *daoSql) SelectTree(source data.Source, spec TreeSpec) ([]Entry, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"ns":     string(spec.NS),
+		"depth":  spec.Depth,
+		"limit":  spec.Limit,
+		"rev_to": int64(math.MaxInt64),
+	}
+	return r.selectMany(ds, selectTreeSql, args, slog.Any("spec", spec))
+}
+
+func (r *daoSql) SelectByPrefix(source data.Source, spec PrefixSpec) ([]Entry, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"prefix": spec.Prefix,
+		"limit":  spec.Limit,
+		"rev_to": int64(math.MaxInt64),
+	}
+	return r.selectMany(ds, selectByPrefixSql, args, slog.Any("spec", spec))
+}
+
+func (r *daoSql) SelectFormer(source data.Source, qn sym.ADT, kind Kind) (Entry, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"sym":    string(qn),
+		"kind":   int16(kind),
+		"rev_to": int64(math.MaxInt64),
+	}
+	dto, err := scanEntrySql(ds.Conn.QueryRowContext(ds.Ctx, selectFormerSql, args.List()...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Entry{}, errMissingQN(qn)
+	}
+	if err != nil {
+		r.log.Error("query execution failed", slog.Any("qn", qn))
+		return Entry{}, err
+	}
+	return dataToEntry(dto)
+}
+
+func (r *daoSql) Rename(source data.Source, cur Entry, next Entry) error {
+	ds := data.MustConform[data.SourceSql](source)
+	idAttr := slog.Any("id", cur.ID)
+	args := data.NamedArgsSql{
+		"id":       cur.ID.String(),
+		"rev_from": rn.ConvertToInt(cur.RN),
+		"rev":      rn.ConvertToInt(next.RN),
+		"rev_to":   int64(math.MaxInt64),
+		"sym":      string(next.QN),
+		"kind":     int16(next.Kind),
+	}
+	res, err := ds.Conn.ExecContext(ds.Ctx, closeAliasSql, args.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", closeAliasSql))
+		return err
+	}
+	closed, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if closed == 0 {
+		return errConcurrentMove(cur)
+	}
+	_, err = ds.Conn.ExecContext(ds.Ctx, openAliasSql, args.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", idAttr, slog.String("q", openAliasSql))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) selectMany(ds data.SourceSql, query string, args data.NamedArgsSql, specAttr slog.Attr) ([]Entry, error) {
+	rows, err := ds.Conn.QueryContext(ds.Ctx, query, args.List()...)
+	if err != nil {
+		r.log.Error("query execution failed", specAttr)
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, func(rows *sql.Rows) (entryDS, error) {
+		return scanEntrySql(rows)
+	})
+	if err != nil {
+		r.log.Error("rows collection failed", specAttr)
+		return nil, err
+	}
+	return dataToEntries(dtos)
+}
+
+func scanEntrySql(row interface{ Scan(...any) error }) (dto entryDS, err error) {
+	err = row.Scan(&dto.ID, &dto.RN, &dto.Sym, &dto.Kind)
+	return dto, err
+}
+
+// the levels are counted by the dots, like with nlevel
+const (
+	selectByQNSql = `
+		select id, rev_from, sym, kind
+		from aliases
+		where sym = @sym
+			and (@kind = 0 or kind = @kind)
+			and rev_to = @rev_to
+		order by kind`
+
+	selectByIDSql = `
+		select id, rev_from, sym, kind
+		from aliases
+		where id = @id
+			and rev_to = @rev_to`
+
+	selectTreeSql = `
+		select id, rev_from, sym, kind
+		from aliases
+		where substr(sym, 1, length(@ns) + 1) = @ns || '.'
+			and (@depth = 0 or
+				length(sym) - length(replace(sym, '.', '')) - length(@ns) + length(replace(@ns, '.', '')) <= @depth)
+			and rev_to = @rev_to
+		order by sym
+		limit @limit`
+
+	selectFormerSql = `
+		select id, rev_from, sym, kind
+		from aliases
+		where sym = @sym
+			and (@kind = 0 or kind = @kind)
+			and rev_to < @rev_to
+		order by rev_to desc
+		limit 1`
+
+	closeAliasSql = `
+		update aliases
+		set rev_to = @rev
+		where id = @id
+			and rev_from = @rev_from
+			and rev_to = @rev_to`
+
+	openAliasSql = `
+		insert into aliases (
+			id, rev_from, rev_to, sym, kind
+		) values (
+			@id, @rev, @rev_to, @sym, @kind
+		)`
+
+	// completes the last label only
+	selectByPrefixSql = `
+		select id, rev_from, sym, kind
+		from aliases
+		where substr(sym, 1, length(@prefix)) = @prefix
+			and instr(substr(sym, length(@prefix) + 1), '.') = 0
+			and rev_to = @rev_to
+		order by sym
+		limit @limit`
+)
@@ -0,0 +1,172 @@
+package alias
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"log/slog"
+	"math"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+
+	sqlitedb "orglang/orglang/db/sqlite"
+)
+
+func TestSelectTreeSql(t *testing.T) {
+	db := newSqliteStub(t)
+	insertAliasesSql(t, db, "acme.sales", "acme.sales.lead", "acme.sales.lead.deal", "acme.salesforce")
+	r := newDaoSql(slog.Default())
+	tests := []struct {
+		name string
+		spec TreeSpec
+		want []sym.ADT
+	}{
+		{"subtree", TreeSpec{NS: "acme.sales", Limit: 10}, []sym.ADT{"acme.sales.lead", "acme.sales.lead.deal"}},
+		{"children", TreeSpec{NS: "acme.sales", Depth: 1, Limit: 10}, []sym.ADT{"acme.sales.lead"}},
+		{"limited", TreeSpec{NS: "acme", Limit: 2}, []sym.ADT{"acme.sales", "acme.sales.lead"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := r.SelectTree(data.SourceSql{Ctx: context.Background(), Conn: db}, tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := qns(entries); !slices.Equal(got, tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSelectByPrefixSql(t *testing.T) {
+	db := newSqliteStub(t)
+	insertAliasesSql(t, db, "acme.sales", "acme.sales.lead", "acme.salesforce", "acme.crm")
+	r := newDaoSql(slog.Default())
+	entries, err := r.SelectByPrefix(data.SourceSql{Ctx: context.Background(), Conn: db}, PrefixSpec{Prefix: "acme.sa", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sym.ADT{"acme.sales", "acme.salesforce"}
+	if got := qns(entries); !slices.Equal(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestRenameSqlKeepsHistory(t *testing.T) {
+	operator := data.NewOperatorSql(newSqliteStub(t))
+	r := newDaoSql(slog.Default())
+	ctx := context.Background()
+	lead := Root{ID: id.New(), RN: rn.Initial(), QN: "acme.sales.lead", Kind: RoleKind}
+	deal := Root{ID: id.New(), RN: rn.Initial(), QN: "acme.sales.deal", Kind: RoleKind}
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		for _, root := range []Root{lead, deal} {
+			err := r.Insert(ds, root)
+			if err != nil {
+				return err
+			}
+		}
+		cur := Entry{ID: lead.ID, RN: lead.RN, QN: lead.QN, Kind: lead.Kind}
+		next := Entry{ID: lead.ID, RN: lead.RN.Next(), QN: "acme.crm.lead", Kind: lead.Kind}
+		err := r.Rename(ds, cur, next)
+		if err != nil {
+			return err
+		}
+		// the former name is free again
+		return r.Insert(ds, Root{ID: id.New(), RN: rn.Initial(), QN: lead.QN, Kind: RoleKind})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = operator.Implicit(ctx, func(ds data.Source) error {
+		former, err := r.SelectFormer(ds, lead.QN, RoleKind)
+		if err != nil {
+			return err
+		}
+		if former.ID != lead.ID {
+			t.Errorf("want former %v, got %v", lead.ID, former.ID)
+		}
+		entry, err := r.SelectByID(ds, lead.ID)
+		if err != nil {
+			return err
+		}
+		if entry.QN != "acme.crm.lead" || entry.RN != lead.RN.Next() {
+			t.Errorf("want the next revision, got %v", entry)
+		}
+		entries, err := r.SelectTree(ds, TreeSpec{NS: "acme", Limit: 10})
+		if err != nil {
+			return err
+		}
+		want := []sym.ADT{"acme.crm.lead", "acme.sales.deal", "acme.sales.lead"}
+		if got := qns(entries); !slices.Equal(got, want) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInsertSqlScopesNamesByKind(t *testing.T) {
+	operator := data.NewOperatorSql(newSqliteStub(t))
+	r := newDaoSql(slog.Default())
+	ctx := context.Background()
+	insert := func(kind Kind) error {
+		return operator.Explicit(ctx, func(ds data.Source) error {
+			return r.Insert(ds, Root{ID: id.New(), RN: rn.Initial(), QN: "acme.lead", Kind: kind})
+		})
+	}
+	err := insert(RoleKind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = insert(ProcDecKind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = insert(RoleKind)
+	if !fault.Is(err, fault.AlreadyExists) {
+		t.Fatalf("want the name taken, got %v", err)
+	}
+}
+
+func insertAliasesSql(t *testing.T, db *sql.DB, names ...sym.ADT) {
+	t.Helper()
+	for _, name := range names {
+		_, err := db.Exec("insert into aliases (id, rev_from, rev_to, sym) values (?, 1, ?, ?)", id.New().String(), int64(math.MaxInt64), string(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func newSqliteStub(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	names, err := fs.Glob(sqlitedb.Migrations, "migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		text, err := fs.ReadFile(sqlitedb.Migrations, name)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		_, err = db.Exec(string(text))
+		if err != nil {
+			t.Fatal(name, err)
+		}
+	}
+	return db
+}
@@ -0,0 +1,57 @@
+package alias
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+)
+
+func (dto QualifiedME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.QN, sym.RequiredQN...),
+		validation.Field(&dto.Kind, optionalKind...),
+	)
+}
+
+func (dto IdentME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.ID, id.Required...),
+	)
+}
+
+func (dto TreeSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.NS, sym.RequiredQN...),
+		validation.Field(&dto.Depth, validation.Min(0)),
+		validation.Field(&dto.Limit, validation.Min(0), validation.Max(maxLimit)),
+	)
+}
+
+// the last label may be partial
+func (dto PrefixSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.Prefix, sym.RequiredQN...),
+		validation.Field(&dto.Limit, validation.Min(0), validation.Max(maxLimit)),
+	)
+}
+
+func (dto MoveSpecME) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.From, sym.RequiredQN...),
+		validation.Field(&dto.To, sym.RequiredQN...),
+		validation.Field(&dto.Kind, optionalKind...),
+	)
+}
+
+var optionalKind = []validation.Rule{
+	validation.In(kindValues()...),
+}
+
+func kindValues() []any {
+	values := make([]any, 0, len(kindNames))
+	for _, name := range kindNames {
+		values = append(values, name)
+	}
+	return values
+}
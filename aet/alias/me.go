@@ -0,0 +1,37 @@
+package alias
+
+type QualifiedME struct {
+	QN   string `json:"qn" param:"qn"`
+	Kind string `json:"kind" query:"kind"`
+}
+
+type IdentME struct {
+	ID string `json:"id" param:"id"`
+}
+
+type TreeSpecME struct {
+	NS    string `json:"ns" param:"qn"`
+	Depth int    `json:"depth" query:"depth"`
+	Limit int    `json:"limit" query:"limit"`
+}
+
+type PrefixSpecME struct {
+	Prefix string `json:"prefix" query:"prefix"`
+	Limit  int    `json:"limit" query:"limit"`
+}
+
+type MoveSpecME struct {
+	From    string `json:"from" param:"qn"`
+	To      string `json:"to"`
+	Kind    string `json:"kind"`
+	Subtree bool   `json:"subtree"`
+}
+
+type EntryME struct {
+	ID string `json:"id"`
+	RN int64  `json:"rev"`
+	QN string `json:"qn"`
+	// blank for the names inserted before the kinds
+	Kind   string `json:"kind,omitempty"`
+	Former string `json:"former_qn,omitempty"`
+}
@@ -0,0 +1,137 @@
+package alias
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/sym"
+)
+
+// Adapter
+type handlerEcho struct {
+	api API
+	log *slog.Logger
+}
+
+func newHandlerEcho(a API, l *slog.Logger) *handlerEcho {
+	name := slog.String("name", "aliasHandlerEcho")
+	return &handlerEcho{a, l.With(name)}
+}
+
+func (h *handlerEcho) GetOne(c echo.Context) error {
+	var dto QualifiedME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	entry, err := h.api.Resolve(c.Request().Context(), sym.ADT(dto.QN), msgToKind(dto.Kind))
+	if err != nil {
+		return err
+	}
+	if entry.Former != sym.Blank {
+		c.Response().Header().Set(headerDeprecation, "true")
+	}
+	return c.JSON(http.StatusOK, msgFromEntry(entry))
+}
+
+func (h *handlerEcho) GetByID(c echo.Context) error {
+	var dto IdentME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	entityID, err := id.ConvertFromString(dto.ID)
+	if err != nil {
+		h.log.Error("mapping failed", slog.Any("dto", dto))
+		return err
+	}
+	entry, err := h.api.Lookup(c.Request().Context(), entityID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, msgFromEntry(entry))
+}
+
+func (h *handlerEcho) GetTree(c echo.Context) error {
+	var dto TreeSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec := TreeSpec{NS: sym.ADT(dto.NS), Depth: dto.Depth, Limit: dto.Limit}
+	entries, err := h.api.Browse(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, msgFromEntries(entries))
+}
+
+func (h *handlerEcho) GetMany(c echo.Context) error {
+	var dto PrefixSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec := PrefixSpec{Prefix: dto.Prefix, Limit: dto.Limit}
+	entries, err := h.api.Search(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, msgFromEntries(entries))
+}
+
+func (h *handlerEcho) PostMove(c echo.Context) error {
+	var dto MoveSpecME
+	err := c.Bind(&dto)
+	if err != nil {
+		h.log.Error("binding failed", slog.Any("struct", reflect.TypeOf(dto)))
+		return err
+	}
+	err = dto.Validate()
+	if err != nil {
+		h.log.Error("validation failed", slog.Any("dto", dto))
+		return err
+	}
+	spec := MoveSpec{
+		From:    sym.ADT(dto.From),
+		To:      sym.ADT(dto.To),
+		Kind:    msgToKind(dto.Kind),
+		Subtree: dto.Subtree,
+	}
+	moved, err := h.api.Move(c.Request().Context(), spec)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, msgFromEntries(moved))
+}
+
+// resolved by a former name
+const headerDeprecation = "Deprecation"
@@ -0,0 +1,69 @@
+package alias
+
+import (
+	"orglang/orglang/avt/id"
+	"orglang/orglang/avt/rn"
+	"orglang/orglang/avt/sym"
+)
+
+func dataFromRoot(root Root) rootDS {
+	return rootDS{
+		ID:   root.ID.String(),
+		RN:   rn.ConvertToInt(root.RN),
+		Sym:  string(root.QN),
+		Kind: int16(root.Kind),
+	}
+}
+
+func dataToEntry(dto entryDS) (Entry, error) {
+	entityID, err := id.ConvertFromString(dto.ID)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		ID:   entityID,
+		RN:   rn.ConvertFromInt(dto.RN),
+		QN:   sym.ADT(dto.Sym),
+		Kind: Kind(dto.Kind.Int16),
+	}, nil
+}
+
+func dataToEntries(dtos []entryDS) ([]Entry, error) {
+	entries := make([]Entry, 0, len(dtos))
+	for _, dto := range dtos {
+		entry, err := dataToEntry(dto)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func msgFromEntry(entry Entry) EntryME {
+	return EntryME{
+		ID:     entry.ID.String(),
+		RN:     rn.ConvertToInt(entry.RN),
+		QN:     string(entry.QN),
+		Kind:   kindNames[entry.Kind],
+		Former: string(entry.Former),
+	}
+}
+
+func msgFromEntries(entries []Entry) []EntryME {
+	dtos := make([]EntryME, 0, len(entries))
+	for _, entry := range entries {
+		dtos = append(dtos, msgFromEntry(entry))
+	}
+	return dtos
+}
+
+// blank name matches any kind
+func msgToKind(name string) Kind {
+	for kind, kindName := range kindNames {
+		if kindName == name {
+			return kind
+		}
+	}
+	return nonkind
+}
@@ -0,0 +1,10 @@
+package api
+
+type props struct {
+	Validation validation `mapstructure:"validation"`
+}
+
+type validation struct {
+	Requests  bool `mapstructure:"requests"`
+	Responses bool `mapstructure:"responses"`
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	_ "embed"
+	"log/slog"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/msg"
+)
+
+var Module = fx.Module("app/api",
+	fx.Provide(
+		fx.Private,
+		newDoc,
+		newHandlerEcho,
+		newCfg,
+	),
+	fx.Invoke(
+		cfgEcho,
+	),
+)
+
+//go:embed openapi.yaml
+var docYaml []byte
+
+func newDoc() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(docYaml)
+	if err != nil {
+		return nil, err
+	}
+	err = doc.Validate(loader.Context)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func newCfg(k core.Keeper) (*props, error) {
+	props := &props{Validation: validation{Requests: true}}
+	err := k.Load("api", props)
+	if err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func cfgEcho(e *echo.Echo, h *handlerEcho, doc *openapi3.T, p *props, l *slog.Logger) {
+	spec := msg.ValidationSpec{
+		Requests:  p.Validation.Requests,
+		Responses: p.Validation.Responses,
+	}
+	e.Use(msg.NewValidatorEcho(doc, spec, l))
+	e.GET("/api/v1/openapi.json", h.GetDoc)
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// Adapter
+type handlerEcho struct {
+	doc []byte
+	log *slog.Logger
+}
+
+func newHandlerEcho(doc *openapi3.T, l *slog.Logger) (*handlerEcho, error) {
+	name := slog.String("name", "apiHandlerEcho")
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &handlerEcho{data, l.With(name)}, nil
+}
+
+func (h *handlerEcho) GetDoc(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, h.doc)
+}
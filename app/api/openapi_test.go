@@ -0,0 +1,306 @@
+package api
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/msg"
+
+	"orglang/orglang/aet/alias"
+
+	pooldump "orglang/orglang/aat/pool/dump"
+	poolexec "orglang/orglang/aat/pool/exec"
+	poolinbox "orglang/orglang/aat/pool/inbox"
+	poolledger "orglang/orglang/aat/pool/ledger"
+	procdec "orglang/orglang/aat/proc/dec"
+	procdef "orglang/orglang/aat/proc/def"
+	procexec "orglang/orglang/aat/proc/exec"
+	typedef "orglang/orglang/aat/type/def"
+)
+
+// schemas by the messages they describe
+var messages = map[string]any{
+	"Problem":      msg.ProblemME{},
+	"FieldError":   msg.FieldME{},
+	"TypeSpec":     typedef.TypeSpecME{},
+	"TypeRef":      typedef.TypeRefME{},
+	"TypeSnap":     typedef.TypeSnapME{},
+	"TypeTerm":     typedef.TermSpecME{},
+	"TypeLink":     typedef.LinkSpecME{},
+	"TypeProd":     typedef.ProdSpecME{},
+	"TypeSum":      typedef.SumSpecME{},
+	"TypeChoice":   typedef.ChoiceSpecME{},
+	"BndSpec":      procdec.BndSpecME{},
+	"SigSpec":      procdec.SigSpecME{},
+	"SigSnap":      procdec.SigSnapME{},
+	"ProcTerm":     procdef.TermSpecME{},
+	"CloseSpec":    procdef.CloseSpecME{},
+	"WaitSpec":     procdef.WaitSpecME{},
+	"SendSpec":     procdef.SendSpecME{},
+	"RecvSpec":     procdef.RecvSpecME{},
+	"LabSpec":      procdef.LabSpecME{},
+	"CaseSpec":     procdef.CaseSpecME{},
+	"BranchSpec":   procdef.BranchSpecME{},
+	"CallSpec":     procdef.CallSpecME{},
+	"SpawnSpec":    procdef.SpawnSpecME{},
+	"FwdSpec":      procdef.FwdSpecME{},
+	"ProcSpec":     procexec.SpecME{},
+	"ProcRef":      procexec.RefME{},
+	"ProcSnap":     procexec.SnapME{},
+	"PoolSpec":     poolexec.PoolSpecME{},
+	"PoolRef":      poolexec.PoolRefME{},
+	"PoolSnap":     poolexec.PoolSnapME{},
+	"StepSpec":     poolexec.StepSpecME{},
+	"CancelSpec":   poolexec.CancelSpecME{},
+	"JournalEntry": poolexec.JournalEntryME{},
+	"Quota":        poolexec.QuotaME{},
+	"Rate":         poolexec.RateME{},
+	"Event":        poolexec.EventME{},
+	"Doc":          pooldump.DocME{},
+	"DocRef":       pooldump.DocRefME{},
+	"Contribution": poolledger.ContributionME{},
+	"TaskRef":      poolinbox.TaskRefME{},
+	"TaskSnap":     poolinbox.TaskSnapME{},
+	"ClaimSpec":    poolinbox.ClaimSpecME{},
+	"DoneSpec":     poolinbox.DoneSpecME{},
+	"Entry":        alias.EntryME{},
+	"MoveSpec":     alias.MoveSpecME{},
+}
+
+func TestRoutesMatchPaths(t *testing.T) {
+	doc, err := newDoc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var documented []string
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+msg.PathToEcho(path))
+		}
+	}
+	var registered []string
+	for _, root := range []string{"../../aat", "../../aet", "../../app"} {
+		registered = append(registered, routesEcho(t, root)...)
+	}
+	for _, route := range registered {
+		if !slices.Contains(documented, route) {
+			t.Errorf("route %v is not documented", route)
+		}
+	}
+	for _, route := range documented {
+		if !slices.Contains(registered, route) {
+			t.Errorf("path %v is not registered", route)
+		}
+	}
+}
+
+func TestSchemasMatchMessages(t *testing.T) {
+	doc, err := newDoc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, ref := range doc.Components.Schemas {
+		if !ref.Value.Type.Is(openapi3.TypeObject) {
+			continue
+		}
+		if _, ok := messages[name]; !ok {
+			t.Errorf("schema %v has no message", name)
+		}
+	}
+	for name, me := range messages {
+		ref, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("message %v has no schema", name)
+			continue
+		}
+		checkSchema(t, name, ref.Value, reflect.TypeOf(me))
+	}
+}
+
+func TestValidatorRejectsRequests(t *testing.T) {
+	doc, err := newDoc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := echo.New()
+	e.Use(msg.NewValidatorEcho(doc, msg.ValidationSpec{Requests: true}, slog.Default()))
+	e.GET("/api/v1/roles/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	cases := map[string]int{
+		"/api/v1/roles/abcdefghij0123456789": http.StatusOK,
+		"/api/v1/roles/not-an-id":            http.StatusBadRequest,
+	}
+	for target, want := range cases {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != want {
+			t.Errorf("%v: want %v, got %v", target, want, rec.Code)
+		}
+	}
+}
+
+func TestValidatorLogsResponseMismatches(t *testing.T) {
+	doc, err := newDoc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	spec := msg.ValidationSpec{Requests: true, Responses: true}
+	e := echo.New()
+	e.Use(msg.NewValidatorEcho(doc, spec, slog.New(slog.NewTextHandler(&logs, nil))))
+	bodies := map[string]string{
+		"abcdefghij0123456789": `{"state":{"kind":"one"}}`,
+		"0123456789abcdefghij": `{"state":{"kind":"unknown"}}`,
+	}
+	e.GET("/api/v1/roles/:id", func(c echo.Context) error {
+		return c.JSONBlob(http.StatusOK, []byte(bodies[c.Param("id")]))
+	})
+	for roleID, body := range bodies {
+		logs.Reset()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/roles/"+roleID, nil))
+		// mismatches reach the client anyway
+		if rec.Code != http.StatusOK || rec.Body.String() != body {
+			t.Errorf("%v: want %v %v, got %v %v", roleID, http.StatusOK, body, rec.Code, rec.Body)
+		}
+		want := strings.Contains(body, "unknown")
+		if got := strings.Contains(logs.String(), "response validation failed"); got != want {
+			t.Errorf("%v: want logged %v, got %q", roleID, want, logs.String())
+		}
+	}
+}
+
+// collects e.METHOD("/api/...") registrations
+func routesEcho(t *testing.T, root string) []string {
+	var routes []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !slices.Contains(methods, sel.Sel.Name) {
+				return true
+			}
+			recv, ok := sel.X.(*ast.Ident)
+			if !ok || recv.Name != "e" {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			route, err := strconv.Unquote(lit.Value)
+			if err != nil || !strings.HasPrefix(route, "/api/") {
+				return true
+			}
+			routes = append(routes, sel.Sel.Name+" "+route)
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return routes
+}
+
+var methods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+func checkSchema(t *testing.T, name string, schema *openapi3.Schema, rt reflect.Type) {
+	fields := make(map[string]reflect.StructField)
+	var mandatory []string
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		tag, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fields[tag] = field
+		if !strings.Contains(opts, "omitempty") {
+			mandatory = append(mandatory, tag)
+		}
+	}
+	for prop, ref := range schema.Properties {
+		field, ok := fields[prop]
+		if !ok {
+			t.Errorf("%v.%v has no field in %v", name, prop, rt)
+			continue
+		}
+		want := schemaType(field.Type)
+		got := ref.Value
+		if len(got.AllOf) > 0 {
+			got = got.AllOf[0].Value
+		}
+		if want != "" && !got.Type.Is(want) {
+			t.Errorf("%v.%v is %v, want %v", name, prop, got.Type, want)
+		}
+	}
+	for tag := range fields {
+		if _, ok := schema.Properties[tag]; !ok {
+			t.Errorf("%v.%v has no property", name, tag)
+		}
+	}
+	// the omitted fields can not be required
+	for _, prop := range schema.Required {
+		if !slices.Contains(mandatory, prop) {
+			t.Errorf("%v.%v is required but optional in %v", name, prop, rt)
+		}
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func schemaType(rt reflect.Type) string {
+	if rt == timeType {
+		return openapi3.TypeString
+	}
+	switch rt.Kind() {
+	case reflect.String:
+		return openapi3.TypeString
+	case reflect.Bool:
+		return openapi3.TypeBoolean
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
+		return openapi3.TypeInteger
+	case reflect.Slice:
+		return openapi3.TypeArray
+	case reflect.Struct, reflect.Pointer, reflect.Map:
+		return openapi3.TypeObject
+	default:
+		return ""
+	}
+}
@@ -8,15 +8,20 @@ import (
 	"orglang/orglang/avt/core"
 	"orglang/orglang/avt/data"
 	"orglang/orglang/avt/msg"
+	"orglang/orglang/avt/outbox"
 
 	"orglang/orglang/aet/alias"
 
+	pooldump "orglang/orglang/aat/pool/dump"
 	poolexec "orglang/orglang/aat/pool/exec"
+	poolinbox "orglang/orglang/aat/pool/inbox"
+	poolledger "orglang/orglang/aat/pool/ledger"
 	procdec "orglang/orglang/aat/proc/dec"
 	procdef "orglang/orglang/aat/proc/def"
 	procexec "orglang/orglang/aat/proc/exec"
 	typedef "orglang/orglang/aat/type/def"
 
+	"orglang/orglang/app/api"
 	"orglang/orglang/app/web"
 )
 
@@ -26,15 +31,20 @@ func main() {
 		core.Module,
 		data.Module,
 		msg.Module,
+		outbox.Module,
 		// aet
 		alias.Module,
 		// aat
 		procdef.Module,
 		poolexec.Module,
+		poolinbox.Module,
+		poolledger.Module,
+		pooldump.Module,
 		typedef.Module,
 		procexec.Module,
 		procdec.Module,
 		// app
+		api.Module,
 		web.Module,
 	).Run()
 }
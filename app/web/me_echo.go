@@ -24,7 +24,7 @@ func newHandlerEcho(a typedef.API, r msg.Renderer, l *slog.Logger) *handlerEcho
 }
 
 func (h *handlerEcho) Home(c echo.Context) error {
-	refs, err := h.api.RetreiveRefs()
+	refs, err := h.api.RetreiveRefs(c.Request().Context())
 	if err != nil {
 		return err
 	}
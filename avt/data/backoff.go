@@ -0,0 +1,18 @@
+package data
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// full jitter over the exponentially growing ceiling
+func Backoff(attempt int, minDelay time.Duration, maxDelay time.Duration) time.Duration {
+	ceiling := maxDelay
+	if attempt < 32 && minDelay<<attempt < ceiling {
+		ceiling = minDelay << attempt
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling)
+}
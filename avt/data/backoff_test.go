@@ -0,0 +1,31 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	minDelay, maxDelay := 10*time.Millisecond, 100*time.Millisecond
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{1, 20 * time.Millisecond},
+		{3, 80 * time.Millisecond},
+		{4, 100 * time.Millisecond},
+		// no overflow on long streaks
+		{64, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for range 100 {
+			got := Backoff(tt.attempt, minDelay, maxDelay)
+			if got < 0 || got >= tt.ceiling {
+				t.Fatalf("attempt %v: want below %v, got %v", tt.attempt, tt.ceiling, got)
+			}
+		}
+	}
+	if got := Backoff(1, 0, 0); got != 0 {
+		t.Fatalf("want no delay, got %v", got)
+	}
+}
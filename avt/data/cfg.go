@@ -1,5 +1,9 @@
 package data
 
+import (
+	"time"
+)
+
 type props struct {
 	Protocol protocol `mapstructure:"protocol"`
 	Mapping  mapping  `mapstructure:"mapping"`
@@ -8,6 +12,29 @@ type props struct {
 type protocol struct {
 	Mode     string   `mapstructure:"mode"`
 	Postgres postgres `mapstructure:"postgres"`
+	Sqlite   sqlite   `mapstructure:"sqlite"`
+}
+
+const (
+	protocolPostgres = "postgres"
+	protocolMemory   = "memory"
+	protocolSqlite   = "sqlite"
+)
+
+// repo adapters
+type Mapping string
+
+const (
+	MappingPgx = Mapping("pgx")
+	MappingMem = Mapping("mem")
+	MappingSql = Mapping("sql")
+)
+
+// every protocol works with its own mapping only
+var protocolMappings = map[string]Mapping{
+	protocolPostgres: MappingPgx,
+	protocolMemory:   MappingMem,
+	protocolSqlite:   MappingSql,
 }
 
 type mapping struct {
@@ -15,5 +42,44 @@ type mapping struct {
 }
 
 type postgres struct {
+	Url     string  `mapstructure:"url"`
+	Tx      tx      `mapstructure:"tx"`
+	Pool    pool    `mapstructure:"pool"`
+	Replica replica `mapstructure:"replica"`
+	// per session, zero disables
+	StatementTimeout time.Duration `mapstructure:"statement_timeout"`
+	LockTimeout      time.Duration `mapstructure:"lock_timeout"`
+	ApplicationName  string        `mapstructure:"application_name"`
+}
+
+// serves implicit operations, empty url keeps them on the primary
+type replica struct {
+	Url  string `mapstructure:"url"`
+	Pool pool   `mapstructure:"pool"`
+}
+
+// zero keeps the pgxpool default
+type pool struct {
+	MaxConns          int32         `mapstructure:"max_conns"`
+	MinConns          int32         `mapstructure:"min_conns"`
+	MaxConnIdleTime   time.Duration `mapstructure:"max_conn_idle_time"`
+	MaxConnLifetime   time.Duration `mapstructure:"max_conn_lifetime"`
+	HealthCheckPeriod time.Duration `mapstructure:"health_check_period"`
+}
+
+// explicit transactions only
+type tx struct {
+	Isolation string `mapstructure:"isolation"`
+	ReadOnly  bool   `mapstructure:"read_only"`
+	Retry     retry  `mapstructure:"retry"`
+}
+
+type retry struct {
+	Attempts int           `mapstructure:"attempts"`
+	MinDelay time.Duration `mapstructure:"min_delay"`
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+}
+
+type sqlite struct {
 	Url string `mapstructure:"url"`
 }
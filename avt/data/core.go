@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -25,12 +27,77 @@ type Operator interface {
 	Implicit(context.Context, func(Source) error) error
 }
 
+// Port
+type Listener interface {
+	// returns once notifications on the channel are being received
+	Listen(context.Context, string) (Wakeup, error)
+}
+
+// coalesced notifications
+type Wakeup struct {
+	C      <-chan struct{}
+	Cancel func()
+}
+
 type OperatorPgx struct {
 	pool *pgxpool.Pool
+	// implicit operations only, nil keeps them on the primary
+	replica *pgxpool.Pool
+	opts    pgx.TxOptions
+	retry   retryPolicy
+	log     *slog.Logger
+}
+
+type primaryKey struct{}
+
+// routes implicit operations to the primary for read-your-writes
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
 }
 
+func primaryForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+type snapshotKey struct{}
+
+// runs explicit operations as read-only repeatable reads,
+// long readers neither block writers nor fail on conflicts
+func WithSnapshot(ctx context.Context) context.Context {
+	return context.WithValue(ctx, snapshotKey{}, true)
+}
+
+func snapshotWanted(ctx context.Context) bool {
+	wanted, _ := ctx.Value(snapshotKey{}).(bool)
+	return wanted
+}
+
+// reruns the whole operation on serialization failures and deadlocks
 func (o *OperatorPgx) Explicit(ctx context.Context, op func(Source) error) error {
-	tx, err := o.pool.Begin(ctx)
+	for attempt := 1; ; attempt++ {
+		err := o.explicitOnce(ctx, op)
+		code, ok := retryable(err)
+		if !ok {
+			return err
+		}
+		codeAttr := slog.String("code", code)
+		if attempt >= o.retry.Attempts {
+			o.log.Error("transaction retries exhausted", codeAttr, slog.Int("attempt", attempt))
+			return err
+		}
+		delay := Backoff(attempt, o.retry.MinDelay, o.retry.MaxDelay)
+		o.log.Warn("transaction conflict", codeAttr, slog.Int("attempt", attempt), slog.Duration("delay", delay))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (o *OperatorPgx) explicitOnce(ctx context.Context, op func(Source) error) error {
+	tx, err := o.pool.BeginTx(ctx, o.txOptions(ctx))
 	if err != nil {
 		return err
 	}
@@ -41,8 +108,15 @@ func (o *OperatorPgx) Explicit(ctx context.Context, op func(Source) error) error
 	return tx.Commit(ctx)
 }
 
+func (o *OperatorPgx) txOptions(ctx context.Context) pgx.TxOptions {
+	if snapshotWanted(ctx) {
+		return pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	}
+	return o.opts
+}
+
 func (o *OperatorPgx) Implicit(ctx context.Context, op func(Source) error) error {
-	conn, err := o.pool.Acquire(ctx)
+	conn, err := o.implicitPool(ctx).Acquire(ctx)
 	if err != nil {
 		return err
 	}
@@ -50,6 +124,14 @@ func (o *OperatorPgx) Implicit(ctx context.Context, op func(Source) error) error
 	return op(SourcePgx{Ctx: ctx, Conn: conn.Conn()})
 }
 
+// the replica may lag behind the committed explicit operations
+func (o *OperatorPgx) implicitPool(ctx context.Context) *pgxpool.Pool {
+	if o.replica != nil && !primaryForced(ctx) {
+		return o.replica
+	}
+	return o.pool
+}
+
 func MustConform[T Source](got Source) T {
 	ds, ok := got.(T)
 	if !ok {
@@ -58,3 +140,8 @@ func MustConform[T Source](got Source) T {
 	}
 	return ds
 }
+
+// UniqueViolated reports a unique constraint violation of any mapping
+func UniqueViolated(err error) bool {
+	return uniqueViolatedPgx(err) || uniqueViolatedSql(err)
+}
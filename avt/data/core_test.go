@@ -0,0 +1,58 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestOperatorPgxSnapshotOptions(t *testing.T) {
+	o := &OperatorPgx{opts: pgx.TxOptions{IsoLevel: pgx.Serializable}}
+	ctx := context.Background()
+	if got := o.txOptions(ctx); got != o.opts {
+		t.Fatalf("want %v, got %v", o.opts, got)
+	}
+	want := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	if got := o.txOptions(WithSnapshot(ctx)); got != want {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestOperatorPgxImplicitRouting(t *testing.T) {
+	primary, replica := newPoolStub(t), newPoolStub(t)
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		replica *pgxpool.Pool
+		ctx     context.Context
+		want    *pgxpool.Pool
+	}{
+		{"replica", replica, ctx, replica},
+		{"forced primary", replica, WithPrimary(ctx), primary},
+		{"no replica", nil, ctx, primary},
+		{"no replica forced", nil, WithPrimary(ctx), primary},
+		// snapshots apply to explicit operations only
+		{"snapshot", replica, WithSnapshot(ctx), replica},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &OperatorPgx{pool: primary, replica: tt.replica}
+			if got := o.implicitPool(tt.ctx); got != tt.want {
+				t.Fatalf("want %p, got %p", tt.want, got)
+			}
+		})
+	}
+}
+
+// connects lazily, never in the test
+func newPoolStub(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	pool, err := pgxpool.New(context.Background(), "postgres://localhost:5432/orglang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
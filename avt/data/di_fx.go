@@ -2,6 +2,11 @@ package data
 
 import (
 	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"strconv"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/fx"
@@ -11,8 +16,8 @@ import (
 
 var Module = fx.Module("avt/data",
 	fx.Provide(
-		newPgx,
-		fx.Annotate(newOperator, fx.As(new(Operator))),
+		newStorage,
+		newMapping,
 	),
 	fx.Provide(
 		fx.Private,
@@ -20,8 +25,92 @@ var Module = fx.Module("avt/data",
 	),
 )
 
-func newOperator(pool *pgxpool.Pool) Operator {
-	return &OperatorPgx{pool}
+func newStorage(p *props, l *slog.Logger, lc fx.Lifecycle) (Operator, Listener, error) {
+	switch p.Protocol.Mode {
+	case protocolMemory:
+		operator := NewOperatorMem()
+		return operator, operator, nil
+	case protocolSqlite:
+		db, err := newSqlite(p, lc)
+		if err != nil {
+			return nil, nil, err
+		}
+		operator := NewOperatorSql(db)
+		return operator, operator, nil
+	case protocolPostgres, "":
+		pool, err := newPgx(p, lc)
+		if err != nil {
+			return nil, nil, err
+		}
+		replica, err := newReplica(p, lc)
+		if err != nil {
+			return nil, nil, err
+		}
+		operator, err := newOperatorPgx(pool, replica, p.Protocol.Postgres.Tx, l)
+		if err != nil {
+			return nil, nil, err
+		}
+		return operator, newListener(pool, l, lc), nil
+	default:
+		return nil, nil, errProtocolUnexpected(p.Protocol.Mode)
+	}
+}
+
+// the first mode wins, the protocol one by default
+func newMapping(p *props) (Mapping, error) {
+	mode := p.Protocol.Mode
+	if mode == "" {
+		mode = protocolPostgres
+	}
+	want := protocolMappings[mode]
+	if len(p.Mapping.Modes) == 0 {
+		return want, nil
+	}
+	got := Mapping(p.Mapping.Modes[0])
+	if got != want {
+		return "", errMappingMismatch(mode, got)
+	}
+	return got, nil
+}
+
+func newOperatorPgx(pool *pgxpool.Pool, replica *pgxpool.Pool, p tx, l *slog.Logger) (*OperatorPgx, error) {
+	opts, err := newTxOptions(p)
+	if err != nil {
+		return nil, err
+	}
+	policy := retryPolicy(p.Retry)
+	if policy.Attempts < 1 {
+		policy.Attempts = 1
+	}
+	name := slog.String("name", "operatorPgx")
+	return &OperatorPgx{pool, replica, opts, policy, l.With(name)}, nil
+}
+
+func newListener(pool *pgxpool.Pool, l *slog.Logger, lc fx.Lifecycle) *ListenerPgx {
+	listener := newListenerPgx(pool, l)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(
+		fx.Hook{
+			OnStart: func(context.Context) error {
+				go func() {
+					defer close(done)
+					listener.run(ctx)
+				}()
+				return nil
+			},
+			OnStop: func(stopCtx context.Context) error {
+				cancel()
+				select {
+				case <-done:
+					return nil
+				case <-stopCtx.Done():
+					return stopCtx.Err()
+				}
+			},
+		},
+	)
+	return listener
 }
 
 func newCfg(k core.Keeper) (*props, error) {
@@ -34,15 +123,35 @@ func newCfg(k core.Keeper) (*props, error) {
 }
 
 func newPgx(p *props, lc fx.Lifecycle) (*pgxpool.Pool, error) {
-	config, err := pgxpool.ParseConfig(p.Protocol.Postgres.Url)
+	err := p.Protocol.Postgres.Validate()
 	if err != nil {
 		return nil, err
 	}
-	config.MaxConns = 2
+	return openPgx(p.Protocol.Postgres.Url, p.Protocol.Postgres.Pool, p.Protocol.Postgres, "pool", lc)
+}
+
+// nil without the url
+func newReplica(p *props, lc fx.Lifecycle) (*pgxpool.Pool, error) {
+	replica := p.Protocol.Postgres.Replica
+	if replica.Url == "" {
+		return nil, nil
+	}
+	return openPgx(replica.Url, replica.Pool, p.Protocol.Postgres, "replica", lc)
+}
+
+// the session settings are shared with the replica
+func openPgx(url string, pp pool, p postgres, statsKey string, lc fx.Lifecycle) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, err
+	}
+	cfgPool(config, pp)
+	cfgSession(config, p)
 	pgx, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, err
 	}
+	publishStats(statsKey, pgx)
 	lc.Append(
 		fx.Hook{
 			OnStart: pgx.Ping,
@@ -54,3 +163,82 @@ func newPgx(p *props, lc fx.Lifecycle) (*pgxpool.Pool, error) {
 	)
 	return pgx, nil
 }
+
+func cfgPool(config *pgxpool.Config, p pool) {
+	if p.MaxConns > 0 {
+		config.MaxConns = p.MaxConns
+	}
+	if p.MinConns > 0 {
+		config.MinConns = p.MinConns
+	}
+	if p.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = p.MaxConnIdleTime
+	}
+	if p.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = p.MaxConnLifetime
+	}
+	if p.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = p.HealthCheckPeriod
+	}
+}
+
+func cfgSession(config *pgxpool.Config, p postgres) {
+	params := config.ConnConfig.RuntimeParams
+	if p.StatementTimeout > 0 {
+		params["statement_timeout"] = strconv.FormatInt(p.StatementTimeout.Milliseconds(), 10)
+	}
+	if p.LockTimeout > 0 {
+		params["lock_timeout"] = strconv.FormatInt(p.LockTimeout.Milliseconds(), 10)
+	}
+	if p.ApplicationName != "" {
+		params["application_name"] = p.ApplicationName
+	}
+}
+
+// the latest pool per key gets published, there is only one per process
+func publishStats(key string, pool *pgxpool.Pool) {
+	stats.Set(key, expvar.Func(func() any {
+		stat := pool.Stat()
+		return map[string]any{
+			"acquired_conns":         stat.AcquiredConns(),
+			"idle_conns":             stat.IdleConns(),
+			"total_conns":            stat.TotalConns(),
+			"max_conns":              stat.MaxConns(),
+			"acquire_count":          stat.AcquireCount(),
+			"acquire_duration_ms":    stat.AcquireDuration().Milliseconds(),
+			"empty_acquire_count":    stat.EmptyAcquireCount(),
+			"canceled_acquire_count": stat.CanceledAcquireCount(),
+			"new_conns_count":        stat.NewConnsCount(),
+			"lifetime_destroy_count": stat.MaxLifetimeDestroyCount(),
+			"idle_destroy_count":     stat.MaxIdleDestroyCount(),
+		}
+	}))
+}
+
+var stats = expvar.NewMap("avt/data")
+
+func errProtocolUnexpected(got string) error {
+	return fmt.Errorf("storage protocol unexpected: %q", got)
+}
+
+func errMappingMismatch(protocol string, got Mapping) error {
+	return fmt.Errorf("storage mapping mismatch: protocol %q, mapping %q", protocol, got)
+}
+
+func newSqlite(p *props, lc fx.Lifecycle) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", p.Protocol.Sqlite.Url)
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				return migrateSqlite(ctx, db)
+			},
+			OnStop: func(ctx context.Context) error {
+				return db.Close()
+			},
+		},
+	)
+	return db, nil
+}
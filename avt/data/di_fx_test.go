@@ -0,0 +1,38 @@
+package data
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestCfgSession(t *testing.T) {
+	tests := []struct {
+		name  string
+		props postgres
+		want  map[string]string
+	}{
+		{"defaults", postgres{}, map[string]string{}},
+		{"timeouts", postgres{StatementTimeout: 30 * time.Second, LockTimeout: 1500 * time.Millisecond},
+			map[string]string{"statement_timeout": "30000", "lock_timeout": "1500"}},
+		{"application name", postgres{ApplicationName: "orglang-worker"},
+			map[string]string{"application_name": "orglang-worker"}},
+		// zero disables
+		{"zero timeouts", postgres{StatementTimeout: 0, LockTimeout: 0}, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := pgxpool.ParseConfig("postgres://localhost:5432/orglang")
+			if err != nil {
+				t.Fatal(err)
+			}
+			clear(config.ConnConfig.RuntimeParams)
+			cfgSession(config, tt.props)
+			if got := config.ConnConfig.RuntimeParams; !maps.Equal(got, tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Adapter
+type ListenerPgx struct {
+	pool    *pgxpool.Pool
+	mu      sync.Mutex
+	waiters map[string]map[chan struct{}]struct{}
+	active  map[string]bool
+	pending []chan struct{}
+	resync  chan struct{}
+	log     *slog.Logger
+}
+
+func newListenerPgx(pool *pgxpool.Pool, l *slog.Logger) *ListenerPgx {
+	name := slog.String("name", "listenerPgx")
+	return &ListenerPgx{
+		pool:    pool,
+		waiters: make(map[string]map[chan struct{}]struct{}),
+		active:  make(map[string]bool),
+		resync:  make(chan struct{}, 1),
+		log:     l.With(name),
+	}
+}
+
+func (l *ListenerPgx) Listen(ctx context.Context, channel string) (Wakeup, error) {
+	ch := make(chan struct{}, 1)
+	l.mu.Lock()
+	if l.waiters[channel] == nil {
+		l.waiters[channel] = make(map[chan struct{}]struct{})
+	}
+	l.waiters[channel][ch] = struct{}{}
+	var ready chan struct{}
+	if !l.active[channel] {
+		ready = make(chan struct{})
+		l.pending = append(l.pending, ready)
+	}
+	l.mu.Unlock()
+	wakeup := Wakeup{C: ch, Cancel: func() { l.unlisten(channel, ch) }}
+	if ready == nil {
+		return wakeup, nil
+	}
+	l.poke()
+	select {
+	case <-ready:
+		return wakeup, nil
+	case <-ctx.Done():
+		wakeup.Cancel()
+		return Wakeup{}, ctx.Err()
+	}
+}
+
+func (l *ListenerPgx) unlisten(channel string, ch chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.waiters[channel], ch)
+	if len(l.waiters[channel]) == 0 {
+		delete(l.waiters, channel)
+		l.poke()
+	}
+}
+
+func (l *ListenerPgx) poke() {
+	select {
+	case l.resync <- struct{}{}:
+	default:
+	}
+}
+
+func (l *ListenerPgx) dispatch(channel string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.waiters[channel] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// wakes everyone up after notifications could have been missed
+func (l *ListenerPgx) broadcast() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, chs := range l.waiters {
+		for ch := range chs {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (l *ListenerPgx) run(ctx context.Context) {
+	for {
+		err := l.serve(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		l.log.Warn("listening interrupted", slog.Any("reason", err))
+		l.broadcast()
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *ListenerPgx) serve(ctx context.Context) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// listening state must not leak back into the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+	defer l.deactivate()
+	for {
+		err = l.sync(ctx, conn)
+		if err != nil {
+			return err
+		}
+		waitCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			select {
+			case <-l.resync:
+				cancel()
+			case <-waitCtx.Done():
+			}
+		}()
+		n, err := conn.WaitForNotification(waitCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if waitCtx.Err() != nil {
+				// interrupted for resync
+				continue
+			}
+			return err
+		}
+		l.dispatch(n.Channel)
+	}
+}
+
+// the part of the connection the subscriptions go through
+type execerPgx interface {
+	Exec(context.Context, string, ...any) (pgconn.CommandTag, error)
+}
+
+// aligns the server side subscriptions with the local waiters
+func (l *ListenerPgx) sync(ctx context.Context, conn execerPgx) error {
+	l.mu.Lock()
+	var listen []string
+	for channel := range l.waiters {
+		if !l.active[channel] {
+			listen = append(listen, channel)
+		}
+	}
+	pending := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+	for _, channel := range listen {
+		_, err := conn.Exec(ctx, "listen "+pgx.Identifier{channel}.Sanitize())
+		if err != nil {
+			l.log.Error("listening failed", slog.String("channel", channel))
+			l.requeue(pending)
+			return err
+		}
+	}
+	l.mu.Lock()
+	for _, channel := range listen {
+		l.active[channel] = true
+	}
+	l.mu.Unlock()
+	for _, ready := range pending {
+		close(ready)
+	}
+	return l.unlistenIdle(ctx, conn)
+}
+
+// the lock keeps new waiters from relying on a channel being dropped
+func (l *ListenerPgx) unlistenIdle(ctx context.Context, conn execerPgx) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for channel := range l.active {
+		if l.waiters[channel] != nil {
+			continue
+		}
+		_, err := conn.Exec(ctx, "unlisten "+pgx.Identifier{channel}.Sanitize())
+		if err != nil {
+			l.log.Error("unlistening failed", slog.String("channel", channel))
+			return err
+		}
+		delete(l.active, channel)
+	}
+	return nil
+}
+
+func (l *ListenerPgx) requeue(pending []chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending = append(l.pending, pending...)
+}
+
+func (l *ListenerPgx) deactivate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	clear(l.active)
+}
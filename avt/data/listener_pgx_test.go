@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestListenerPgxRelistensAfterUnlisten(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := newListenerPgx(nil, slog.Default())
+	conn := &execerStub{}
+	go func() {
+		for {
+			select {
+			case <-l.resync:
+				_ = l.sync(ctx, conn)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	first, err := l.Listen(ctx, "chnl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	returned := make(chan Wakeup)
+	// a waiter arrives while the channel is being dropped
+	conn.onUnlisten = func() {
+		go func() {
+			second, _ := l.Listen(ctx, "chnl")
+			conn.record("returned")
+			returned <- second
+		}()
+		time.Sleep(50 * time.Millisecond)
+	}
+	first.Cancel()
+	select {
+	case second := <-returned:
+		defer second.Cancel()
+	case <-time.After(5 * time.Second):
+		t.Fatal("listen timed out")
+	}
+	want := []string{`listen "chnl"`, `unlisten "chnl"`, `listen "chnl"`, "returned"}
+	if got := conn.history(); !slices.Equal(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+type execerStub struct {
+	mu         sync.Mutex
+	log        []string
+	onUnlisten func()
+}
+
+func (e *execerStub) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	e.record(sql)
+	if e.onUnlisten != nil && sql == `unlisten "chnl"` {
+		e.onUnlisten()
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (e *execerStub) record(entry string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.log = append(e.log, entry)
+}
+
+func (e *execerStub) history() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Clone(e.log)
+}
@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// in-memory tables shared by every repo of the process
+type SourceMem struct {
+	Ctx context.Context
+	tx  *txMem
+}
+
+func (SourceMem) source() {}
+
+type txMem struct {
+	// committed tables at the start of the transaction
+	base map[string]any
+	// tables changed by the transaction
+	dirty    map[string]any
+	notices  []string
+	readOnly bool
+}
+
+func (tx *txMem) table(name string) (any, bool) {
+	rows, ok := tx.dirty[name]
+	if ok {
+		return rows, true
+	}
+	rows, ok = tx.base[name]
+	return rows, ok
+}
+
+// Adapter, serves as the listener as well
+type OperatorMem struct {
+	notifier
+	// serializes explicit transactions
+	writer sync.Mutex
+	mu     sync.RWMutex
+	tables map[string]any
+}
+
+func NewOperatorMem() *OperatorMem {
+	return &OperatorMem{
+		notifier: newNotifier(),
+		tables:   make(map[string]any),
+	}
+}
+
+func (o *OperatorMem) Explicit(ctx context.Context, op func(Source) error) error {
+	o.writer.Lock()
+	defer o.writer.Unlock()
+	tx := &txMem{base: o.snapshot(), dirty: make(map[string]any), readOnly: snapshotWanted(ctx)}
+	err := op(SourceMem{Ctx: ctx, tx: tx})
+	if err != nil {
+		// nothing to roll back, the changes never got published
+		return err
+	}
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+	o.commit(tx)
+	return nil
+}
+
+func (o *OperatorMem) Implicit(ctx context.Context, op func(Source) error) error {
+	tx := &txMem{base: o.snapshot(), readOnly: true}
+	return op(SourceMem{Ctx: ctx, tx: tx})
+}
+
+func (o *OperatorMem) snapshot() map[string]any {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	tables := make(map[string]any, len(o.tables))
+	for name, rows := range o.tables {
+		tables[name] = rows
+	}
+	return tables
+}
+
+func (o *OperatorMem) commit(tx *txMem) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for name, rows := range tx.dirty {
+		o.tables[name] = rows
+	}
+	o.notify(tx.notices)
+}
+
+// delivered to the listeners on commit only
+func (ds SourceMem) Notify(channel string) {
+	ds.tx.notices = append(ds.tx.notices, channel)
+}
+
+// the rows must be treated as immutable, changes go through SetRows
+func Rows[T any](ds SourceMem, table string) []T {
+	rows, ok := ds.tx.table(table)
+	if !ok {
+		return nil
+	}
+	// appends always reallocate
+	return slices.Clip(rows.([]T))
+}
+
+func SetRows[T any](ds SourceMem, table string, rows []T) {
+	if ds.tx.readOnly {
+		panic("transaction is read-only: " + table)
+	}
+	ds.tx.dirty[table] = rows
+}
+
+func InsertRows[T any](ds SourceMem, table string, rows ...T) {
+	SetRows(ds, table, append(Rows[T](ds, table), rows...))
+}
+
+// updates matching rows in a copy of the table
+func UpdateRows[T any](ds SourceMem, table string, update func(*T) bool) int {
+	rows := slices.Clone(Rows[T](ds, table))
+	updated := 0
+	for i := range rows {
+		if update(&rows[i]) {
+			updated++
+		}
+	}
+	if updated > 0 {
+		SetRows(ds, table, rows)
+	}
+	return updated
+}
+
+func DeleteRows[T any](ds SourceMem, table string, match func(T) bool) int {
+	rows := Rows[T](ds, table)
+	kept := slices.DeleteFunc(slices.Clone(rows), match)
+	deleted := len(rows) - len(kept)
+	if deleted > 0 {
+		SetRows(ds, table, kept)
+	}
+	return deleted
+}
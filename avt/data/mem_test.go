@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOperatorMemRollback(t *testing.T) {
+	operator := NewOperatorMem()
+	ctx := context.Background()
+	err := operator.Explicit(ctx, func(source Source) error {
+		InsertRows(MustConform[SourceMem](source), "rows", 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failure := errors.New("failure")
+	err = operator.Explicit(ctx, func(source Source) error {
+		InsertRows(MustConform[SourceMem](source), "rows", 2)
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("want %v, got %v", failure, err)
+	}
+	var got []int
+	_ = operator.Implicit(ctx, func(source Source) error {
+		got = Rows[int](MustConform[SourceMem](source), "rows")
+		return nil
+	})
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("want [1], got %v", got)
+	}
+}
+
+func TestOperatorMemNotify(t *testing.T) {
+	operator := NewOperatorMem()
+	ctx := context.Background()
+	wakeup, _ := operator.Listen(ctx, "chnl")
+	defer wakeup.Cancel()
+	_ = operator.Explicit(ctx, func(source Source) error {
+		MustConform[SourceMem](source).Notify("chnl")
+		select {
+		case <-wakeup.C:
+			t.Fatal("notified before commit")
+		default:
+		}
+		return nil
+	})
+	select {
+	case <-wakeup.C:
+	default:
+		t.Fatal("not notified after commit")
+	}
+}
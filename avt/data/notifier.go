@@ -0,0 +1,52 @@
+package data
+
+import (
+	"context"
+	"sync"
+)
+
+// in-process wakeups for the embedded storages
+type notifier struct {
+	mu      *sync.Mutex
+	waiters map[string]map[chan struct{}]struct{}
+}
+
+func newNotifier() notifier {
+	return notifier{
+		mu:      &sync.Mutex{},
+		waiters: make(map[string]map[chan struct{}]struct{}),
+	}
+}
+
+func (n notifier) Listen(ctx context.Context, channel string) (Wakeup, error) {
+	ch := make(chan struct{}, 1)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.waiters[channel] == nil {
+		n.waiters[channel] = make(map[chan struct{}]struct{})
+	}
+	n.waiters[channel][ch] = struct{}{}
+	cancel := func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.waiters[channel], ch)
+		if len(n.waiters[channel]) == 0 {
+			delete(n.waiters, channel)
+		}
+	}
+	return Wakeup{C: ch, Cancel: cancel}, nil
+}
+
+// coalesced like pg_notify within a transaction
+func (n notifier) notify(channels []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, channel := range channels {
+		for ch := range n.waiters[channel] {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
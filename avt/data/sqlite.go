@@ -0,0 +1,233 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"time"
+
+	sqlitedrv "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	sqlitedb "orglang/orglang/db/sqlite"
+)
+
+type SourceSql struct {
+	Ctx  context.Context
+	Conn ConnSql
+	tx   *txSql
+}
+
+func (SourceSql) source() {}
+
+// either a transaction or a dedicated connection
+type ConnSql interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...any) *sql.Row
+}
+
+type txSql struct {
+	notices []string
+}
+
+// delivered to the listeners on commit only
+func (ds SourceSql) Notify(channel string) {
+	ds.tx.notices = append(ds.tx.notices, channel)
+}
+
+// Adapter, serves as the listener as well
+type OperatorSql struct {
+	notifier
+	db *sql.DB
+}
+
+func NewOperatorSql(db *sql.DB) *OperatorSql {
+	return &OperatorSql{newNotifier(), db}
+}
+
+func (o *OperatorSql) Explicit(ctx context.Context, op func(Source) error) error {
+	tx, err := o.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: snapshotWanted(ctx)})
+	if err != nil {
+		return err
+	}
+	state := &txSql{}
+	err = op(SourceSql{Ctx: ctx, Conn: tx, tx: state})
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	o.notify(state.notices)
+	return nil
+}
+
+func (o *OperatorSql) Implicit(ctx context.Context, op func(Source) error) error {
+	conn, err := o.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	state := &txSql{}
+	err = op(SourceSql{Ctx: ctx, Conn: conn, tx: state})
+	if err != nil {
+		return err
+	}
+	o.notify(state.notices)
+	return nil
+}
+
+func CollectRowsSql[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error)) ([]T, error) {
+	defer rows.Close()
+	var dtos []T
+	for rows.Next() {
+		dto, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		dtos = append(dtos, dto)
+	}
+	return dtos, rows.Err()
+}
+
+// json column stored as text
+type JsonSql[T any] struct {
+	V T
+}
+
+func (j JsonSql[T]) Value() (driver.Value, error) {
+	b, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (j *JsonSql[T]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		var zero T
+		j.V = zero
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), &j.V)
+	case []byte:
+		return json.Unmarshal(v, &j.V)
+	default:
+		return fmt.Errorf("json column unexpected: %T", src)
+	}
+}
+
+// fixed width utc text keeps the comparisons lexical
+type TimeSql struct {
+	V time.Time
+}
+
+const timeLayoutSql = "2006-01-02 15:04:05.000000000Z07:00"
+
+var timeLayoutsSql = []string{
+	timeLayoutSql,
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+	time.DateTime,
+}
+
+func (t TimeSql) Value() (driver.Value, error) {
+	return t.V.UTC().Format(timeLayoutSql), nil
+}
+
+func (t *TimeSql) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		t.V = time.Time{}
+		return nil
+	case time.Time:
+		t.V = v
+		return nil
+	case string:
+		var err error
+		for _, layout := range timeLayoutsSql {
+			t.V, err = time.Parse(layout, v)
+			if err == nil {
+				return nil
+			}
+		}
+		return err
+	default:
+		return fmt.Errorf("time column unexpected: %T", src)
+	}
+}
+
+const createMigrations = `
+	create table if not exists schema_migrations (
+		name text primary key,
+		applied_at timestamp default current_timestamp
+	)`
+
+// every migration runs once in its own transaction
+func migrateSqlite(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, createMigrations)
+	if err != nil {
+		return err
+	}
+	names, err := fs.Glob(sqlitedb.Migrations, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		err = applyMigration(ctx, db, name)
+		if err != nil {
+			return fmt.Errorf("migration %v failed: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, name string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	var applied int
+	err = tx.QueryRowContext(ctx, "select count(*) from schema_migrations where name = ?", name).Scan(&applied)
+	if err != nil || applied > 0 {
+		return errors.Join(err, tx.Rollback())
+	}
+	script, err := fs.ReadFile(sqlitedb.Migrations, name)
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	_, err = tx.ExecContext(ctx, string(script))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	_, err = tx.ExecContext(ctx, "insert into schema_migrations (name) values (?)", name)
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// named arguments in the pgx style
+type NamedArgsSql map[string]any
+
+func (a NamedArgsSql) List() []any {
+	args := make([]any, 0, len(a))
+	for name, value := range a {
+		args = append(args, sql.Named(name, value))
+	}
+	return args
+}
+
+func uniqueViolatedSql(err error) bool {
+	var sqlErr *sqlitedrv.Error
+	return errors.As(err, &sqlErr) && sqlErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
@@ -0,0 +1,53 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestOperatorSqlRollback(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	err = migrateSqlite(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// migrations apply once
+	err = migrateSqlite(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operator := &OperatorSql{newNotifier(), db}
+	wakeup, _ := operator.Listen(ctx, "chnl")
+	defer wakeup.Cancel()
+	failure := errors.New("failure")
+	err = operator.Explicit(ctx, func(source Source) error {
+		ds := MustConform[SourceSql](source)
+		_, err := ds.Conn.ExecContext(ds.Ctx, "insert into aliases (id, sym) values (?, ?)", "1", "a.b")
+		if err != nil {
+			return err
+		}
+		ds.Notify("chnl")
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("want %v, got %v", failure, err)
+	}
+	select {
+	case <-wakeup.C:
+		t.Fatal("notified on rollback")
+	default:
+	}
+	var count int
+	err = db.QueryRowContext(ctx, "select count(*) from aliases").Scan(&count)
+	if err != nil || count != 0 {
+		t.Fatalf("want 0 rows, got %v, %v", count, err)
+	}
+}
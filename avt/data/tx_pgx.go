@@ -0,0 +1,65 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	uniqueViolation      = "23505"
+)
+
+var isolationLevels = map[string]pgx.TxIsoLevel{
+	"":                pgx.Serializable,
+	"serializable":    pgx.Serializable,
+	"repeatable read": pgx.RepeatableRead,
+	"read committed":  pgx.ReadCommitted,
+}
+
+func newTxOptions(p tx) (pgx.TxOptions, error) {
+	level, ok := isolationLevels[p.Isolation]
+	if !ok {
+		return pgx.TxOptions{}, errIsolationUnexpected(p.Isolation)
+	}
+	opts := pgx.TxOptions{IsoLevel: level}
+	if p.ReadOnly {
+		opts.AccessMode = pgx.ReadOnly
+	}
+	return opts, nil
+}
+
+// bounded exponential backoff
+type retryPolicy struct {
+	Attempts int
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// the transaction lost to a concurrent one and can be rerun as is
+func retryable(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return "", false
+	}
+	switch pgErr.Code {
+	case serializationFailure, deadlockDetected:
+		return pgErr.Code, true
+	default:
+		return "", false
+	}
+}
+
+func uniqueViolatedPgx(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+func errIsolationUnexpected(got string) error {
+	return fmt.Errorf("transaction isolation unexpected: %q", got)
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain", errors.New("failure"), false},
+		{"serialization", &pgconn.PgError{Code: serializationFailure}, true},
+		{"deadlock", &pgconn.PgError{Code: deadlockDetected}, true},
+		{"wrapped", fmt.Errorf("commit: %w", &pgconn.PgError{Code: serializationFailure}), true},
+		{"unique", &pgconn.PgError{Code: uniqueViolation}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := retryable(tt.err); got != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewTxOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		props   tx
+		want    pgx.TxOptions
+		wantErr bool
+	}{
+		{"default", tx{}, pgx.TxOptions{IsoLevel: pgx.Serializable}, false},
+		{"repeatable read", tx{Isolation: "repeatable read"}, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, false},
+		{"read only", tx{Isolation: "read committed", ReadOnly: true}, pgx.TxOptions{IsoLevel: pgx.ReadCommitted, AccessMode: pgx.ReadOnly}, false},
+		{"unexpected", tx{Isolation: "snapshot"}, pgx.TxOptions{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTxOptions(tt.props)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
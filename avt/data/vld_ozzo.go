@@ -0,0 +1,54 @@
+package data
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func (p postgres) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Url, validation.Required),
+		validation.Field(&p.Tx),
+		validation.Field(&p.Pool),
+		validation.Field(&p.Replica),
+		validation.Field(&p.StatementTimeout, validation.Min(0)),
+		validation.Field(&p.LockTimeout, validation.Min(0)),
+		// fits into NAMEDATALEN
+		validation.Field(&p.ApplicationName,
+			validation.Length(0, 63),
+			validation.Match(regexp.MustCompile(`^[\x20-\x7e]*$`)),
+		),
+	)
+}
+
+func (p tx) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Isolation, validation.In("serializable", "repeatable read", "read committed")),
+		validation.Field(&p.Retry),
+	)
+}
+
+func (p retry) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Attempts, validation.Min(0)),
+		validation.Field(&p.MinDelay, validation.Min(0)),
+		validation.Field(&p.MaxDelay, validation.Min(p.MinDelay)),
+	)
+}
+
+func (p replica) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Pool),
+	)
+}
+
+func (p pool) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.MaxConns, validation.Min(int32(0))),
+		validation.Field(&p.MinConns, validation.Min(int32(0)), validation.When(p.MaxConns > 0, validation.Max(p.MaxConns))),
+		validation.Field(&p.MaxConnIdleTime, validation.Min(0)),
+		validation.Field(&p.MaxConnLifetime, validation.Min(0)),
+		validation.Field(&p.HealthCheckPeriod, validation.Min(0)),
+	)
+}
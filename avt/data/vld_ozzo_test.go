@@ -0,0 +1,61 @@
+package data
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPoolValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		props   pool
+		wantErr bool
+	}{
+		{"defaults", pool{}, false},
+		{"sized", pool{MaxConns: 16, MinConns: 4, MaxConnIdleTime: time.Minute}, false},
+		{"min above max", pool{MaxConns: 2, MinConns: 4}, true},
+		// zero max keeps the pgxpool default
+		{"min without max", pool{MinConns: 4}, false},
+		{"negative max", pool{MaxConns: -1}, true},
+		{"negative lifetime", pool{MaxConnLifetime: -time.Second}, true},
+		{"negative health check", pool{HealthCheckPeriod: -time.Second}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.props.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPostgresValidate(t *testing.T) {
+	url := "postgres://localhost:5432/orglang"
+	tests := []struct {
+		name    string
+		props   postgres
+		wantErr bool
+	}{
+		{"minimal", postgres{Url: url}, false},
+		{"no url", postgres{}, true},
+		{"timeouts", postgres{Url: url, StatementTimeout: time.Second, LockTimeout: time.Second}, false},
+		{"negative statement timeout", postgres{Url: url, StatementTimeout: -time.Second}, true},
+		{"negative lock timeout", postgres{Url: url, LockTimeout: -time.Second}, true},
+		{"application name", postgres{Url: url, ApplicationName: "orglang-worker"}, false},
+		{"long application name", postgres{Url: url, ApplicationName: strings.Repeat("a", 64)}, true},
+		{"non ascii application name", postgres{Url: url, ApplicationName: "orglang\n"}, true},
+		{"unexpected isolation", postgres{Url: url, Tx: tx{Isolation: "snapshot"}}, true},
+		{"invalid replica pool", postgres{Url: url, Replica: replica{Pool: pool{MaxConns: -1}}}, true},
+		{"inverted retry delays", postgres{Url: url, Tx: tx{Retry: retry{MinDelay: time.Second, MaxDelay: time.Millisecond}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.props.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
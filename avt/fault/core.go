@@ -0,0 +1,94 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies runtime errors by the way callers should react on them
+type Kind int8
+
+const (
+	Unknown = Kind(iota)
+	// request is inconsistent with the protocol or the current state
+	ProtocolViolation
+	// stored state is inconsistent with itself
+	StateCorruption
+	// requested entity is missing
+	NotFound
+	// entity was modified by a concurrent request
+	ConcurrentModification
+	// request is over the configured limit, callers should back off
+	QuotaExceeded
+	// entity with the same identity exists already
+	AlreadyExists
+	// request was cut short by the server, callers should resume where it stopped
+	Unavailable
+)
+
+func (k Kind) String() string {
+	switch k {
+	case ProtocolViolation:
+		return "protocol-violation"
+	case StateCorruption:
+		return "state-corruption"
+	case NotFound:
+		return "not-found"
+	case ConcurrentModification:
+		return "concurrent-modification"
+	case QuotaExceeded:
+		return "quota-exceeded"
+	case AlreadyExists:
+		return "already-exists"
+	case Unavailable:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
+type ADT struct {
+	Kind   Kind
+	Reason error
+}
+
+func (e *ADT) Error() string {
+	return e.Reason.Error()
+}
+
+func (e *ADT) Unwrap() error {
+	return e.Reason
+}
+
+// Wrap classifies err with k, nil stays nil
+func Wrap(k Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ADT{Kind: k, Reason: err}
+}
+
+// Classify wraps err with k unless err is already classified
+func Classify(k Kind, err error) error {
+	if err == nil || KindOf(err) != Unknown {
+		return err
+	}
+	return &ADT{Kind: k, Reason: err}
+}
+
+func New(k Kind, format string, args ...any) error {
+	return &ADT{Kind: k, Reason: fmt.Errorf(format, args...)}
+}
+
+// KindOf returns the kind of the outermost classified error in the chain
+func KindOf(err error) Kind {
+	var f *ADT
+	if errors.As(err, &f) {
+		return f.Kind
+	}
+	return Unknown
+}
+
+func Is(err error, k Kind) bool {
+	return KindOf(err) == k
+}
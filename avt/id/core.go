@@ -2,6 +2,7 @@ package id
 
 import (
 	"errors"
+	"time"
 
 	"github.com/rs/xid"
 )
@@ -30,6 +31,11 @@ func (id ADT) IsEmpty() bool {
 	return xid.ID(id).IsZero()
 }
 
+// generation time, seconds precision
+func (id ADT) Time() time.Time {
+	return xid.ID(id).Time()
+}
+
 func (id ADT) String() string {
 	return xid.ID(id).String()
 }
@@ -1,13 +1,21 @@
 package msg
 
+import (
+	"slices"
+)
+
 type props struct {
 	Protocol protocol `mapstructure:"protocol"`
 	Mapping  mapping  `mapstructure:"mapping"`
 }
 
 type protocol struct {
+	// http unless set
 	Modes []string `mapstructure:"modes"`
 	Http  http     `mapstructure:"http"`
+	Grpc  grpc     `mapstructure:"grpc"`
+	// zero port disables
+	Admin admin `mapstructure:"admin"`
 }
 
 type mapping struct {
@@ -17,3 +25,25 @@ type mapping struct {
 type http struct {
 	Port int `mapstructure:"port"`
 }
+
+type grpc struct {
+	Port int `mapstructure:"port"`
+}
+
+type admin struct {
+	// localhost unless set
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+const (
+	httpMode = "http"
+	grpcMode = "grpc"
+)
+
+func (p protocol) enabled(mode string) bool {
+	if len(p.Modes) == 0 {
+		return mode == httpMode
+	}
+	return slices.Contains(p.Modes, mode)
+}
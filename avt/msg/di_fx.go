@@ -2,12 +2,17 @@ package msg
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
+	"net"
+	nethttp "net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"go.uber.org/fx"
+	gogrpc "google.golang.org/grpc"
 
 	"orglang/orglang/avt/core"
 )
@@ -15,11 +20,15 @@ import (
 var Module = fx.Module("avt/msg",
 	fx.Provide(
 		newEcho,
+		newGrpc,
 	),
 	fx.Provide(
 		fx.Private,
 		newCfg,
 	),
+	fx.Invoke(
+		serveAdmin,
+	),
 )
 
 func newCfg(k core.Keeper) (*props, error) {
@@ -34,6 +43,7 @@ func newCfg(k core.Keeper) (*props, error) {
 func newEcho(p *props, l *slog.Logger, lc fx.Lifecycle) *echo.Echo {
 	e := echo.New()
 	log := l.With(slog.String("name", "echo.Echo"))
+	e.HTTPErrorHandler = NewErrorHandlerEcho(log)
 	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		LogMethod:   true,
 		LogURI:      true,
@@ -52,6 +62,20 @@ func newEcho(p *props, l *slog.Logger, lc fx.Lifecycle) *echo.Echo {
 			return nil
 		},
 	}))
+	// panics end up in the error handler as well
+	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+			log.Error("request processing panicked",
+				slog.String("uri", c.Request().RequestURI),
+				slog.String("reason", err.Error()),
+				slog.String("stack", string(stack)),
+			)
+			return err
+		},
+	}))
+	if !p.Protocol.enabled(httpMode) {
+		return e
+	}
 	lc.Append(
 		fx.Hook{
 			OnStart: func(ctx context.Context) error {
@@ -65,3 +89,73 @@ func newEcho(p *props, l *slog.Logger, lc fx.Lifecycle) *echo.Echo {
 	)
 	return e
 }
+
+// keeps the diagnostics off the public port
+func serveAdmin(p *props, l *slog.Logger, lc fx.Lifecycle) {
+	if p.Protocol.Admin.Port == 0 {
+		return
+	}
+	host := p.Protocol.Admin.Host
+	if host == "" {
+		host = "localhost"
+	}
+	log := l.With(slog.String("name", "adminServer"))
+	mux := nethttp.NewServeMux()
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	srv := &nethttp.Server{
+		Addr:    net.JoinHostPort(host, strconv.Itoa(p.Protocol.Admin.Port)),
+		Handler: mux,
+	}
+	lc.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				lis, err := net.Listen("tcp", srv.Addr)
+				if err != nil {
+					log.Error("listening failed", slog.String("addr", srv.Addr))
+					return err
+				}
+				go srv.Serve(lis)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				return srv.Shutdown(ctx)
+			},
+		},
+	)
+}
+
+// modules register their services before the start
+func newGrpc(p *props, l *slog.Logger, lc fx.Lifecycle) *gogrpc.Server {
+	s := newServerGrpc(l.With(slog.String("name", "grpc.Server")))
+	if !p.Protocol.enabled(grpcMode) {
+		return s
+	}
+	lc.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				lis, err := net.Listen("tcp", fmt.Sprintf(":%v", p.Protocol.Grpc.Port))
+				if err != nil {
+					return err
+				}
+				go s.Serve(lis)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				// open streams hold the graceful stop until the deadline
+				done := make(chan struct{})
+				go func() {
+					s.GracefulStop()
+					close(done)
+				}()
+				select {
+				case <-done:
+					return nil
+				case <-ctx.Done():
+					s.Stop()
+					return ctx.Err()
+				}
+			},
+		},
+	)
+	return s
+}
@@ -0,0 +1,13 @@
+package msg
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+)
+
+var IdemKeyOptional = []validation.Rule{
+	validation.Length(1, 64),
+}
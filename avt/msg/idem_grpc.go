@@ -0,0 +1,16 @@
+package msg
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// aka Idempotency-Key header, blank unless sent
+func IdemKeyGrpc(ctx context.Context) string {
+	vals := metadata.ValueFromIncomingContext(ctx, HeaderIdempotencyKey)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
@@ -0,0 +1,132 @@
+package msg
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	nethttp "net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+)
+
+type ValidationSpec struct {
+	Requests  bool
+	Responses bool
+}
+
+// Adapter
+type validatorEcho struct {
+	// by method and echo path
+	routes map[string]*routers.Route
+	spec   ValidationSpec
+	log    *slog.Logger
+}
+
+// checks the exchanges of the routes the document describes, the rest pass through
+func NewValidatorEcho(doc *openapi3.T, spec ValidationSpec, l *slog.Logger) echo.MiddlewareFunc {
+	name := slog.String("name", "openapiValidatorEcho")
+	v := &validatorEcho{make(map[string]*routers.Route), spec, l.With(name)}
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			v.routes[routeKey(method, PathToEcho(path))] = &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: op,
+			}
+		}
+	}
+	return v.handle
+}
+
+var templateParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// /pools/{id} becomes /pools/:id
+func PathToEcho(path string) string {
+	return templateParam.ReplaceAllString(path, ":$1")
+}
+
+func routeKey(method string, path string) string {
+	return method + " " + path
+}
+
+func (v *validatorEcho) handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		route, ok := v.routes[routeKey(c.Request().Method, c.Path())]
+		if !ok {
+			return next(c)
+		}
+		params := make(map[string]string, len(c.ParamNames()))
+		for i, name := range c.ParamNames() {
+			params[name] = c.ParamValues()[i]
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request(),
+			PathParams: params,
+			Route:      route,
+			Options:    &openapi3filter.Options{},
+		}
+		if v.spec.Requests {
+			err := openapi3filter.ValidateRequest(c.Request().Context(), input)
+			if err != nil {
+				return echo.NewHTTPError(nethttp.StatusBadRequest, err.Error()).SetInternal(err)
+			}
+		}
+		if !v.spec.Responses {
+			return next(c)
+		}
+		res := c.Response()
+		rec := &recorderEcho{ResponseWriter: res.Writer}
+		res.Writer = rec
+		err := next(c)
+		res.Writer = rec.ResponseWriter
+		// errors get rendered later by the error handler
+		if err != nil || !res.Committed || streaming(res.Header()) {
+			return err
+		}
+		err = openapi3filter.ValidateResponse(c.Request().Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 res.Status,
+			Header:                 res.Header(),
+			Body:                   io.NopCloser(&rec.body),
+			Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+		})
+		if err != nil {
+			// already sent, so the mismatch only gets logged
+			v.log.Warn("response validation failed",
+				slog.String("method", route.Method),
+				slog.String("path", route.Path),
+				slog.Int("status", res.Status),
+				slog.String("reason", err.Error()),
+			)
+		}
+		return nil
+	}
+}
+
+func streaming(h nethttp.Header) bool {
+	return strings.HasPrefix(h.Get(echo.HeaderContentType), MIMETextEventStream)
+}
+
+// tees the body on the way to the client
+type recorderEcho struct {
+	nethttp.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorderEcho) Write(b []byte) (int, error) {
+	if !streaming(r.Header()) {
+		r.body.Write(b)
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorderEcho) Unwrap() nethttp.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,121 @@
+package msg
+
+import (
+	"errors"
+	nethttp "net/http"
+	"slices"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"orglang/orglang/avt/fault"
+)
+
+const (
+	MIMEProblemJSON = "application/problem+json"
+)
+
+// stable codes besides the fault kinds
+const (
+	// fields violate the constraints
+	CodeInvalidRequest = "invalid-request"
+	// request can not be decoded or routed
+	CodeMalformedRequest = "malformed-request"
+	CodeRouteNotFound    = "route-not-found"
+	CodeMethodNotAllowed = "method-not-allowed"
+	CodeMediaUnsupported = "media-type-unsupported"
+	CodeRequestRejected  = "request-rejected"
+	// details stay in the logs
+	CodeInternal = "internal"
+)
+
+// RFC 7807 problem details
+type ProblemME struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// unlike the detail, clients may rely on it
+	Code     string    `json:"code"`
+	Detail   string    `json:"detail,omitempty"`
+	Instance string    `json:"instance,omitempty"`
+	Errors   []FieldME `json:"errors,omitempty"`
+}
+
+type FieldME struct {
+	// dot separated json path, blank for the whole request
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func StatusFromKind(k fault.Kind) int {
+	switch k {
+	case fault.ProtocolViolation:
+		return nethttp.StatusUnprocessableEntity
+	case fault.NotFound:
+		return nethttp.StatusNotFound
+	case fault.ConcurrentModification, fault.AlreadyExists:
+		return nethttp.StatusConflict
+	case fault.QuotaExceeded:
+		return nethttp.StatusTooManyRequests
+	case fault.Unavailable:
+		return nethttp.StatusServiceUnavailable
+	default:
+		return nethttp.StatusInternalServerError
+	}
+}
+
+func MsgFromFault(f *fault.ADT, instance string) ProblemME {
+	dto := newProblem(StatusFromKind(f.Kind), f.Kind.String(), instance)
+	dto.Detail = f.Error()
+	return dto
+}
+
+// field errors keep the json names ozzo reports them by
+func MsgFromValidation(err error, instance string) ProblemME {
+	dto := newProblem(nethttp.StatusBadRequest, CodeInvalidRequest, instance)
+	dto.Detail = "request validation failed"
+	dto.Errors = msgFromFieldErrors("", err)
+	slices.SortFunc(dto.Errors, func(a, b FieldME) int { return strings.Compare(a.Field, b.Field) })
+	return dto
+}
+
+func MsgFromInternal(instance string) ProblemME {
+	return newProblem(nethttp.StatusInternalServerError, CodeInternal, instance)
+}
+
+func newProblem(status int, code string, instance string) ProblemME {
+	return ProblemME{
+		Type:     "urn:orglang:problem:" + code,
+		Title:    nethttp.StatusText(status),
+		Status:   status,
+		Code:     code,
+		Instance: instance,
+	}
+}
+
+func msgFromFieldErrors(path string, err error) []FieldME {
+	var invalidStruct validation.Errors
+	if errors.As(err, &invalidStruct) {
+		var dtos []FieldME
+		for name, fieldErr := range invalidStruct {
+			dtos = append(dtos, msgFromFieldErrors(joinField(path, name), fieldErr)...)
+		}
+		return dtos
+	}
+	var invalidValue validation.Error
+	if errors.As(err, &invalidValue) {
+		return []FieldME{{Field: path, Code: invalidValue.Code(), Message: invalidValue.Error()}}
+	}
+	return []FieldME{{Field: path, Code: CodeInvalidRequest, Message: err.Error()}}
+}
+
+func joinField(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	if name == "" {
+		return path
+	}
+	return path + "." + name
+}
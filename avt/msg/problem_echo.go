@@ -0,0 +1,112 @@
+package msg
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/fault"
+)
+
+// renders every error as a problem, so the handlers just return them
+func NewErrorHandlerEcho(l *slog.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+		dto := msgFromErrorEcho(err, c.Request().URL.Path)
+		if c.Request().Method == nethttp.MethodHead {
+			err = c.NoContent(dto.Status)
+		} else {
+			c.Response().Header().Set(echo.HeaderContentType, MIMEProblemJSON)
+			err = c.JSON(dto.Status, dto)
+		}
+		if err != nil {
+			l.Error("problem rendering failed", slog.Any("reason", err))
+		}
+	}
+}
+
+func msgFromErrorEcho(err error, instance string) ProblemME {
+	var f *fault.ADT
+	if errors.As(err, &f) {
+		return MsgFromFault(f, instance)
+	}
+	var invalidRule validation.InternalError
+	if errors.As(err, &invalidRule) {
+		return MsgFromInternal(instance)
+	}
+	var invalidStruct validation.Errors
+	var invalidValue validation.Error
+	if errors.As(err, &invalidStruct) || errors.As(err, &invalidValue) {
+		return MsgFromValidation(err, instance)
+	}
+	var malformedReq *openapi3filter.ParseError
+	if errors.As(err, &malformedReq) {
+		dto := newProblem(nethttp.StatusBadRequest, CodeMalformedRequest, instance)
+		dto.Detail = malformedReq.Error()
+		return dto
+	}
+	var invalidReq *openapi3filter.RequestError
+	if errors.As(err, &invalidReq) {
+		dto := newProblem(nethttp.StatusBadRequest, CodeInvalidRequest, instance)
+		dto.Detail = "request does not match the contract"
+		dto.Errors = []FieldME{msgFromRequestError(invalidReq)}
+		return dto
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		inner, ok := he.Internal.(*echo.HTTPError)
+		if ok {
+			he = inner
+		}
+		dto := newProblem(he.Code, codeFromStatus(he.Code), instance)
+		if he.Code < nethttp.StatusInternalServerError {
+			dto.Detail = fmt.Sprint(he.Message)
+		}
+		return dto
+	}
+	return MsgFromInternal(instance)
+}
+
+func msgFromRequestError(err *openapi3filter.RequestError) FieldME {
+	dto := FieldME{Code: CodeInvalidRequest, Message: err.Reason}
+	if err.Parameter != nil {
+		dto.Field = err.Parameter.Name
+	}
+	var invalidSchema *openapi3.SchemaError
+	if errors.As(err, &invalidSchema) {
+		dto.Field = joinField(dto.Field, strings.Join(invalidSchema.JSONPointer(), "."))
+		dto.Code = invalidSchema.SchemaField
+		dto.Message = invalidSchema.Reason
+	} else if dto.Message == "" && err.Err != nil {
+		dto.Message = err.Err.Error()
+	}
+	return dto
+}
+
+func codeFromStatus(status int) string {
+	switch status {
+	case nethttp.StatusBadRequest:
+		return CodeMalformedRequest
+	case nethttp.StatusNotFound:
+		return CodeRouteNotFound
+	case nethttp.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case nethttp.StatusUnsupportedMediaType:
+		return CodeMediaUnsupported
+	case nethttp.StatusTooManyRequests:
+		return fault.QuotaExceeded.String()
+	}
+	if status < nethttp.StatusInternalServerError {
+		return CodeRequestRejected
+	}
+	return CodeInternal
+}
@@ -0,0 +1,89 @@
+package msg
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"orglang/orglang/avt/fault"
+)
+
+func CodeFromKind(k fault.Kind) codes.Code {
+	switch k {
+	case fault.ProtocolViolation:
+		return codes.FailedPrecondition
+	case fault.StateCorruption:
+		return codes.DataLoss
+	case fault.NotFound:
+		return codes.NotFound
+	case fault.ConcurrentModification:
+		return codes.Aborted
+	case fault.QuotaExceeded:
+		return codes.ResourceExhausted
+	case fault.AlreadyExists:
+		return codes.AlreadyExists
+	case fault.Unavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// classified and validation errors become statuses, the rest is left to grpc
+func statusFromError(err error) error {
+	var f *fault.ADT
+	if errors.As(err, &f) {
+		return status.Error(CodeFromKind(f.Kind), f.Error())
+	}
+	var invalidStruct validation.Errors
+	if errors.As(err, &invalidStruct) {
+		return status.Error(codes.InvalidArgument, invalidStruct.Error())
+	}
+	var invalidValue validation.Error
+	if errors.As(err, &invalidValue) {
+		return status.Error(codes.InvalidArgument, invalidValue.Error())
+	}
+	return err
+}
+
+func newServerGrpc(l *slog.Logger) *gogrpc.Server {
+	return gogrpc.NewServer(
+		gogrpc.ChainUnaryInterceptor(newUnaryInterceptorGrpc(l)),
+		gogrpc.ChainStreamInterceptor(newStreamInterceptorGrpc(l)),
+	)
+}
+
+func newUnaryInterceptorGrpc(l *slog.Logger) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = statusFromError(err)
+			logFailureGrpc(l, info.FullMethod, err)
+		}
+		return resp, err
+	}
+}
+
+func newStreamInterceptorGrpc(l *slog.Logger) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			err = statusFromError(err)
+			logFailureGrpc(l, info.FullMethod, err)
+		}
+		return err
+	}
+}
+
+func logFailureGrpc(l *slog.Logger, method string, err error) {
+	l.Error("request processing failed",
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.String("reason", status.Convert(err).Message()),
+	)
+}
@@ -0,0 +1,92 @@
+package msg
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"orglang/orglang/avt/fault"
+	"orglang/orglang/rpc"
+)
+
+func TestCodeFromKind(t *testing.T) {
+	tests := []struct {
+		kind fault.Kind
+		want codes.Code
+	}{
+		{fault.ProtocolViolation, codes.FailedPrecondition},
+		{fault.StateCorruption, codes.DataLoss},
+		{fault.NotFound, codes.NotFound},
+		{fault.ConcurrentModification, codes.Aborted},
+		{fault.QuotaExceeded, codes.ResourceExhausted},
+		{fault.AlreadyExists, codes.AlreadyExists},
+		{fault.Unavailable, codes.Unavailable},
+		{fault.Unknown, codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := CodeFromKind(tt.kind); got != tt.want {
+			t.Errorf("kind %v: want %v, got %v", tt.kind, tt.want, got)
+		}
+	}
+}
+
+func TestInterceptorGrpcRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"classified", fault.New(fault.NotFound, "role missing: %v", "a.b"), codes.NotFound},
+		{"wrapped", errors.Join(errors.New("lookup"), fault.New(fault.AlreadyExists, "alias taken")), codes.AlreadyExists},
+		{"invalid", validation.Errors{"id": errors.New("cannot be blank")}, codes.InvalidArgument},
+		{"unclassified", errors.New("failure"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClientStub(t, &roleServerStub{err: tt.err})
+			_, err := client.GetRole(context.Background(), &rpc.GetRoleRequest{Id: "id"})
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("want %v, got %v: %v", tt.want, got, err)
+			}
+		})
+	}
+}
+
+type roleServerStub struct {
+	rpc.UnimplementedRoleServiceServer
+	err error
+}
+
+func (s *roleServerStub) GetRole(context.Context, *rpc.GetRoleRequest) (*rpc.RoleSnap, error) {
+	return nil, s.err
+}
+
+// serves over the in-memory listener with the production interceptors
+func newClientStub(t *testing.T, srv rpc.RoleServiceServer) rpc.RoleServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := newServerGrpc(slog.Default())
+	rpc.RegisterRoleServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := gogrpc.NewClient("passthrough:///bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rpc.NewRoleServiceClient(conn)
+}
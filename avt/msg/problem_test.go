@@ -0,0 +1,92 @@
+package msg
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	nethttp "net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+
+	"orglang/orglang/avt/fault"
+)
+
+type specStub struct {
+	QN   string   `json:"qn"`
+	Term termStub `json:"term"`
+}
+
+type termStub struct {
+	Kind string `json:"kind"`
+}
+
+func (dto specStub) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.QN, validation.Required),
+		validation.Field(&dto.Term),
+	)
+}
+
+func (dto termStub) Validate() error {
+	return validation.ValidateStruct(&dto,
+		validation.Field(&dto.Kind, validation.In("one")),
+	)
+}
+
+func TestErrorHandlerEcho(t *testing.T) {
+	cases := map[string]struct {
+		err    error
+		status int
+		code   string
+		fields []string
+	}{
+		"fault": {
+			fault.New(fault.ConcurrentModification, "got revision 2"),
+			nethttp.StatusConflict, "concurrent-modification", nil,
+		},
+		"validation": {
+			specStub{Term: termStub{Kind: "two"}}.Validate(),
+			nethttp.StatusBadRequest, CodeInvalidRequest, []string{"qn", "term.kind"},
+		},
+		"binding": {
+			echo.NewHTTPError(nethttp.StatusBadRequest, "malformed").SetInternal(errors.New("eof")),
+			nethttp.StatusBadRequest, CodeMalformedRequest, nil,
+		},
+		"unclassified": {
+			errors.New("connection refused"),
+			nethttp.StatusInternalServerError, CodeInternal, nil,
+		},
+	}
+	e := echo.New()
+	e.HTTPErrorHandler = NewErrorHandlerEcho(slog.Default())
+	for name, tc := range cases {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(nethttp.MethodGet, "/api/v1/roles", nil), rec)
+		e.HTTPErrorHandler(tc.err, c)
+		if rec.Code != tc.status {
+			t.Errorf("%v: want status %v, got %v", name, tc.status, rec.Code)
+		}
+		if rec.Header().Get(echo.HeaderContentType) != MIMEProblemJSON {
+			t.Errorf("%v: want problem content type, got %q", name, rec.Header().Get(echo.HeaderContentType))
+		}
+		var dto ProblemME
+		err := json.Unmarshal(rec.Body.Bytes(), &dto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dto.Code != tc.code {
+			t.Errorf("%v: want code %v, got %v", name, tc.code, dto.Code)
+		}
+		var fields []string
+		for _, f := range dto.Errors {
+			fields = append(fields, f.Field)
+		}
+		if !slices.Equal(fields, tc.fields) {
+			t.Errorf("%v: want fields %v, got %v", name, tc.fields, dto.Errors)
+		}
+	}
+}
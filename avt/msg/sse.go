@@ -0,0 +1,77 @@
+package msg
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	MIMETextEventStream = "text/event-stream"
+)
+
+// server-sent event
+type SSE struct {
+	ID    string
+	Event string
+	Data  []byte
+}
+
+func WriteSSE(w io.Writer, e SSE) error {
+	var buf bytes.Buffer
+	if e.ID != "" {
+		fmt.Fprintf(&buf, "id: %v\n", e.ID)
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&buf, "event: %v\n", e.Event)
+	}
+	for _, line := range bytes.Split(e.Data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// keeps idle connections open through proxies
+func WriteSSEComment(w io.Writer, text string) error {
+	_, err := fmt.Fprintf(w, ": %v\n\n", text)
+	return err
+}
+
+// ReadSSE dispatches events from r until yield returns false or r is drained
+func ReadSSE(r io.Reader, yield func(SSE) bool) error {
+	scanner := bufio.NewScanner(r)
+	var e SSE
+	var data [][]byte
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data == nil {
+				continue
+			}
+			e.Data = bytes.Join(data, []byte("\n"))
+			if !yield(e) {
+				return nil
+			}
+			e, data = SSE{}, nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, val, _ := strings.Cut(line, ":")
+		val = strings.TrimPrefix(val, " ")
+		switch field {
+		case "id":
+			e.ID = val
+		case "event":
+			e.Event = val
+		case "data":
+			data = append(data, []byte(val))
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"time"
+)
+
+type props struct {
+	Dispatch dispatch  `mapstructure:"dispatch"`
+	Retry    retry     `mapstructure:"retry"`
+	Webhooks []webhook `mapstructure:"webhooks"`
+}
+
+type dispatch struct {
+	// pause between sweeps besides the wakeups
+	Interval time.Duration `mapstructure:"interval"`
+	// events per claim
+	Batch int `mapstructure:"batch"`
+	// claimed events stay hidden from other dispatchers
+	Lease time.Duration `mapstructure:"lease"`
+	// dispatched events outlive it, zero keeps them forever
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+type retry struct {
+	// failed deliveries before the event gets parked, zero retries forever
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	MinDelay    time.Duration `mapstructure:"min_delay"`
+	MaxDelay    time.Duration `mapstructure:"max_delay"`
+}
+
+type webhook struct {
+	Url string `mapstructure:"url"`
+	// all kinds when empty
+	Kinds   []string      `mapstructure:"kinds"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Port for the services, runs within the state-changing transaction
+type Writer interface {
+	Write(data.Source, EventSpec) (Event, error)
+}
+
+// Port for the consumers catching up on a stream
+type Reader interface {
+	Replay(context.Context, ReplaySpec) ([]Event, error)
+}
+
+// Port
+type Registry interface {
+	Subscribe(Subscriber)
+}
+
+type Kind string
+
+type EventSpec struct {
+	Kind      Kind
+	SubjectID id.ADT
+	// events of the same stream get consecutive sequence numbers
+	StreamID id.ADT
+	// marshaled to json
+	Payload any
+}
+
+type Event struct {
+	ID        id.ADT
+	Kind      Kind
+	SubjectID id.ADT
+	StreamID  id.ADT
+	// zero outside of streams
+	Seq     int64
+	Payload json.RawMessage
+	At      time.Time
+}
+
+type ReplaySpec struct {
+	StreamID id.ADT
+	// events after this sequence number
+	Seq int64
+}
+
+// delivered at least once, handlers must tolerate duplicates
+type Subscriber struct {
+	// all kinds when empty
+	Kinds  []Kind
+	Handle func(context.Context, Event) error
+}
+
+func (s Subscriber) accepts(kind Kind) bool {
+	return len(s.Kinds) == 0 || slices.Contains(s.Kinds, kind)
+}
+
+type EventRec struct {
+	ID        id.ADT
+	Kind      Kind
+	SubjectID id.ADT
+	StreamID  id.ADT
+	Seq       int64
+	Payload   json.RawMessage
+	At        time.Time
+	// failed deliveries so far
+	Attempts  int
+	NextAt    time.Time
+	LastError string
+	// zero until the attempts run out
+	ParkedAt time.Time
+}
+
+type service struct {
+	events   Repo
+	operator data.Operator
+	mu       sync.RWMutex
+	subs     []Subscriber
+	log      *slog.Logger
+}
+
+var metrics = expvar.NewMap("avt/outbox")
+
+func newService(events Repo, operator data.Operator, l *slog.Logger) *service {
+	name := slog.String("name", "outboxService")
+	return &service{events: events, operator: operator, log: l.With(name)}
+}
+
+func (s *service) Write(ds data.Source, spec EventSpec) (Event, error) {
+	payload, err := json.Marshal(spec.Payload)
+	if err != nil {
+		return Event{}, err
+	}
+	now := time.Now()
+	rec := EventRec{
+		ID:        id.New(),
+		Kind:      spec.Kind,
+		SubjectID: spec.SubjectID,
+		StreamID:  spec.StreamID,
+		Payload:   payload,
+		At:        now,
+		NextAt:    now,
+	}
+	kindAttr := slog.Any("kind", spec.Kind)
+	if !spec.StreamID.IsEmpty() {
+		// the stream counter stays locked until the commit
+		rec.Seq, err = s.events.NextSeq(ds, spec.StreamID)
+		if err != nil {
+			s.log.Error("writing failed", kindAttr, slog.Any("streamID", spec.StreamID))
+			return Event{}, err
+		}
+	}
+	err = s.events.Insert(ds, rec)
+	if err != nil {
+		s.log.Error("writing failed", kindAttr, slog.Any("subjectID", spec.SubjectID))
+		return Event{}, err
+	}
+	return ConvertRecToEvent(rec), nil
+}
+
+// dispatched events stay replayable until swept
+func (s *service) Replay(ctx context.Context, spec ReplaySpec) ([]Event, error) {
+	var recs []EventRec
+	err := s.operator.Implicit(ctx, func(ds data.Source) (err error) {
+		recs, err = s.events.SelectStream(ds, spec.StreamID, spec.Seq)
+		return err
+	})
+	if err != nil {
+		s.log.Error("replaying failed", slog.Any("streamID", spec.StreamID), slog.Int64("seq", spec.Seq))
+		return nil, err
+	}
+	events := make([]Event, 0, len(recs))
+	for _, rec := range recs {
+		events = append(events, ConvertRecToEvent(rec))
+	}
+	return events, nil
+}
+
+func (s *service) Subscribe(sub Subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs = append(s.subs, sub)
+}
+
+func (s *service) subscribers(kind Kind) []Subscriber {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var subs []Subscriber
+	for _, sub := range s.subs {
+		if sub.accepts(kind) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+func ConvertRecToEvent(rec EventRec) Event {
+	return Event{
+		ID:        rec.ID,
+		Kind:      rec.Kind,
+		SubjectID: rec.SubjectID,
+		StreamID:  rec.StreamID,
+		Seq:       rec.Seq,
+		Payload:   rec.Payload,
+		At:        rec.At,
+	}
+}
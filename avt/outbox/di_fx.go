@@ -0,0 +1,81 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.uber.org/fx"
+
+	"orglang/orglang/avt/core"
+	"orglang/orglang/avt/data"
+)
+
+var Module = fx.Module("avt/outbox",
+	fx.Provide(
+		newService,
+		fx.Annotate(func(s *service) Writer { return s }),
+		fx.Annotate(func(s *service) Reader { return s }),
+		fx.Annotate(func(s *service) Registry { return s }),
+	),
+	fx.Provide(
+		fx.Private,
+		newRepoByMapping,
+		newDispatcher,
+		newCfg,
+	),
+	fx.Invoke(
+		cfgDispatcher,
+	),
+)
+
+func newRepoByMapping(m data.Mapping, l *slog.Logger) Repo {
+	switch m {
+	case data.MappingMem:
+		return newDaoMem(l)
+	case data.MappingSql:
+		return newDaoSql(l)
+	default:
+		return newDaoPgx(l)
+	}
+}
+
+func newCfg(k core.Keeper) (*props, error) {
+	props := &props{
+		Dispatch: dispatch{Batch: 100, Lease: time.Minute},
+	}
+	err := k.Load("outbox", props)
+	if err != nil {
+		return nil, err
+	}
+	err = props.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func cfgDispatcher(d *dispatcher, lc fx.Lifecycle) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(
+		fx.Hook{
+			OnStart: func(context.Context) error {
+				go func() {
+					defer close(done)
+					d.run(ctx)
+				}()
+				return nil
+			},
+			OnStop: func(stopCtx context.Context) error {
+				cancel()
+				select {
+				case <-done:
+					return nil
+				case <-stopCtx.Done():
+					return stopCtx.Err()
+				}
+			},
+		},
+	)
+}
@@ -0,0 +1,205 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+)
+
+// delivers committed events at least once
+type dispatcher struct {
+	events   Repo
+	subs     *service
+	hooks    []*webhookResty
+	operator data.Operator
+	listener data.Listener
+	policy   dispatchPolicy
+	log      *slog.Logger
+}
+
+type dispatchPolicy struct {
+	// pause between sweeps besides the wakeups
+	Interval time.Duration
+	Batch    int
+	Lease    time.Duration
+	// zero keeps dispatched events forever
+	Retention time.Duration
+	// zero retries forever
+	MaxAttempts int
+	MinDelay    time.Duration
+	MaxDelay    time.Duration
+}
+
+func newDispatcher(
+	events Repo,
+	subs *service,
+	operator data.Operator,
+	listener data.Listener,
+	p *props,
+	l *slog.Logger,
+) *dispatcher {
+	name := slog.String("name", "outboxDispatcher")
+	hooks := make([]*webhookResty, 0, len(p.Webhooks))
+	for _, hook := range p.Webhooks {
+		hooks = append(hooks, newWebhookResty(hook))
+	}
+	policy := dispatchPolicy{
+		Interval:    p.Dispatch.Interval,
+		Batch:       p.Dispatch.Batch,
+		Lease:       p.Dispatch.Lease,
+		Retention:   p.Dispatch.Retention,
+		MaxAttempts: p.Retry.MaxAttempts,
+		MinDelay:    p.Retry.MinDelay,
+		MaxDelay:    p.Retry.MaxDelay,
+	}
+	return &dispatcher{events, subs, hooks, operator, listener, policy, l.With(name)}
+}
+
+func (d *dispatcher) run(ctx context.Context) {
+	if d.policy.Interval <= 0 {
+		d.log.Info("dispatching disabled")
+		return
+	}
+	wakeup, err := d.listener.Listen(ctx, channel)
+	if err != nil {
+		d.log.Error("listening failed", slog.Any("reason", err))
+		return
+	}
+	defer wakeup.Cancel()
+	ticker := time.NewTicker(d.policy.Interval)
+	defer ticker.Stop()
+	for {
+		err = d.drain(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.log.Error("dispatching failed", slog.Any("reason", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err = d.sweep(ctx)
+			if err != nil && ctx.Err() == nil {
+				d.log.Error("sweeping failed", slog.Any("reason", err))
+			}
+		case <-wakeup.C:
+		}
+	}
+}
+
+// deletes dispatched events past the retention batch by batch
+func (d *dispatcher) sweep(ctx context.Context) error {
+	if d.policy.Retention <= 0 {
+		return nil
+	}
+	before := time.Now().Add(-d.policy.Retention)
+	for {
+		var deleted int64
+		err := d.operator.Explicit(ctx, func(ds data.Source) (err error) {
+			deleted, err = d.events.DeleteDispatched(ds, before, d.policy.Batch)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		metrics.Add("events_swept", deleted)
+		if deleted < int64(d.policy.Batch) {
+			return nil
+		}
+	}
+}
+
+// claims batches until the due events run out
+func (d *dispatcher) drain(ctx context.Context) error {
+	for {
+		var recs []EventRec
+		now := time.Now()
+		err := d.operator.Explicit(ctx, func(ds data.Source) (err error) {
+			recs, err = d.events.Claim(ds, now, now.Add(d.policy.Lease), d.policy.Batch)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, rec := range recs {
+			err = d.dispatch(ctx, rec)
+			if err != nil {
+				return err
+			}
+		}
+		if len(recs) < d.policy.Batch {
+			return nil
+		}
+	}
+}
+
+// a failed delivery reruns for every receiver, hence at least once
+func (d *dispatcher) dispatch(ctx context.Context, rec EventRec) error {
+	idAttr := slog.Any("eventID", rec.ID)
+	ev := ConvertRecToEvent(rec)
+	deliveryErr := d.deliver(ctx, ev)
+	if deliveryErr == nil {
+		err := d.operator.Explicit(ctx, func(ds data.Source) error {
+			return d.events.MarkDispatched(ds, rec.ID, time.Now())
+		})
+		if err != nil {
+			return err
+		}
+		metrics.Add("events_dispatched", 1)
+		d.log.Debug("dispatching succeeded", idAttr, slog.Any("kind", rec.Kind))
+		return nil
+	}
+	if ctx.Err() != nil {
+		// the lease expires and the event gets claimed again
+		return ctx.Err()
+	}
+	rec.Attempts++
+	delay := data.Backoff(rec.Attempts, d.policy.MinDelay, d.policy.MaxDelay)
+	rec.NextAt = time.Now().Add(delay)
+	rec.LastError = deliveryErr.Error()
+	parked := d.policy.MaxAttempts > 0 && rec.Attempts >= d.policy.MaxAttempts
+	if parked {
+		rec.ParkedAt = time.Now()
+	}
+	err := d.operator.Explicit(ctx, func(ds data.Source) error {
+		return d.events.MarkFailed(ds, rec)
+	})
+	if err != nil {
+		return err
+	}
+	metrics.Add("events_failed", 1)
+	if parked {
+		metrics.Add("events_parked", 1)
+		d.log.Error("delivery abandoned", idAttr, slog.Int("attempts", rec.Attempts), slog.Any("reason", deliveryErr))
+		return nil
+	}
+	d.log.Warn("delivery failed", idAttr, slog.Int("attempt", rec.Attempts), slog.Duration("delay", delay), slog.Any("reason", deliveryErr))
+	return nil
+}
+
+func (d *dispatcher) deliver(ctx context.Context, ev Event) error {
+	var errs []error
+	for _, sub := range d.subs.subscribers(ev.Kind) {
+		errs = append(errs, handle(ctx, sub, ev))
+	}
+	for _, hook := range d.hooks {
+		if hook.accepts(ev.Kind) {
+			errs = append(errs, hook.Deliver(ctx, ev))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// a panicking subscriber must not take the dispatcher down
+func handle(ctx context.Context, sub Subscriber, ev Event) (err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("subscriber panicked: %v", r)
+		}
+	}()
+	return sub.Handle(ctx, ev)
+}
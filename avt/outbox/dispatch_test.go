@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+func TestDispatcherRedelivers(t *testing.T) {
+	operator := data.NewOperatorMem()
+	events := newDaoMem(slog.Default())
+	subs := newService(events, operator, slog.Default())
+	var got []Kind
+	subs.Subscribe(Subscriber{
+		Kinds: []Kind{"wanted"},
+		Handle: func(_ context.Context, ev Event) error {
+			got = append(got, ev.Kind)
+			if len(got) == 1 {
+				return errors.New("failure")
+			}
+			return nil
+		},
+	})
+	p := &props{Dispatch: dispatch{Batch: 10}}
+	d := newDispatcher(events, subs, operator, operator, p, slog.Default())
+	ctx := context.Background()
+	for _, kind := range []Kind{"wanted", "unwanted"} {
+		err := operator.Explicit(ctx, func(ds data.Source) error {
+			_, err := subs.Write(ds, EventSpec{Kind: kind, SubjectID: id.New()})
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// zero delays make the failed event due right away
+	for range 2 {
+		err := d.drain(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("want 2 deliveries, got %v", got)
+	}
+}
+
+func TestDispatcherParksExhaustedEvents(t *testing.T) {
+	operator := data.NewOperatorMem()
+	events := newDaoMem(slog.Default())
+	subs := newService(events, operator, slog.Default())
+	deliveries := 0
+	subs.Subscribe(Subscriber{
+		Handle: func(context.Context, Event) error {
+			deliveries++
+			return errors.New("failure")
+		},
+	})
+	p := &props{Dispatch: dispatch{Batch: 10}, Retry: retry{MaxAttempts: 2}}
+	d := newDispatcher(events, subs, operator, operator, p, slog.Default())
+	ctx := context.Background()
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		_, err := subs.Write(ds, EventSpec{Kind: "wanted", SubjectID: id.New()})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		err = d.drain(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if deliveries != 2 {
+		t.Fatalf("want 2 deliveries, got %v", deliveries)
+	}
+}
+
+func TestDispatcherSweepsDispatchedEvents(t *testing.T) {
+	operator := data.NewOperatorMem()
+	events := newDaoMem(slog.Default())
+	subs := newService(events, operator, slog.Default())
+	p := &props{Dispatch: dispatch{Batch: 1, Retention: time.Hour}}
+	d := newDispatcher(events, subs, operator, operator, p, slog.Default())
+	ctx := context.Background()
+	now := time.Now()
+	stale, fresh, pending := newEventRec(now), newEventRec(now), newEventRec(now)
+	err := operator.Explicit(ctx, func(ds data.Source) error {
+		for _, rec := range []EventRec{stale, fresh, pending} {
+			err := events.Insert(ds, rec)
+			if err != nil {
+				return err
+			}
+		}
+		err := events.MarkDispatched(ds, stale.ID, now.Add(-2*time.Hour))
+		if err != nil {
+			return err
+		}
+		return events.MarkDispatched(ds, fresh.ID, now)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []eventRecDS
+	err = operator.Implicit(ctx, func(ds data.Source) error {
+		got = data.Rows[eventRecDS](data.MustConform[data.SourceMem](ds), eventsMem)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ID != fresh.ID.String() {
+		t.Fatalf("want the stale event swept only, got %v", got)
+	}
+}
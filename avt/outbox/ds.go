@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"database/sql"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Port
+type Repo interface {
+	Insert(data.Source, EventRec) error
+	// bumps the stream counter, which never goes back even if the events get swept
+	NextSeq(data.Source, id.ADT) (int64, error)
+	// ordered by sequence number
+	SelectStream(source data.Source, streamID id.ADT, after int64) ([]EventRec, error)
+	// hides due events from other dispatchers until the lease expires
+	Claim(source data.Source, now time.Time, until time.Time, limit int) ([]EventRec, error)
+	MarkDispatched(data.Source, id.ADT, time.Time) error
+	// parked events stay too
+	MarkFailed(data.Source, EventRec) error
+	DeleteDispatched(source data.Source, before time.Time, limit int) (int64, error)
+}
+
+type eventRecDS struct {
+	ID           string         `db:"id"`
+	Kind         string         `db:"kind"`
+	SubjectID    sql.NullString `db:"subject_id"`
+	StreamID     sql.NullString `db:"stream_id"`
+	Seq          sql.NullInt64  `db:"seq"`
+	Payload      []byte         `db:"payload"`
+	At           time.Time      `db:"created_at"`
+	Attempts     int            `db:"attempts"`
+	NextAt       time.Time      `db:"next_at"`
+	DispatchedAt sql.NullTime   `db:"dispatched_at"`
+	LastError    sql.NullString `db:"last_error"`
+	ParkedAt     sql.NullTime   `db:"parked_at"`
+}
+
+type streamDS struct {
+	StreamID string `db:"stream_id"`
+	Seq      int64  `db:"seq"`
+}
+
+const channel = "outbox"
@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoMem struct {
+	log *slog.Logger
+}
+
+func newDaoMem(l *slog.Logger) *daoMem {
+	name := slog.String("name", "outboxDaoMem")
+	return &daoMem{l.With(name)}
+}
+
+const (
+	eventsMem  = "outbox_events"
+	streamsMem = "outbox_streams"
+)
+
+func (r *daoMem) Insert(source data.Source, rec EventRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.InsertRows(ds, eventsMem, dataFromEventRec(rec))
+	ds.Notify(channel)
+	return nil
+}
+
+func (r *daoMem) NextSeq(source data.Source, streamID id.ADT) (int64, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	var seq int64
+	bumped := data.UpdateRows(ds, streamsMem, func(row *streamDS) bool {
+		if row.StreamID != streamID.String() {
+			return false
+		}
+		row.Seq++
+		seq = row.Seq
+		return true
+	})
+	if bumped == 0 {
+		seq = 1
+		data.InsertRows(ds, streamsMem, streamDS{StreamID: streamID.String(), Seq: seq})
+	}
+	return seq, nil
+}
+
+// the counter hands out the numbers in insertion order
+func (r *daoMem) SelectStream(source data.Source, streamID id.ADT, after int64) ([]EventRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	var dtos []eventRecDS
+	for _, row := range data.Rows[eventRecDS](ds, eventsMem) {
+		if row.StreamID.String == streamID.String() && row.Seq.Int64 > after {
+			dtos = append(dtos, row)
+		}
+	}
+	return dataToEventRecs(dtos)
+}
+
+// the rows keep the insertion order
+func (r *daoMem) Claim(source data.Source, now time.Time, until time.Time, limit int) ([]EventRec, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	var dtos []eventRecDS
+	data.UpdateRows(ds, eventsMem, func(row *eventRecDS) bool {
+		if len(dtos) >= limit || row.DispatchedAt.Valid || row.ParkedAt.Valid || row.NextAt.After(now) {
+			return false
+		}
+		row.NextAt = until
+		dtos = append(dtos, *row)
+		return true
+	})
+	return dataToEventRecs(dtos)
+}
+
+func (r *daoMem) MarkDispatched(source data.Source, eventID id.ADT, at time.Time) error {
+	ds := data.MustConform[data.SourceMem](source)
+	data.UpdateRows(ds, eventsMem, func(row *eventRecDS) bool {
+		if row.ID != eventID.String() {
+			return false
+		}
+		row.DispatchedAt = sql.NullTime{Time: at, Valid: true}
+		row.LastError = sql.NullString{}
+		return true
+	})
+	return nil
+}
+
+func (r *daoMem) MarkFailed(source data.Source, rec EventRec) error {
+	ds := data.MustConform[data.SourceMem](source)
+	dto := dataFromEventRec(rec)
+	data.UpdateRows(ds, eventsMem, func(row *eventRecDS) bool {
+		if row.ID != dto.ID {
+			return false
+		}
+		row.Attempts = dto.Attempts
+		row.NextAt = dto.NextAt
+		row.LastError = dto.LastError
+		row.ParkedAt = dto.ParkedAt
+		return true
+	})
+	return nil
+}
+
+func (r *daoMem) DeleteDispatched(source data.Source, before time.Time, limit int) (int64, error) {
+	ds := data.MustConform[data.SourceMem](source)
+	deleted := data.DeleteRows(ds, eventsMem, func(row eventRecDS) bool {
+		if !row.DispatchedAt.Valid || !row.DispatchedAt.Time.Before(before) {
+			return false
+		}
+		limit--
+		return limit >= 0
+	})
+	return int64(deleted), nil
+}
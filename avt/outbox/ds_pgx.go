@@ -0,0 +1,226 @@
+package outbox
+
+import (
+	"log/slog"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoPgx struct {
+	log *slog.Logger
+}
+
+func newDaoPgx(l *slog.Logger) *daoPgx {
+	name := slog.String("name", "outboxDaoPgx")
+	return &daoPgx{l.With(name)}
+}
+
+func (r *daoPgx) Insert(source data.Source, rec EventRec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	dto := dataFromEventRec(rec)
+	args := pgx.NamedArgs{
+		"id":         dto.ID,
+		"kind":       dto.Kind,
+		"subject_id": dto.SubjectID,
+		"stream_id":  dto.StreamID,
+		"seq":        dto.Seq,
+		"payload":    string(dto.Payload),
+		"created_at": dto.At,
+		"next_at":    dto.NextAt,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, insertEvent, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dto", dto))
+		return err
+	}
+	// delivered on commit only
+	_, err = ds.Conn.Exec(ds.Ctx, notifyEvents, channel)
+	if err != nil {
+		r.log.Error("notification failed", slog.String("channel", channel))
+		return err
+	}
+	r.log.Debug("insertion succeeded", slog.Any("eventID", rec.ID))
+	return nil
+}
+
+func (r *daoPgx) NextSeq(source data.Source, streamID id.ADT) (int64, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		"stream_id": streamID.String(),
+	}
+	var seq int64
+	err := ds.Conn.QueryRow(ds.Ctx, bumpStream, args).Scan(&seq)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("streamID", streamID))
+		return 0, err
+	}
+	return seq, nil
+}
+
+func (r *daoPgx) SelectStream(source data.Source, streamID id.ADT, after int64) ([]EventRec, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		"stream_id": streamID.String(),
+		"seq":       after,
+	}
+	rows, err := ds.Conn.Query(ds.Ctx, selectStream, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("streamID", streamID))
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[eventRecDS])
+	if err != nil {
+		r.log.Error("collection failed", slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	return dataToEventRecs(dtos)
+}
+
+func (r *daoPgx) Claim(source data.Source, now time.Time, until time.Time, limit int) ([]EventRec, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		"now":   now,
+		"until": until,
+		"limit": limit,
+	}
+	rows, err := ds.Conn.Query(ds.Ctx, claimEvents, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Time("now", now))
+		return nil, err
+	}
+	defer rows.Close()
+	dtos, err := pgx.CollectRows(rows, pgx.RowToStructByName[eventRecDS])
+	if err != nil {
+		r.log.Error("collection failed", slog.Any("t", reflect.TypeOf(dtos)))
+		return nil, err
+	}
+	// returning keeps no order
+	slices.SortFunc(dtos, func(a, b eventRecDS) int { return a.At.Compare(b.At) })
+	return dataToEventRecs(dtos)
+}
+
+func (r *daoPgx) MarkDispatched(source data.Source, eventID id.ADT, at time.Time) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		"id":            eventID.String(),
+		"dispatched_at": at,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, markDispatched, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("eventID", eventID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoPgx) MarkFailed(source data.Source, rec EventRec) error {
+	ds := data.MustConform[data.SourcePgx](source)
+	dto := dataFromEventRec(rec)
+	args := pgx.NamedArgs{
+		"id":         dto.ID,
+		"attempts":   dto.Attempts,
+		"next_at":    dto.NextAt,
+		"last_error": dto.LastError,
+		"parked_at":  dto.ParkedAt,
+	}
+	_, err := ds.Conn.Exec(ds.Ctx, markFailed, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("eventID", rec.ID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoPgx) DeleteDispatched(source data.Source, before time.Time, limit int) (int64, error) {
+	ds := data.MustConform[data.SourcePgx](source)
+	args := pgx.NamedArgs{
+		"before": before,
+		"limit":  limit,
+	}
+	ct, err := ds.Conn.Exec(ds.Ctx, deleteDispatched, args)
+	if err != nil {
+		r.log.Error("execution failed", slog.Time("before", before))
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+const (
+	insertEvent = `
+		insert into outbox_events (
+			id, kind, subject_id, stream_id, seq, payload, created_at, attempts, next_at
+		) values (
+			@id, @kind, @subject_id, @stream_id, @seq, @payload, @created_at, 0, @next_at
+		)`
+
+	// the row lock keeps the numbers in commit order
+	bumpStream = `
+		insert into outbox_streams (stream_id, seq)
+		values (@stream_id, 1)
+		on conflict (stream_id) do update
+		set seq = outbox_streams.seq + 1
+		returning seq`
+
+	selectStream = `
+		select
+			id, kind, subject_id, stream_id, seq, payload, created_at,
+			attempts, next_at, dispatched_at, last_error, parked_at
+		from outbox_events
+		where stream_id = @stream_id
+			and seq > @seq
+		order by seq`
+
+	notifyEvents = `
+		select pg_notify($1, '')`
+
+	// concurrent dispatchers skip each other's rows
+	claimEvents = `
+		update outbox_events ev
+		set next_at = @until
+		from (
+			select id
+			from outbox_events
+			where dispatched_at is null
+				and parked_at is null
+				and next_at <= @now
+			order by created_at
+			limit @limit
+			for update skip locked
+		) due
+		where ev.id = due.id
+		returning
+			ev.id, ev.kind, ev.subject_id, ev.stream_id, ev.seq, ev.payload, ev.created_at,
+			ev.attempts, ev.next_at, ev.dispatched_at, ev.last_error, ev.parked_at`
+
+	markDispatched = `
+		update outbox_events
+		set dispatched_at = @dispatched_at,
+			last_error = null
+		where id = @id`
+
+	markFailed = `
+		update outbox_events
+		set attempts = @attempts,
+			next_at = @next_at,
+			last_error = @last_error,
+			parked_at = @parked_at
+		where id = @id`
+
+	// parked events wait for an operator
+	deleteDispatched = `
+		delete from outbox_events
+		where id in (
+			select id
+			from outbox_events
+			where dispatched_at < @before
+			limit @limit
+		)`
+)
@@ -0,0 +1,188 @@
+package outbox
+
+import (
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+)
+
+// Adapter
+type daoSql struct {
+	log *slog.Logger
+}
+
+func newDaoSql(l *slog.Logger) *daoSql {
+	name := slog.String("name", "outboxDaoSql")
+	return &daoSql{l.With(name)}
+}
+
+func (r *daoSql) Insert(source data.Source, rec EventRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := dataFromEventRec(rec)
+	args := data.NamedArgsSql{
+		"id":         dto.ID,
+		"kind":       dto.Kind,
+		"subject_id": dto.SubjectID,
+		"stream_id":  dto.StreamID,
+		"seq":        dto.Seq,
+		"payload":    string(dto.Payload),
+		"created_at": data.TimeSql{V: dto.At},
+		"next_at":    data.TimeSql{V: dto.NextAt},
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, insertEvent, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("dto", dto))
+		return err
+	}
+	ds.Notify(channel)
+	r.log.Debug("insertion succeeded", slog.Any("eventID", rec.ID))
+	return nil
+}
+
+func (r *daoSql) NextSeq(source data.Source, streamID id.ADT) (int64, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"stream_id": streamID.String(),
+	}
+	var seq int64
+	err := ds.Conn.QueryRowContext(ds.Ctx, bumpStream, args.List()...).Scan(&seq)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("streamID", streamID))
+		return 0, err
+	}
+	return seq, nil
+}
+
+func (r *daoSql) SelectStream(source data.Source, streamID id.ADT, after int64) ([]EventRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"stream_id": streamID.String(),
+		"seq":       after,
+	}
+	rows, err := ds.Conn.QueryContext(ds.Ctx, selectStreamSql, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("streamID", streamID))
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, scanEventSql)
+	if err != nil {
+		r.log.Error("collection failed")
+		return nil, err
+	}
+	return dataToEventRecs(dtos)
+}
+
+// writers are serialized already, no row locking needed
+func (r *daoSql) Claim(source data.Source, now time.Time, until time.Time, limit int) ([]EventRec, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"now":   data.TimeSql{V: now},
+		"until": data.TimeSql{V: until},
+		"limit": limit,
+	}
+	rows, err := ds.Conn.QueryContext(ds.Ctx, claimEventsSql, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Time("now", now))
+		return nil, err
+	}
+	dtos, err := data.CollectRowsSql(rows, scanEventSql)
+	if err != nil {
+		r.log.Error("collection failed")
+		return nil, err
+	}
+	slices.SortFunc(dtos, func(a, b eventRecDS) int { return a.At.Compare(b.At) })
+	return dataToEventRecs(dtos)
+}
+
+func (r *daoSql) MarkDispatched(source data.Source, eventID id.ADT, at time.Time) error {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"id":            eventID.String(),
+		"dispatched_at": data.TimeSql{V: at},
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, markDispatched, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("eventID", eventID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) MarkFailed(source data.Source, rec EventRec) error {
+	ds := data.MustConform[data.SourceSql](source)
+	dto := dataFromEventRec(rec)
+	// null until parked
+	var parkedAt any
+	if dto.ParkedAt.Valid {
+		parkedAt = data.TimeSql{V: dto.ParkedAt.Time}
+	}
+	args := data.NamedArgsSql{
+		"id":         dto.ID,
+		"attempts":   dto.Attempts,
+		"next_at":    data.TimeSql{V: dto.NextAt},
+		"last_error": dto.LastError,
+		"parked_at":  parkedAt,
+	}
+	_, err := ds.Conn.ExecContext(ds.Ctx, markFailed, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Any("eventID", rec.ID))
+		return err
+	}
+	return nil
+}
+
+func (r *daoSql) DeleteDispatched(source data.Source, before time.Time, limit int) (int64, error) {
+	ds := data.MustConform[data.SourceSql](source)
+	args := data.NamedArgsSql{
+		"before": data.TimeSql{V: before},
+		"limit":  limit,
+	}
+	res, err := ds.Conn.ExecContext(ds.Ctx, deleteDispatched, args.List()...)
+	if err != nil {
+		r.log.Error("execution failed", slog.Time("before", before))
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// the dispatch marks stay unread
+func scanEventSql(rows *sql.Rows) (dto eventRecDS, err error) {
+	var payload string
+	var at, nextAt data.TimeSql
+	err = rows.Scan(&dto.ID, &dto.Kind, &dto.SubjectID, &dto.StreamID, &dto.Seq, &payload, &at, &dto.Attempts, &nextAt, &dto.LastError)
+	dto.Payload = []byte(payload)
+	dto.At = at.V
+	dto.NextAt = nextAt.V
+	return dto, err
+}
+
+const (
+	claimEventsSql = `
+		update outbox_events
+		set next_at = @until
+		where id in (
+			select id
+			from outbox_events
+			where dispatched_at is null
+				and parked_at is null
+				and next_at <= @now
+			order by created_at
+			limit @limit
+		)
+		returning
+			id, kind, subject_id, stream_id, seq, payload, created_at,
+			attempts, next_at, last_error`
+
+	selectStreamSql = `
+		select
+			id, kind, subject_id, stream_id, seq, payload, created_at,
+			attempts, next_at, last_error
+		from outbox_events
+		where stream_id = @stream_id
+			and seq > @seq
+		order by seq`
+)
@@ -0,0 +1,174 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"orglang/orglang/avt/data"
+	"orglang/orglang/avt/id"
+
+	sqlitedb "orglang/orglang/db/sqlite"
+)
+
+func TestClaimSqlHonorsLease(t *testing.T) {
+	operator := data.NewOperatorSql(newSqliteStub(t))
+	r := newDaoSql(slog.Default())
+	now := time.Now().Truncate(time.Millisecond)
+	first, second := newEventRec(now), newEventRec(now.Add(time.Millisecond))
+	explicit(t, operator, func(ds data.Source) error {
+		return r.Insert(ds, second)
+	})
+	explicit(t, operator, func(ds data.Source) error {
+		return r.Insert(ds, first)
+	})
+	until := now.Add(time.Minute)
+	var recs []EventRec
+	claim := func(now, until time.Time, limit int) {
+		explicit(t, operator, func(ds data.Source) (err error) {
+			recs, err = r.Claim(ds, now, until, limit)
+			return err
+		})
+	}
+	claim(now.Add(time.Second), until, 10)
+	if len(recs) != 2 || recs[0].ID != first.ID {
+		t.Fatalf("want both events oldest first, got %v", recs)
+	}
+	claim(now.Add(time.Second), until, 10)
+	if len(recs) != 0 {
+		t.Fatalf("want no events within the lease, got %v", recs)
+	}
+	// the dispatcher died without marking them
+	claim(until, until.Add(time.Minute), 1)
+	if len(recs) != 1 || recs[0].ID != first.ID {
+		t.Fatalf("want the oldest event after the lease, got %v", recs)
+	}
+}
+
+func TestClaimSqlSkipsParkedAndDispatched(t *testing.T) {
+	operator := data.NewOperatorSql(newSqliteStub(t))
+	r := newDaoSql(slog.Default())
+	now := time.Now()
+	parked, dispatched := newEventRec(now), newEventRec(now)
+	parked.Attempts = 3
+	parked.LastError = "failure"
+	parked.ParkedAt = now
+	explicit(t, operator, func(ds data.Source) error {
+		err := r.Insert(ds, parked)
+		if err != nil {
+			return err
+		}
+		err = r.Insert(ds, dispatched)
+		if err != nil {
+			return err
+		}
+		err = r.MarkFailed(ds, parked)
+		if err != nil {
+			return err
+		}
+		return r.MarkDispatched(ds, dispatched.ID, now)
+	})
+	var recs []EventRec
+	explicit(t, operator, func(ds data.Source) (err error) {
+		recs, err = r.Claim(ds, now.Add(time.Hour), now.Add(2*time.Hour), 10)
+		return err
+	})
+	if len(recs) != 0 {
+		t.Fatalf("want no events, got %v", recs)
+	}
+	var deleted int64
+	explicit(t, operator, func(ds data.Source) (err error) {
+		deleted, err = r.DeleteDispatched(ds, now.Add(time.Second), 10)
+		return err
+	})
+	if deleted != 1 {
+		t.Fatalf("want the dispatched event deleted only, got %v", deleted)
+	}
+}
+
+func TestReplaySqlOutlivesSweeps(t *testing.T) {
+	operator := data.NewOperatorSql(newSqliteStub(t))
+	s := newService(newDaoSql(slog.Default()), operator, slog.Default())
+	streamID := id.New()
+	write := func() Event {
+		var ev Event
+		explicit(t, operator, func(ds data.Source) (err error) {
+			ev, err = s.Write(ds, EventSpec{Kind: "step.taken", StreamID: streamID})
+			return err
+		})
+		return ev
+	}
+	first, second := write(), write()
+	if first.Seq != 1 || second.Seq != 2 {
+		t.Fatalf("want consecutive numbers, got %v and %v", first.Seq, second.Seq)
+	}
+	events, err := s.Replay(context.Background(), ReplaySpec{StreamID: streamID, Seq: first.Seq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != second.ID {
+		t.Fatalf("want the second event only, got %v", events)
+	}
+	explicit(t, operator, func(ds data.Source) error {
+		for _, ev := range []Event{first, second} {
+			err := s.events.MarkDispatched(ds, ev.ID, time.Now().Add(-time.Hour))
+			if err != nil {
+				return err
+			}
+		}
+		_, err := s.events.DeleteDispatched(ds, time.Now(), 10)
+		return err
+	})
+	// a resuming watcher must not mistake new events for seen ones
+	third := write()
+	if third.Seq != 3 {
+		t.Fatalf("want the numbering to go on, got %v", third.Seq)
+	}
+}
+
+func explicit(t *testing.T, operator data.Operator, op func(data.Source) error) {
+	t.Helper()
+	err := operator.Explicit(context.Background(), op)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newEventRec(at time.Time) EventRec {
+	return EventRec{
+		ID:        id.New(),
+		Kind:      "role.created",
+		SubjectID: id.New(),
+		Payload:   []byte(`{}`),
+		At:        at,
+		NextAt:    at,
+	}
+}
+
+func newSqliteStub(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	names, err := fs.Glob(sqlitedb.Migrations, "migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		text, err := fs.ReadFile(sqlitedb.Migrations, name)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		_, err = db.Exec(string(text))
+		if err != nil {
+			t.Fatal(name, err)
+		}
+	}
+	return db
+}
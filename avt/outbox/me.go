@@ -0,0 +1,17 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// webhook request body
+type EventME struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	SubjectID string          `json:"subject_id,omitempty"`
+	StreamID  string          `json:"stream_id,omitempty"`
+	Seq       int64           `json:"seq,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	At        time.Time       `json:"at"`
+}
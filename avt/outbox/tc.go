@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"database/sql"
+
+	"orglang/orglang/avt/id"
+)
+
+func dataFromEventRec(rec EventRec) eventRecDS {
+	return eventRecDS{
+		ID:        rec.ID.String(),
+		Kind:      string(rec.Kind),
+		SubjectID: sql.NullString{String: rec.SubjectID.String(), Valid: !rec.SubjectID.IsEmpty()},
+		StreamID:  sql.NullString{String: rec.StreamID.String(), Valid: !rec.StreamID.IsEmpty()},
+		Seq:       sql.NullInt64{Int64: rec.Seq, Valid: !rec.StreamID.IsEmpty()},
+		Payload:   rec.Payload,
+		At:        rec.At,
+		Attempts:  rec.Attempts,
+		NextAt:    rec.NextAt,
+		LastError: sql.NullString{String: rec.LastError, Valid: rec.LastError != ""},
+		ParkedAt:  sql.NullTime{Time: rec.ParkedAt, Valid: !rec.ParkedAt.IsZero()},
+	}
+}
+
+func dataToEventRec(dto eventRecDS) (EventRec, error) {
+	eventID, err := id.ConvertFromString(dto.ID)
+	if err != nil {
+		return EventRec{}, err
+	}
+	subjectID := id.Empty()
+	if dto.SubjectID.Valid {
+		subjectID, err = id.ConvertFromString(dto.SubjectID.String)
+		if err != nil {
+			return EventRec{}, err
+		}
+	}
+	streamID := id.Empty()
+	if dto.StreamID.Valid {
+		streamID, err = id.ConvertFromString(dto.StreamID.String)
+		if err != nil {
+			return EventRec{}, err
+		}
+	}
+	return EventRec{
+		ID:        eventID,
+		Kind:      Kind(dto.Kind),
+		SubjectID: subjectID,
+		StreamID:  streamID,
+		Seq:       dto.Seq.Int64,
+		Payload:   dto.Payload,
+		At:        dto.At,
+		Attempts:  dto.Attempts,
+		NextAt:    dto.NextAt,
+		LastError: dto.LastError.String,
+		ParkedAt:  dto.ParkedAt.Time,
+	}, nil
+}
+
+func dataToEventRecs(dtos []eventRecDS) ([]EventRec, error) {
+	recs := make([]EventRec, 0, len(dtos))
+	for _, dto := range dtos {
+		rec, err := dataToEventRec(dto)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+func msgFromEvent(ev Event) EventME {
+	dto := EventME{
+		ID:      ev.ID.String(),
+		Kind:    string(ev.Kind),
+		Payload: ev.Payload,
+		At:      ev.At,
+	}
+	if !ev.SubjectID.IsEmpty() {
+		dto.SubjectID = ev.SubjectID.String()
+	}
+	if !ev.StreamID.IsEmpty() {
+		dto.StreamID = ev.StreamID.String()
+		dto.Seq = ev.Seq
+	}
+	return dto
+}
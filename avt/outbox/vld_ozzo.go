@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+func (p props) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Dispatch),
+		validation.Field(&p.Retry),
+		validation.Field(&p.Webhooks),
+	)
+}
+
+func (p dispatch) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Batch, validation.Min(1)),
+		validation.Field(&p.Lease, validation.Required),
+		validation.Field(&p.Retention, validation.Min(0)),
+	)
+}
+
+func (p retry) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.MaxAttempts, validation.Min(0)),
+		validation.Field(&p.MinDelay, validation.Min(0)),
+		validation.Field(&p.MaxDelay, validation.Min(p.MinDelay)),
+	)
+}
+
+func (p webhook) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Url, validation.Required, is.URL),
+		validation.Field(&p.Timeout, validation.Min(0)),
+	)
+}
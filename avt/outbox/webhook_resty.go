@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-resty/resty/v2"
+
+	"orglang/orglang/avt/msg"
+)
+
+// Adapter
+type webhookResty struct {
+	resty *resty.Client
+	url   string
+	kinds []Kind
+}
+
+func newWebhookResty(p webhook) *webhookResty {
+	r := resty.New().SetTimeout(p.Timeout)
+	kinds := make([]Kind, 0, len(p.Kinds))
+	for _, kind := range p.Kinds {
+		kinds = append(kinds, Kind(kind))
+	}
+	return &webhookResty{r, p.Url, kinds}
+}
+
+func (w *webhookResty) accepts(kind Kind) bool {
+	return len(w.kinds) == 0 || slices.Contains(w.kinds, kind)
+}
+
+// receivers deduplicate by the event id
+func (w *webhookResty) Deliver(ctx context.Context, ev Event) error {
+	req := msgFromEvent(ev)
+	resp, err := w.resty.R().
+		SetContext(ctx).
+		SetHeader(msg.HeaderIdempotencyKey, req.ID).
+		SetBody(&req).
+		Post(w.url)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("webhook %v received: %v", w.url, resp.Status())
+	}
+	return nil
+}
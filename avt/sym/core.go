@@ -35,6 +35,24 @@ func (s ADT) NS() ADT {
 	return ADT(sym[0:strings.LastIndex(sym, sep)])
 }
 
+// the symbol itself or its descendant
+func (s ADT) Within(ns ADT) bool {
+	return s == ns || strings.HasPrefix(string(s), string(ns)+sep)
+}
+
+// path below the namespace, blank for the namespace itself
+func (s ADT) Rel(ns ADT) ADT {
+	return ADT(strings.TrimPrefix(strings.TrimPrefix(string(s), string(ns)), sep))
+}
+
+// blank path keeps the symbol as is
+func (s ADT) Join(rel ADT) ADT {
+	if rel == Blank {
+		return s
+	}
+	return s.New(string(rel))
+}
+
 func ConvertToSame(s ADT) ADT {
 	return s
 }
@@ -0,0 +1,56 @@
+package sym
+
+import (
+	"testing"
+)
+
+func TestWithin(t *testing.T) {
+	tests := []struct {
+		s, ns ADT
+		want  bool
+	}{
+		{"acme.sales", "acme.sales", true},
+		{"acme.sales.lead", "acme.sales", true},
+		{"acme.salesforce", "acme.sales", false},
+		{"acme", "acme.sales", false},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Within(tt.ns); got != tt.want {
+			t.Errorf("%v within %v: want %v, got %v", tt.s, tt.ns, tt.want, got)
+		}
+	}
+}
+
+func TestRel(t *testing.T) {
+	tests := []struct {
+		s, ns, want ADT
+	}{
+		{"acme.sales", "acme.sales", Blank},
+		{"acme.sales.lead", "acme.sales", "lead"},
+		{"acme.sales.lead.deal", "acme", "sales.lead.deal"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Rel(tt.ns); got != tt.want {
+			t.Errorf("%v rel %v: want %q, got %q", tt.s, tt.ns, tt.want, got)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		s, rel, want ADT
+	}{
+		{"acme.crm", Blank, "acme.crm"},
+		{"acme.crm", "lead", "acme.crm.lead"},
+		{"acme.crm", "lead.deal", "acme.crm.lead.deal"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Join(tt.rel); got != tt.want {
+			t.Errorf("%v join %v: want %q, got %q", tt.s, tt.rel, tt.want, got)
+		}
+	}
+	// moving keeps the path below the namespace
+	if got := ADT("acme.crm").Join(ADT("acme.sales.lead").Rel("acme.sales")); got != "acme.crm.lead" {
+		t.Errorf("want acme.crm.lead, got %v", got)
+	}
+}
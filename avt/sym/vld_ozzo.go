@@ -16,3 +16,11 @@ var Required = append(Optional, validation.Required)
 func ReqiredWhen(condition bool) []validation.Rule {
 	return append(Optional, validation.Required.When(condition))
 }
+
+// dot separated simple names
+var OptionalQN = []validation.Rule{
+	validation.Length(1, 512),
+	validation.Match(regexp.MustCompile(`^[0-9A-Za-z_-]+(\.[0-9A-Za-z_-]+)*$`)),
+}
+
+var RequiredQN = append(OptionalQN, validation.Required)
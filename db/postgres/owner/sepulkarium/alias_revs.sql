@@ -0,0 +1,7 @@
+-- закрытые ревизии хранят прежние имена,
+-- уникальны только текущие
+ALTER TABLE aliases DROP CONSTRAINT aliases_sym_key;
+
+CREATE UNIQUE INDEX aliases_sym_current_idx ON aliases (sym) WHERE rev_to = 9223372036854775807;
+
+CREATE INDEX aliases_id_idx ON aliases (id);
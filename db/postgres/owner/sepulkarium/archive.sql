@@ -0,0 +1,12 @@
+-- снимки закрытых процессов, вынесенные из живых таблиц
+CREATE TABLE proc_archive (
+	proc_id varchar(36) PRIMARY KEY,
+	pool_id varchar(36),
+	bnds jsonb,
+	liabs jsonb,
+	journal jsonb,
+	archived_at timestamptz
+);
+
+-- процесс без записей в журнале архивируется по времени создания
+ALTER TABLE pool_liabs ADD COLUMN created_at timestamptz DEFAULT now();
@@ -0,0 +1,2 @@
+-- входящие находят завершение задачи по ключу, не зная пула
+CREATE INDEX pool_idems_key_idx ON pool_idems (idem_key);
@@ -0,0 +1,9 @@
+-- результаты запросов с ключами идемпотентности
+CREATE TABLE pool_idems (
+	pool_id varchar(36),
+	idem_key varchar(64),
+	kind smallint,
+	proc_id varchar(36),
+	rev integer,
+	PRIMARY KEY (pool_id, idem_key)
+);
@@ -0,0 +1,6 @@
+-- задачи, взятые людьми в работу
+CREATE TABLE inbox_claims (
+	chnl_id varchar(36) PRIMARY KEY,
+	agent_qn varchar(512),
+	claimed_at timestamptz
+);
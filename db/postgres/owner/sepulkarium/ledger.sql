@@ -0,0 +1,12 @@
+-- вклад пулов и агентов по завершенным шагам
+CREATE TABLE ledger_entries (
+	pool_id varchar(36),
+	proc_id varchar(36),
+	chnl_id varchar(36),
+	rev integer,
+	agent_qn varchar(256),
+	weight bigint,
+	created_at timestamptz
+);
+
+CREATE INDEX ledger_entries_pool_idx ON ledger_entries (pool_id, created_at);
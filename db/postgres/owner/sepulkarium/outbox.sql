@@ -0,0 +1,20 @@
+-- доменные события, записанные в транзакции изменения
+-- доставляются диспетчером хотя бы один раз
+CREATE TABLE outbox_events (
+	id varchar(36) PRIMARY KEY,
+	kind varchar(64),
+	subject_id varchar(36),
+	payload jsonb,
+	created_at timestamptz,
+	attempts integer,
+	next_at timestamptz,
+	dispatched_at timestamptz,
+	last_error text,
+	parked_at timestamptz
+);
+
+-- отложенные после исчерпания попыток ждут оператора
+CREATE INDEX outbox_events_pending_idx ON outbox_events (next_at) WHERE dispatched_at IS NULL AND parked_at IS NULL;
+
+-- доставленные удаляются по истечении срока хранения
+CREATE INDEX outbox_events_dispatched_idx ON outbox_events (dispatched_at) WHERE dispatched_at IS NOT NULL;
@@ -0,0 +1,18 @@
+-- переопределения квот пула, нули снимают ограничение
+CREATE TABLE pool_quotas (
+	pool_id varchar(36) PRIMARY KEY,
+	live_procs integer,
+	spawn_limit integer,
+	spawn_interval_ms bigint,
+	step_limit integer,
+	step_interval_ms bigint
+);
+
+-- счетчики фиксированных окон
+CREATE TABLE pool_counters (
+	pool_id varchar(36),
+	kind smallint,
+	window_start timestamptz,
+	hits integer,
+	PRIMARY KEY (pool_id, kind, window_start)
+);
@@ -0,0 +1,17 @@
+-- компенсации завершенных шагов, выполняются в обратном порядке
+CREATE TABLE proc_comps (
+	pool_id varchar(36),
+	proc_id varchar(36),
+	rev integer,
+	spec jsonb
+);
+
+-- журнал шагов, отмен и компенсаций
+CREATE TABLE proc_journal (
+	pool_id varchar(36),
+	proc_id varchar(36),
+	kind varchar(32),
+	rev integer,
+	detail text,
+	created_at timestamptz
+);
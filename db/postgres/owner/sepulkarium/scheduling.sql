@@ -0,0 +1,12 @@
+-- приоритет процесса при выборке следующего шага
+ALTER TABLE pool_liabs ADD COLUMN priority smallint DEFAULT 0;
+
+-- время ожидания шага учитывается при старении приоритета
+ALTER TABLE proc_steps ADD COLUMN created_at timestamptz DEFAULT now();
+
+-- процессы, выданные опрашивающим исполнителям,
+-- по истечении аренды процесс выдается снова
+CREATE TABLE poll_claims (
+	proc_id varchar(36) PRIMARY KEY,
+	claimed_until timestamptz
+);
@@ -0,0 +1,10 @@
+package sqlite
+
+import (
+	"embed"
+)
+
+// applied in lexical order of the file names
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
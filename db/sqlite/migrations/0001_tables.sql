@@ -0,0 +1,201 @@
+-- ltree колонки хранятся как текст с точками,
+-- поиск по поддереву через sym = @sym or sym like @sym || '.%'
+-- jsonb колонки хранятся как текст и разбираются функциями json1
+
+CREATE TABLE role_roots (
+	role_id text PRIMARY KEY,
+	title text,
+	rev integer
+);
+
+CREATE TABLE role_states (
+	role_id text,
+	state_id text,
+	rev_from integer,
+	rev_to integer
+);
+
+CREATE TABLE role_subs (
+	role_id text,
+	role_fqn text,
+	rev_from integer,
+	rev_to integer
+);
+
+CREATE TABLE sig_roots (
+	sig_id text PRIMARY KEY,
+	title text,
+	rev integer
+);
+
+CREATE TABLE sig_pes (
+	sig_id text,
+	chnl_key text,
+	role_fqn text,
+	rev_from integer,
+	rev_to integer
+);
+
+CREATE TABLE sig_ces (
+	sig_id text,
+	chnl_key text,
+	role_fqn text,
+	rev_from integer,
+	rev_to integer
+);
+
+CREATE TABLE sig_subs (
+	sig_id text,
+	sig_fqn text,
+	rev_from integer,
+	rev_to integer
+);
+
+CREATE TABLE pool_roots (
+	pool_id text PRIMARY KEY,
+	title text,
+	proc_id text,
+	sup_pool_id text,
+	rev integer
+);
+
+CREATE TABLE pool_caps (
+	pool_id text,
+	sig_id text,
+	rev integer
+);
+
+CREATE TABLE pool_deps (
+	pool_id text,
+	sig_id text,
+	rev integer
+);
+
+CREATE TABLE pool_liabs (
+	proc_id text,
+	pool_id text,
+	rev integer,
+	priority integer DEFAULT 0
+);
+
+CREATE INDEX pool_liabs_proc_idx ON pool_liabs (proc_id);
+
+CREATE TABLE proc_bnds (
+	proc_id text,
+	chnl_ph text,
+	chnl_id text,
+	state_id text,
+	rev integer
+);
+
+CREATE INDEX proc_bnds_proc_idx ON proc_bnds (proc_id);
+
+CREATE TABLE proc_steps (
+	id text,
+	proc_id text,
+	chnl_id text,
+	kind integer,
+	spec text,
+	rev integer,
+	created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE pool_idems (
+	pool_id text,
+	idem_key text,
+	kind integer,
+	proc_id text,
+	rev integer,
+	PRIMARY KEY (pool_id, idem_key)
+);
+
+CREATE TABLE pool_quotas (
+	pool_id text PRIMARY KEY,
+	live_procs integer,
+	spawn_limit integer,
+	spawn_interval_ms integer,
+	step_limit integer,
+	step_interval_ms integer
+);
+
+CREATE TABLE pool_counters (
+	pool_id text,
+	kind integer,
+	window_start timestamp,
+	hits integer,
+	PRIMARY KEY (pool_id, kind, window_start)
+);
+
+CREATE TABLE inbox_claims (
+	chnl_id text PRIMARY KEY,
+	agent_qn text,
+	claimed_at timestamp
+);
+
+CREATE TABLE proc_comps (
+	pool_id text,
+	proc_id text,
+	rev integer,
+	spec text
+);
+
+CREATE TABLE proc_journal (
+	pool_id text,
+	proc_id text,
+	kind text,
+	rev integer,
+	detail text,
+	created_at timestamp
+);
+
+CREATE TABLE proc_archive (
+	proc_id text PRIMARY KEY,
+	pool_id text,
+	bnds text,
+	liabs text,
+	journal text,
+	archived_at timestamp
+);
+
+CREATE TABLE ledger_entries (
+	pool_id text,
+	proc_id text,
+	chnl_id text,
+	rev integer,
+	agent_qn text,
+	weight integer,
+	created_at timestamp
+);
+
+CREATE INDEX ledger_entries_pool_idx ON ledger_entries (pool_id, created_at);
+
+CREATE TABLE pool_sups (
+	pool_id text,
+	sup_pool_id text,
+	rev integer
+);
+
+CREATE TABLE states (
+	id text,
+	from_id text,
+	kind integer,
+	spec text
+);
+
+CREATE INDEX states_from_idx ON states (from_id);
+
+CREATE TABLE steps (
+	id text,
+	kind integer,
+	pid text,
+	vid text,
+	spec text
+);
+
+CREATE TABLE aliases (
+	id text,
+	sym text UNIQUE,
+	rev_from integer,
+	rev_to integer,
+	kind integer
+);
@@ -0,0 +1,13 @@
+CREATE TABLE outbox_events (
+	id text PRIMARY KEY,
+	kind text,
+	subject_id text,
+	payload text,
+	created_at timestamp,
+	attempts integer,
+	next_at timestamp,
+	dispatched_at timestamp,
+	last_error text
+);
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (next_at) WHERE dispatched_at IS NULL;
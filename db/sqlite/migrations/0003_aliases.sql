@@ -0,0 +1,19 @@
+-- закрытые ревизии хранят прежние имена,
+-- уникальны только текущие
+CREATE TABLE aliases_revs (
+	id text,
+	sym text,
+	rev_from integer,
+	rev_to integer,
+	kind integer
+);
+
+INSERT INTO aliases_revs SELECT id, sym, rev_from, rev_to, kind FROM aliases;
+
+DROP TABLE aliases;
+
+ALTER TABLE aliases_revs RENAME TO aliases;
+
+CREATE UNIQUE INDEX aliases_sym_current_idx ON aliases (sym) WHERE rev_to = 9223372036854775807;
+
+CREATE INDEX aliases_id_idx ON aliases (id);
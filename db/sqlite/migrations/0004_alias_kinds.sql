@@ -0,0 +1,10 @@
+-- имена без вида заведены ролями и декларациями процессов,
+-- 1 соответствует role, 2 соответствует proc-dec
+UPDATE aliases SET kind = 1 WHERE kind IS NULL AND id IN (SELECT role_id FROM role_roots);
+
+UPDATE aliases SET kind = 2 WHERE kind IS NULL AND id IN (SELECT sig_id FROM sig_roots);
+
+-- текущие имена уникальны в пределах вида сущности
+DROP INDEX aliases_sym_current_idx;
+
+CREATE UNIQUE INDEX aliases_kind_sym_current_idx ON aliases (kind, sym) WHERE rev_to = 9223372036854775807;
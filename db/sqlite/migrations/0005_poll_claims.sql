@@ -0,0 +1,6 @@
+-- процессы, выданные опрашивающим исполнителям,
+-- по истечении аренды процесс выдается снова
+CREATE TABLE poll_claims (
+	proc_id text PRIMARY KEY,
+	claimed_until timestamp
+);
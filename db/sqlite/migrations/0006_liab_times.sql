@@ -0,0 +1,4 @@
+-- процесс без записей в журнале архивируется по времени создания
+ALTER TABLE pool_liabs ADD COLUMN created_at timestamp;
+
+UPDATE pool_liabs SET created_at = strftime('%Y-%m-%d %H:%M:%f000000Z', 'now');
@@ -0,0 +1,9 @@
+-- отложенные после исчерпания попыток ждут оператора
+ALTER TABLE outbox_events ADD COLUMN parked_at timestamp;
+
+DROP INDEX outbox_events_pending_idx;
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (next_at) WHERE dispatched_at IS NULL AND parked_at IS NULL;
+
+-- доставленные удаляются по истечении срока хранения
+CREATE INDEX outbox_events_dispatched_idx ON outbox_events (dispatched_at) WHERE dispatched_at IS NOT NULL;
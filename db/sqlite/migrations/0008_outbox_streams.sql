@@ -0,0 +1,12 @@
+-- события потока нумеруются подряд для возобновления наблюдения
+ALTER TABLE outbox_events ADD COLUMN stream_id text;
+
+ALTER TABLE outbox_events ADD COLUMN seq integer;
+
+CREATE INDEX outbox_events_stream_idx ON outbox_events (stream_id, seq) WHERE stream_id IS NOT NULL;
+
+-- счетчик не откатывается при удалении доставленных событий
+CREATE TABLE outbox_streams (
+	stream_id text PRIMARY KEY,
+	seq integer
+);
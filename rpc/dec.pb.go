@@ -0,0 +1,495 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: dec.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateDecRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Qn    string                 `protobuf:"bytes,1,opt,name=qn,proto3" json:"qn,omitempty"`
+	// provision endpoint
+	X *ChnlBnd `protobuf:"bytes,2,opt,name=x,proto3" json:"x,omitempty"`
+	// reception endpoints
+	Ys            []*ChnlBnd `protobuf:"bytes,3,rep,name=ys,proto3" json:"ys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDecRequest) Reset() {
+	*x = CreateDecRequest{}
+	mi := &file_dec_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDecRequest) ProtoMessage() {}
+
+func (x *CreateDecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dec_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDecRequest.ProtoReflect.Descriptor instead.
+func (*CreateDecRequest) Descriptor() ([]byte, []int) {
+	return file_dec_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateDecRequest) GetQn() string {
+	if x != nil {
+		return x.Qn
+	}
+	return ""
+}
+
+func (x *CreateDecRequest) GetX() *ChnlBnd {
+	if x != nil {
+		return x.X
+	}
+	return nil
+}
+
+func (x *CreateDecRequest) GetYs() []*ChnlBnd {
+	if x != nil {
+		return x.Ys
+	}
+	return nil
+}
+
+type GetDecRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDecRequest) Reset() {
+	*x = GetDecRequest{}
+	mi := &file_dec_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecRequest) ProtoMessage() {}
+
+func (x *GetDecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dec_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecRequest.ProtoReflect.Descriptor instead.
+func (*GetDecRequest) Descriptor() ([]byte, []int) {
+	return file_dec_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDecRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListDecsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDecsRequest) Reset() {
+	*x = ListDecsRequest{}
+	mi := &file_dec_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDecsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecsRequest) ProtoMessage() {}
+
+func (x *ListDecsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dec_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecsRequest.ProtoReflect.Descriptor instead.
+func (*ListDecsRequest) Descriptor() ([]byte, []int) {
+	return file_dec_proto_rawDescGZIP(), []int{2}
+}
+
+type ListDecsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refs          []*DecRef              `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDecsResponse) Reset() {
+	*x = ListDecsResponse{}
+	mi := &file_dec_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDecsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecsResponse) ProtoMessage() {}
+
+func (x *ListDecsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dec_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecsResponse.ProtoReflect.Descriptor instead.
+func (*ListDecsResponse) Descriptor() ([]byte, []int) {
+	return file_dec_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListDecsResponse) GetRefs() []*DecRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type ChnlBnd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChnlPh        string                 `protobuf:"bytes,1,opt,name=chnl_ph,json=chnlPh,proto3" json:"chnl_ph,omitempty"`
+	TypeQn        string                 `protobuf:"bytes,2,opt,name=type_qn,json=typeQn,proto3" json:"type_qn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChnlBnd) Reset() {
+	*x = ChnlBnd{}
+	mi := &file_dec_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChnlBnd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChnlBnd) ProtoMessage() {}
+
+func (x *ChnlBnd) ProtoReflect() protoreflect.Message {
+	mi := &file_dec_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChnlBnd.ProtoReflect.Descriptor instead.
+func (*ChnlBnd) Descriptor() ([]byte, []int) {
+	return file_dec_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChnlBnd) GetChnlPh() string {
+	if x != nil {
+		return x.ChnlPh
+	}
+	return ""
+}
+
+func (x *ChnlBnd) GetTypeQn() string {
+	if x != nil {
+		return x.TypeQn
+	}
+	return ""
+}
+
+type DecRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rev           int64                  `protobuf:"varint,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecRef) Reset() {
+	*x = DecRef{}
+	mi := &file_dec_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecRef) ProtoMessage() {}
+
+func (x *DecRef) ProtoReflect() protoreflect.Message {
+	mi := &file_dec_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecRef.ProtoReflect.Descriptor instead.
+func (*DecRef) Descriptor() ([]byte, []int) {
+	return file_dec_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DecRef) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DecRef) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *DecRef) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type DecSnap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rev           int64                  `protobuf:"varint,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	X             *ChnlBnd               `protobuf:"bytes,4,opt,name=x,proto3" json:"x,omitempty"`
+	Ys            []*ChnlBnd             `protobuf:"bytes,5,rep,name=ys,proto3" json:"ys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecSnap) Reset() {
+	*x = DecSnap{}
+	mi := &file_dec_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecSnap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecSnap) ProtoMessage() {}
+
+func (x *DecSnap) ProtoReflect() protoreflect.Message {
+	mi := &file_dec_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecSnap.ProtoReflect.Descriptor instead.
+func (*DecSnap) Descriptor() ([]byte, []int) {
+	return file_dec_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DecSnap) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DecSnap) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *DecSnap) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DecSnap) GetX() *ChnlBnd {
+	if x != nil {
+		return x.X
+	}
+	return nil
+}
+
+func (x *DecSnap) GetYs() []*ChnlBnd {
+	if x != nil {
+		return x.Ys
+	}
+	return nil
+}
+
+var File_dec_proto protoreflect.FileDescriptor
+
+const file_dec_proto_rawDesc = "" +
+	"\n" +
+	"\tdec.proto\x12\aorglang\"d\n" +
+	"\x10CreateDecRequest\x12\x0e\n" +
+	"\x02qn\x18\x01 \x01(\tR\x02qn\x12\x1e\n" +
+	"\x01x\x18\x02 \x01(\v2\x10.orglang.ChnlBndR\x01x\x12 \n" +
+	"\x02ys\x18\x03 \x03(\v2\x10.orglang.ChnlBndR\x02ys\"\x1f\n" +
+	"\rGetDecRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x11\n" +
+	"\x0fListDecsRequest\"7\n" +
+	"\x10ListDecsResponse\x12#\n" +
+	"\x04refs\x18\x01 \x03(\v2\x0f.orglang.DecRefR\x04refs\";\n" +
+	"\aChnlBnd\x12\x17\n" +
+	"\achnl_ph\x18\x01 \x01(\tR\x06chnlPh\x12\x17\n" +
+	"\atype_qn\x18\x02 \x01(\tR\x06typeQn\"@\n" +
+	"\x06DecRef\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\x03R\x03rev\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"\x83\x01\n" +
+	"\aDecSnap\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\x03R\x03rev\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1e\n" +
+	"\x01x\x18\x04 \x01(\v2\x10.orglang.ChnlBndR\x01x\x12 \n" +
+	"\x02ys\x18\x05 \x03(\v2\x10.orglang.ChnlBndR\x02ys2\xbb\x01\n" +
+	"\n" +
+	"DecService\x128\n" +
+	"\tCreateDec\x12\x19.orglang.CreateDecRequest\x1a\x10.orglang.DecSnap\x122\n" +
+	"\x06GetDec\x12\x16.orglang.GetDecRequest\x1a\x10.orglang.DecSnap\x12?\n" +
+	"\bListDecs\x12\x18.orglang.ListDecsRequest\x1a\x19.orglang.ListDecsResponseB(\n" +
+	"\x0forg.orglang.rpcP\x01Z\x13orglang/orglang/rpcb\x06proto3"
+
+var (
+	file_dec_proto_rawDescOnce sync.Once
+	file_dec_proto_rawDescData []byte
+)
+
+func file_dec_proto_rawDescGZIP() []byte {
+	file_dec_proto_rawDescOnce.Do(func() {
+		file_dec_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dec_proto_rawDesc), len(file_dec_proto_rawDesc)))
+	})
+	return file_dec_proto_rawDescData
+}
+
+var file_dec_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_dec_proto_goTypes = []any{
+	(*CreateDecRequest)(nil), // 0: orglang.CreateDecRequest
+	(*GetDecRequest)(nil),    // 1: orglang.GetDecRequest
+	(*ListDecsRequest)(nil),  // 2: orglang.ListDecsRequest
+	(*ListDecsResponse)(nil), // 3: orglang.ListDecsResponse
+	(*ChnlBnd)(nil),          // 4: orglang.ChnlBnd
+	(*DecRef)(nil),           // 5: orglang.DecRef
+	(*DecSnap)(nil),          // 6: orglang.DecSnap
+}
+var file_dec_proto_depIdxs = []int32{
+	4, // 0: orglang.CreateDecRequest.x:type_name -> orglang.ChnlBnd
+	4, // 1: orglang.CreateDecRequest.ys:type_name -> orglang.ChnlBnd
+	5, // 2: orglang.ListDecsResponse.refs:type_name -> orglang.DecRef
+	4, // 3: orglang.DecSnap.x:type_name -> orglang.ChnlBnd
+	4, // 4: orglang.DecSnap.ys:type_name -> orglang.ChnlBnd
+	0, // 5: orglang.DecService.CreateDec:input_type -> orglang.CreateDecRequest
+	1, // 6: orglang.DecService.GetDec:input_type -> orglang.GetDecRequest
+	2, // 7: orglang.DecService.ListDecs:input_type -> orglang.ListDecsRequest
+	6, // 8: orglang.DecService.CreateDec:output_type -> orglang.DecSnap
+	6, // 9: orglang.DecService.GetDec:output_type -> orglang.DecSnap
+	3, // 10: orglang.DecService.ListDecs:output_type -> orglang.ListDecsResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_dec_proto_init() }
+func file_dec_proto_init() {
+	if File_dec_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dec_proto_rawDesc), len(file_dec_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dec_proto_goTypes,
+		DependencyIndexes: file_dec_proto_depIdxs,
+		MessageInfos:      file_dec_proto_msgTypes,
+	}.Build()
+	File_dec_proto = out.File
+	file_dec_proto_goTypes = nil
+	file_dec_proto_depIdxs = nil
+}
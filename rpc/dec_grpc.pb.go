@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: dec.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DecService_CreateDec_FullMethodName = "/orglang.DecService/CreateDec"
+	DecService_GetDec_FullMethodName    = "/orglang.DecService/GetDec"
+	DecService_ListDecs_FullMethodName  = "/orglang.DecService/ListDecs"
+)
+
+// DecServiceClient is the client API for DecService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// process declarations, see aat/proc/dec
+type DecServiceClient interface {
+	CreateDec(ctx context.Context, in *CreateDecRequest, opts ...grpc.CallOption) (*DecSnap, error)
+	GetDec(ctx context.Context, in *GetDecRequest, opts ...grpc.CallOption) (*DecSnap, error)
+	ListDecs(ctx context.Context, in *ListDecsRequest, opts ...grpc.CallOption) (*ListDecsResponse, error)
+}
+
+type decServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDecServiceClient(cc grpc.ClientConnInterface) DecServiceClient {
+	return &decServiceClient{cc}
+}
+
+func (c *decServiceClient) CreateDec(ctx context.Context, in *CreateDecRequest, opts ...grpc.CallOption) (*DecSnap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecSnap)
+	err := c.cc.Invoke(ctx, DecService_CreateDec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decServiceClient) GetDec(ctx context.Context, in *GetDecRequest, opts ...grpc.CallOption) (*DecSnap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecSnap)
+	err := c.cc.Invoke(ctx, DecService_GetDec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decServiceClient) ListDecs(ctx context.Context, in *ListDecsRequest, opts ...grpc.CallOption) (*ListDecsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDecsResponse)
+	err := c.cc.Invoke(ctx, DecService_ListDecs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecServiceServer is the server API for DecService service.
+// All implementations must embed UnimplementedDecServiceServer
+// for forward compatibility.
+//
+// process declarations, see aat/proc/dec
+type DecServiceServer interface {
+	CreateDec(context.Context, *CreateDecRequest) (*DecSnap, error)
+	GetDec(context.Context, *GetDecRequest) (*DecSnap, error)
+	ListDecs(context.Context, *ListDecsRequest) (*ListDecsResponse, error)
+	mustEmbedUnimplementedDecServiceServer()
+}
+
+// UnimplementedDecServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDecServiceServer struct{}
+
+func (UnimplementedDecServiceServer) CreateDec(context.Context, *CreateDecRequest) (*DecSnap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDec not implemented")
+}
+func (UnimplementedDecServiceServer) GetDec(context.Context, *GetDecRequest) (*DecSnap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDec not implemented")
+}
+func (UnimplementedDecServiceServer) ListDecs(context.Context, *ListDecsRequest) (*ListDecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDecs not implemented")
+}
+func (UnimplementedDecServiceServer) mustEmbedUnimplementedDecServiceServer() {}
+func (UnimplementedDecServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeDecServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DecServiceServer will
+// result in compilation errors.
+type UnsafeDecServiceServer interface {
+	mustEmbedUnimplementedDecServiceServer()
+}
+
+func RegisterDecServiceServer(s grpc.ServiceRegistrar, srv DecServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDecServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DecService_ServiceDesc, srv)
+}
+
+func _DecService_CreateDec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecServiceServer).CreateDec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecService_CreateDec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecServiceServer).CreateDec(ctx, req.(*CreateDecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecService_GetDec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecServiceServer).GetDec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecService_GetDec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecServiceServer).GetDec(ctx, req.(*GetDecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecService_ListDecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecServiceServer).ListDecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecService_ListDecs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecServiceServer).ListDecs(ctx, req.(*ListDecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DecService_ServiceDesc is the grpc.ServiceDesc for DecService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DecService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orglang.DecService",
+	HandlerType: (*DecServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDec",
+			Handler:    _DecService_CreateDec_Handler,
+		},
+		{
+			MethodName: "GetDec",
+			Handler:    _DecService_GetDec_Handler,
+		},
+		{
+			MethodName: "ListDecs",
+			Handler:    _DecService_ListDecs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dec.proto",
+}
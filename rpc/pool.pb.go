@@ -0,0 +1,961 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pool.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreatePoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SigQn         string                 `protobuf:"bytes,1,opt,name=sig_qn,json=sigQn,proto3" json:"sig_qn,omitempty"`
+	ProcIds       []string               `protobuf:"bytes,2,rep,name=proc_ids,json=procIds,proto3" json:"proc_ids,omitempty"`
+	SupId         string                 `protobuf:"bytes,3,opt,name=sup_id,json=supId,proto3" json:"sup_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePoolRequest) Reset() {
+	*x = CreatePoolRequest{}
+	mi := &file_pool_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePoolRequest) ProtoMessage() {}
+
+func (x *CreatePoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePoolRequest.ProtoReflect.Descriptor instead.
+func (*CreatePoolRequest) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreatePoolRequest) GetSigQn() string {
+	if x != nil {
+		return x.SigQn
+	}
+	return ""
+}
+
+func (x *CreatePoolRequest) GetProcIds() []string {
+	if x != nil {
+		return x.ProcIds
+	}
+	return nil
+}
+
+func (x *CreatePoolRequest) GetSupId() string {
+	if x != nil {
+		return x.SupId
+	}
+	return ""
+}
+
+type GetPoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoolRequest) Reset() {
+	*x = GetPoolRequest{}
+	mi := &file_pool_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolRequest) ProtoMessage() {}
+
+func (x *GetPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolRequest.ProtoReflect.Descriptor instead.
+func (*GetPoolRequest) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPoolRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PoolRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PoolId        string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	ProcId        string                 `protobuf:"bytes,2,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolRef) Reset() {
+	*x = PoolRef{}
+	mi := &file_pool_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolRef) ProtoMessage() {}
+
+func (x *PoolRef) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolRef.ProtoReflect.Descriptor instead.
+func (*PoolRef) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PoolRef) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *PoolRef) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+type PoolSnap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Subs          []*PoolRef             `protobuf:"bytes,3,rep,name=subs,proto3" json:"subs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolSnap) Reset() {
+	*x = PoolSnap{}
+	mi := &file_pool_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolSnap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolSnap) ProtoMessage() {}
+
+func (x *PoolSnap) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolSnap.ProtoReflect.Descriptor instead.
+func (*PoolSnap) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PoolSnap) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PoolSnap) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PoolSnap) GetSubs() []*PoolRef {
+	if x != nil {
+		return x.Subs
+	}
+	return nil
+}
+
+type SpawnProcRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PoolId string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	ProcId string                 `protobuf:"bytes,2,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	Term   *CallProc              `protobuf:"bytes,3,opt,name=term,proto3" json:"term,omitempty"`
+	// higher goes first
+	Priority int32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// makes replays return the original result
+	IdemKey       string `protobuf:"bytes,5,opt,name=idem_key,json=idemKey,proto3" json:"idem_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpawnProcRequest) Reset() {
+	*x = SpawnProcRequest{}
+	mi := &file_pool_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpawnProcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpawnProcRequest) ProtoMessage() {}
+
+func (x *SpawnProcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpawnProcRequest.ProtoReflect.Descriptor instead.
+func (*SpawnProcRequest) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SpawnProcRequest) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *SpawnProcRequest) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+func (x *SpawnProcRequest) GetTerm() *CallProc {
+	if x != nil {
+		return x.Term
+	}
+	return nil
+}
+
+func (x *SpawnProcRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SpawnProcRequest) GetIdemKey() string {
+	if x != nil {
+		return x.IdemKey
+	}
+	return ""
+}
+
+type ProcRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProcId        string                 `protobuf:"bytes,1,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcRef) Reset() {
+	*x = ProcRef{}
+	mi := &file_pool_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcRef) ProtoMessage() {}
+
+func (x *ProcRef) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcRef.ProtoReflect.Descriptor instead.
+func (*ProcRef) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProcRef) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+type CancelProcRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PoolId        string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	ProcId        string                 `protobuf:"bytes,2,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelProcRequest) Reset() {
+	*x = CancelProcRequest{}
+	mi := &file_pool_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelProcRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelProcRequest) ProtoMessage() {}
+
+func (x *CancelProcRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelProcRequest.ProtoReflect.Descriptor instead.
+func (*CancelProcRequest) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelProcRequest) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *CancelProcRequest) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+func (x *CancelProcRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelProcResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelProcResponse) Reset() {
+	*x = CancelProcResponse{}
+	mi := &file_pool_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelProcResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelProcResponse) ProtoMessage() {}
+
+func (x *CancelProcResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelProcResponse.ProtoReflect.Descriptor instead.
+func (*CancelProcResponse) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{7}
+}
+
+type GetJournalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProcId        string                 `protobuf:"bytes,1,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJournalRequest) Reset() {
+	*x = GetJournalRequest{}
+	mi := &file_pool_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJournalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJournalRequest) ProtoMessage() {}
+
+func (x *GetJournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJournalRequest.ProtoReflect.Descriptor instead.
+func (*GetJournalRequest) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetJournalRequest) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+type GetJournalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*JournalEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJournalResponse) Reset() {
+	*x = GetJournalResponse{}
+	mi := &file_pool_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJournalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJournalResponse) ProtoMessage() {}
+
+func (x *GetJournalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJournalResponse.ProtoReflect.Descriptor instead.
+func (*GetJournalResponse) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetJournalResponse) GetEntries() []*JournalEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type JournalEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PoolId        string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	ProcId        string                 `protobuf:"bytes,2,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Rev           int64                  `protobuf:"varint,4,opt,name=rev,proto3" json:"rev,omitempty"`
+	Detail        string                 `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalEntry) Reset() {
+	*x = JournalEntry{}
+	mi := &file_pool_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalEntry) ProtoMessage() {}
+
+func (x *JournalEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalEntry.ProtoReflect.Descriptor instead.
+func (*JournalEntry) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JournalEntry) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *JournalEntry) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *JournalEntry) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PoolId string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	// events after this sequence number get replayed first
+	Seq           int64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_pool_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchEventsRequest) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type PoolEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PoolId        string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	ProcId        string                 `protobuf:"bytes,2,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	ChnlId        string                 `protobuf:"bytes,3,opt,name=chnl_id,json=chnlId,proto3" json:"chnl_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Rev           int64                  `protobuf:"varint,5,opt,name=rev,proto3" json:"rev,omitempty"`
+	Seq           int64                  `protobuf:"varint,6,opt,name=seq,proto3" json:"seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolEvent) Reset() {
+	*x = PoolEvent{}
+	mi := &file_pool_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolEvent) ProtoMessage() {}
+
+func (x *PoolEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolEvent.ProtoReflect.Descriptor instead.
+func (*PoolEvent) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PoolEvent) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *PoolEvent) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+func (x *PoolEvent) GetChnlId() string {
+	if x != nil {
+		return x.ChnlId
+	}
+	return ""
+}
+
+func (x *PoolEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PoolEvent) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *PoolEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type PollProcsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PoolId        string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollProcsRequest) Reset() {
+	*x = PollProcsRequest{}
+	mi := &file_pool_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollProcsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollProcsRequest) ProtoMessage() {}
+
+func (x *PollProcsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pool_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollProcsRequest.ProtoReflect.Descriptor instead.
+func (*PollProcsRequest) Descriptor() ([]byte, []int) {
+	return file_pool_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PollProcsRequest) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+var File_pool_proto protoreflect.FileDescriptor
+
+const file_pool_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"pool.proto\x12\aorglang\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\n" +
+	"proc.proto\"\\\n" +
+	"\x11CreatePoolRequest\x12\x15\n" +
+	"\x06sig_qn\x18\x01 \x01(\tR\x05sigQn\x12\x19\n" +
+	"\bproc_ids\x18\x02 \x03(\tR\aprocIds\x12\x15\n" +
+	"\x06sup_id\x18\x03 \x01(\tR\x05supId\" \n" +
+	"\x0eGetPoolRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\aPoolRef\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x17\n" +
+	"\aproc_id\x18\x02 \x01(\tR\x06procId\"V\n" +
+	"\bPoolSnap\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12$\n" +
+	"\x04subs\x18\x03 \x03(\v2\x10.orglang.PoolRefR\x04subs\"\xa2\x01\n" +
+	"\x10SpawnProcRequest\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x17\n" +
+	"\aproc_id\x18\x02 \x01(\tR\x06procId\x12%\n" +
+	"\x04term\x18\x03 \x01(\v2\x11.orglang.CallProcR\x04term\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x19\n" +
+	"\bidem_key\x18\x05 \x01(\tR\aidemKey\"\"\n" +
+	"\aProcRef\x12\x17\n" +
+	"\aproc_id\x18\x01 \x01(\tR\x06procId\"]\n" +
+	"\x11CancelProcRequest\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x17\n" +
+	"\aproc_id\x18\x02 \x01(\tR\x06procId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x14\n" +
+	"\x12CancelProcResponse\",\n" +
+	"\x11GetJournalRequest\x12\x17\n" +
+	"\aproc_id\x18\x01 \x01(\tR\x06procId\"E\n" +
+	"\x12GetJournalResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.orglang.JournalEntryR\aentries\"\xaa\x01\n" +
+	"\fJournalEntry\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x17\n" +
+	"\aproc_id\x18\x02 \x01(\tR\x06procId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03rev\x18\x04 \x01(\x03R\x03rev\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\x12*\n" +
+	"\x02at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\"?\n" +
+	"\x12WatchEventsRequest\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\"\x8e\x01\n" +
+	"\tPoolEvent\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x17\n" +
+	"\aproc_id\x18\x02 \x01(\tR\x06procId\x12\x17\n" +
+	"\achnl_id\x18\x03 \x01(\tR\x06chnlId\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03rev\x18\x05 \x01(\x03R\x03rev\x12\x10\n" +
+	"\x03seq\x18\x06 \x01(\x03R\x03seq\"+\n" +
+	"\x10PollProcsRequest\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId2\xc8\x03\n" +
+	"\vPoolService\x12:\n" +
+	"\n" +
+	"CreatePool\x12\x1a.orglang.CreatePoolRequest\x1a\x10.orglang.PoolRef\x125\n" +
+	"\aGetPool\x12\x17.orglang.GetPoolRequest\x1a\x11.orglang.PoolSnap\x128\n" +
+	"\tSpawnProc\x12\x19.orglang.SpawnProcRequest\x1a\x10.orglang.ProcRef\x12E\n" +
+	"\n" +
+	"CancelProc\x12\x1a.orglang.CancelProcRequest\x1a\x1b.orglang.CancelProcResponse\x12E\n" +
+	"\n" +
+	"GetJournal\x12\x1a.orglang.GetJournalRequest\x1a\x1b.orglang.GetJournalResponse\x12@\n" +
+	"\vWatchEvents\x12\x1b.orglang.WatchEventsRequest\x1a\x12.orglang.PoolEvent0\x01\x12<\n" +
+	"\tPollProcs\x12\x19.orglang.PollProcsRequest\x1a\x10.orglang.ProcRef(\x010\x01B(\n" +
+	"\x0forg.orglang.rpcP\x01Z\x13orglang/orglang/rpcb\x06proto3"
+
+var (
+	file_pool_proto_rawDescOnce sync.Once
+	file_pool_proto_rawDescData []byte
+)
+
+func file_pool_proto_rawDescGZIP() []byte {
+	file_pool_proto_rawDescOnce.Do(func() {
+		file_pool_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pool_proto_rawDesc), len(file_pool_proto_rawDesc)))
+	})
+	return file_pool_proto_rawDescData
+}
+
+var file_pool_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_pool_proto_goTypes = []any{
+	(*CreatePoolRequest)(nil),     // 0: orglang.CreatePoolRequest
+	(*GetPoolRequest)(nil),        // 1: orglang.GetPoolRequest
+	(*PoolRef)(nil),               // 2: orglang.PoolRef
+	(*PoolSnap)(nil),              // 3: orglang.PoolSnap
+	(*SpawnProcRequest)(nil),      // 4: orglang.SpawnProcRequest
+	(*ProcRef)(nil),               // 5: orglang.ProcRef
+	(*CancelProcRequest)(nil),     // 6: orglang.CancelProcRequest
+	(*CancelProcResponse)(nil),    // 7: orglang.CancelProcResponse
+	(*GetJournalRequest)(nil),     // 8: orglang.GetJournalRequest
+	(*GetJournalResponse)(nil),    // 9: orglang.GetJournalResponse
+	(*JournalEntry)(nil),          // 10: orglang.JournalEntry
+	(*WatchEventsRequest)(nil),    // 11: orglang.WatchEventsRequest
+	(*PoolEvent)(nil),             // 12: orglang.PoolEvent
+	(*PollProcsRequest)(nil),      // 13: orglang.PollProcsRequest
+	(*CallProc)(nil),              // 14: orglang.CallProc
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_pool_proto_depIdxs = []int32{
+	2,  // 0: orglang.PoolSnap.subs:type_name -> orglang.PoolRef
+	14, // 1: orglang.SpawnProcRequest.term:type_name -> orglang.CallProc
+	10, // 2: orglang.GetJournalResponse.entries:type_name -> orglang.JournalEntry
+	15, // 3: orglang.JournalEntry.at:type_name -> google.protobuf.Timestamp
+	0,  // 4: orglang.PoolService.CreatePool:input_type -> orglang.CreatePoolRequest
+	1,  // 5: orglang.PoolService.GetPool:input_type -> orglang.GetPoolRequest
+	4,  // 6: orglang.PoolService.SpawnProc:input_type -> orglang.SpawnProcRequest
+	6,  // 7: orglang.PoolService.CancelProc:input_type -> orglang.CancelProcRequest
+	8,  // 8: orglang.PoolService.GetJournal:input_type -> orglang.GetJournalRequest
+	11, // 9: orglang.PoolService.WatchEvents:input_type -> orglang.WatchEventsRequest
+	13, // 10: orglang.PoolService.PollProcs:input_type -> orglang.PollProcsRequest
+	2,  // 11: orglang.PoolService.CreatePool:output_type -> orglang.PoolRef
+	3,  // 12: orglang.PoolService.GetPool:output_type -> orglang.PoolSnap
+	5,  // 13: orglang.PoolService.SpawnProc:output_type -> orglang.ProcRef
+	7,  // 14: orglang.PoolService.CancelProc:output_type -> orglang.CancelProcResponse
+	9,  // 15: orglang.PoolService.GetJournal:output_type -> orglang.GetJournalResponse
+	12, // 16: orglang.PoolService.WatchEvents:output_type -> orglang.PoolEvent
+	5,  // 17: orglang.PoolService.PollProcs:output_type -> orglang.ProcRef
+	11, // [11:18] is the sub-list for method output_type
+	4,  // [4:11] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_pool_proto_init() }
+func file_pool_proto_init() {
+	if File_pool_proto != nil {
+		return
+	}
+	file_proc_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pool_proto_rawDesc), len(file_pool_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pool_proto_goTypes,
+		DependencyIndexes: file_pool_proto_depIdxs,
+		MessageInfos:      file_pool_proto_msgTypes,
+	}.Build()
+	File_pool_proto = out.File
+	file_pool_proto_goTypes = nil
+	file_pool_proto_depIdxs = nil
+}
@@ -0,0 +1,355 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pool.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PoolService_CreatePool_FullMethodName  = "/orglang.PoolService/CreatePool"
+	PoolService_GetPool_FullMethodName     = "/orglang.PoolService/GetPool"
+	PoolService_SpawnProc_FullMethodName   = "/orglang.PoolService/SpawnProc"
+	PoolService_CancelProc_FullMethodName  = "/orglang.PoolService/CancelProc"
+	PoolService_GetJournal_FullMethodName  = "/orglang.PoolService/GetJournal"
+	PoolService_WatchEvents_FullMethodName = "/orglang.PoolService/WatchEvents"
+	PoolService_PollProcs_FullMethodName   = "/orglang.PoolService/PollProcs"
+)
+
+// PoolServiceClient is the client API for PoolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// pool execution, see aat/pool/exec
+type PoolServiceClient interface {
+	CreatePool(ctx context.Context, in *CreatePoolRequest, opts ...grpc.CallOption) (*PoolRef, error)
+	GetPool(ctx context.Context, in *GetPoolRequest, opts ...grpc.CallOption) (*PoolSnap, error)
+	SpawnProc(ctx context.Context, in *SpawnProcRequest, opts ...grpc.CallOption) (*ProcRef, error)
+	CancelProc(ctx context.Context, in *CancelProcRequest, opts ...grpc.CallOption) (*CancelProcResponse, error)
+	GetJournal(ctx context.Context, in *GetJournalRequest, opts ...grpc.CallOption) (*GetJournalResponse, error)
+	// committed events, the ones after the revision get replayed first
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PoolEvent], error)
+	// every request asks for the next process ready to step
+	PollProcs(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PollProcsRequest, ProcRef], error)
+}
+
+type poolServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPoolServiceClient(cc grpc.ClientConnInterface) PoolServiceClient {
+	return &poolServiceClient{cc}
+}
+
+func (c *poolServiceClient) CreatePool(ctx context.Context, in *CreatePoolRequest, opts ...grpc.CallOption) (*PoolRef, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolRef)
+	err := c.cc.Invoke(ctx, PoolService_CreatePool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetPool(ctx context.Context, in *GetPoolRequest, opts ...grpc.CallOption) (*PoolSnap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolSnap)
+	err := c.cc.Invoke(ctx, PoolService_GetPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) SpawnProc(ctx context.Context, in *SpawnProcRequest, opts ...grpc.CallOption) (*ProcRef, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcRef)
+	err := c.cc.Invoke(ctx, PoolService_SpawnProc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) CancelProc(ctx context.Context, in *CancelProcRequest, opts ...grpc.CallOption) (*CancelProcResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelProcResponse)
+	err := c.cc.Invoke(ctx, PoolService_CancelProc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetJournal(ctx context.Context, in *GetJournalRequest, opts ...grpc.CallOption) (*GetJournalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJournalResponse)
+	err := c.cc.Invoke(ctx, PoolService_GetJournal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PoolEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PoolService_ServiceDesc.Streams[0], PoolService_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, PoolEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoolService_WatchEventsClient = grpc.ServerStreamingClient[PoolEvent]
+
+func (c *poolServiceClient) PollProcs(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PollProcsRequest, ProcRef], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PoolService_ServiceDesc.Streams[1], PoolService_PollProcs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PollProcsRequest, ProcRef]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoolService_PollProcsClient = grpc.BidiStreamingClient[PollProcsRequest, ProcRef]
+
+// PoolServiceServer is the server API for PoolService service.
+// All implementations must embed UnimplementedPoolServiceServer
+// for forward compatibility.
+//
+// pool execution, see aat/pool/exec
+type PoolServiceServer interface {
+	CreatePool(context.Context, *CreatePoolRequest) (*PoolRef, error)
+	GetPool(context.Context, *GetPoolRequest) (*PoolSnap, error)
+	SpawnProc(context.Context, *SpawnProcRequest) (*ProcRef, error)
+	CancelProc(context.Context, *CancelProcRequest) (*CancelProcResponse, error)
+	GetJournal(context.Context, *GetJournalRequest) (*GetJournalResponse, error)
+	// committed events, the ones after the revision get replayed first
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[PoolEvent]) error
+	// every request asks for the next process ready to step
+	PollProcs(grpc.BidiStreamingServer[PollProcsRequest, ProcRef]) error
+	mustEmbedUnimplementedPoolServiceServer()
+}
+
+// UnimplementedPoolServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPoolServiceServer struct{}
+
+func (UnimplementedPoolServiceServer) CreatePool(context.Context, *CreatePoolRequest) (*PoolRef, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePool not implemented")
+}
+func (UnimplementedPoolServiceServer) GetPool(context.Context, *GetPoolRequest) (*PoolSnap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPool not implemented")
+}
+func (UnimplementedPoolServiceServer) SpawnProc(context.Context, *SpawnProcRequest) (*ProcRef, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SpawnProc not implemented")
+}
+func (UnimplementedPoolServiceServer) CancelProc(context.Context, *CancelProcRequest) (*CancelProcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelProc not implemented")
+}
+func (UnimplementedPoolServiceServer) GetJournal(context.Context, *GetJournalRequest) (*GetJournalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJournal not implemented")
+}
+func (UnimplementedPoolServiceServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[PoolEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedPoolServiceServer) PollProcs(grpc.BidiStreamingServer[PollProcsRequest, ProcRef]) error {
+	return status.Errorf(codes.Unimplemented, "method PollProcs not implemented")
+}
+func (UnimplementedPoolServiceServer) mustEmbedUnimplementedPoolServiceServer() {}
+func (UnimplementedPoolServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafePoolServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PoolServiceServer will
+// result in compilation errors.
+type UnsafePoolServiceServer interface {
+	mustEmbedUnimplementedPoolServiceServer()
+}
+
+func RegisterPoolServiceServer(s grpc.ServiceRegistrar, srv PoolServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPoolServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PoolService_ServiceDesc, srv)
+}
+
+func _PoolService_CreatePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).CreatePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_CreatePool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).CreatePool(ctx, req.(*CreatePoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetPool(ctx, req.(*GetPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_SpawnProc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpawnProcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).SpawnProc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_SpawnProc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).SpawnProc(ctx, req.(*SpawnProcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_CancelProc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelProcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).CancelProc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_CancelProc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).CancelProc(ctx, req.(*CancelProcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetJournal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetJournal(ctx, req.(*GetJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PoolServiceServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, PoolEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoolService_WatchEventsServer = grpc.ServerStreamingServer[PoolEvent]
+
+func _PoolService_PollProcs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PoolServiceServer).PollProcs(&grpc.GenericServerStream[PollProcsRequest, ProcRef]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoolService_PollProcsServer = grpc.BidiStreamingServer[PollProcsRequest, ProcRef]
+
+// PoolService_ServiceDesc is the grpc.ServiceDesc for PoolService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PoolService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orglang.PoolService",
+	HandlerType: (*PoolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePool",
+			Handler:    _PoolService_CreatePool_Handler,
+		},
+		{
+			MethodName: "GetPool",
+			Handler:    _PoolService_GetPool_Handler,
+		},
+		{
+			MethodName: "SpawnProc",
+			Handler:    _PoolService_SpawnProc_Handler,
+		},
+		{
+			MethodName: "CancelProc",
+			Handler:    _PoolService_CancelProc_Handler,
+		},
+		{
+			MethodName: "GetJournal",
+			Handler:    _PoolService_GetJournal_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _PoolService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PollProcs",
+			Handler:       _PoolService_PollProcs_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pool.proto",
+}
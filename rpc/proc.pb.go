@@ -0,0 +1,898 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proc.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// process terms, see aat/proc/def
+type ProcTerm struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Term:
+	//
+	//	*ProcTerm_Close
+	//	*ProcTerm_Wait
+	//	*ProcTerm_Send
+	//	*ProcTerm_Recv
+	//	*ProcTerm_Lab
+	//	*ProcTerm_Case
+	//	*ProcTerm_Spawn
+	//	*ProcTerm_Fwd
+	//	*ProcTerm_Call
+	Term          isProcTerm_Term `protobuf_oneof:"term"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcTerm) Reset() {
+	*x = ProcTerm{}
+	mi := &file_proc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcTerm) ProtoMessage() {}
+
+func (x *ProcTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcTerm.ProtoReflect.Descriptor instead.
+func (*ProcTerm) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProcTerm) GetTerm() isProcTerm_Term {
+	if x != nil {
+		return x.Term
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetClose() *CloseProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Close); ok {
+			return x.Close
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetWait() *WaitProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Wait); ok {
+			return x.Wait
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetSend() *SendProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Send); ok {
+			return x.Send
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetRecv() *RecvProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Recv); ok {
+			return x.Recv
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetLab() *LabProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Lab); ok {
+			return x.Lab
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetCase() *CaseProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Case); ok {
+			return x.Case
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetSpawn() *SpawnProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Spawn); ok {
+			return x.Spawn
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetFwd() *FwdProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Fwd); ok {
+			return x.Fwd
+		}
+	}
+	return nil
+}
+
+func (x *ProcTerm) GetCall() *CallProc {
+	if x != nil {
+		if x, ok := x.Term.(*ProcTerm_Call); ok {
+			return x.Call
+		}
+	}
+	return nil
+}
+
+type isProcTerm_Term interface {
+	isProcTerm_Term()
+}
+
+type ProcTerm_Close struct {
+	Close *CloseProc `protobuf:"bytes,1,opt,name=close,proto3,oneof"`
+}
+
+type ProcTerm_Wait struct {
+	Wait *WaitProc `protobuf:"bytes,2,opt,name=wait,proto3,oneof"`
+}
+
+type ProcTerm_Send struct {
+	Send *SendProc `protobuf:"bytes,3,opt,name=send,proto3,oneof"`
+}
+
+type ProcTerm_Recv struct {
+	Recv *RecvProc `protobuf:"bytes,4,opt,name=recv,proto3,oneof"`
+}
+
+type ProcTerm_Lab struct {
+	Lab *LabProc `protobuf:"bytes,5,opt,name=lab,proto3,oneof"`
+}
+
+type ProcTerm_Case struct {
+	Case *CaseProc `protobuf:"bytes,6,opt,name=case,proto3,oneof"`
+}
+
+type ProcTerm_Spawn struct {
+	Spawn *SpawnProc `protobuf:"bytes,7,opt,name=spawn,proto3,oneof"`
+}
+
+type ProcTerm_Fwd struct {
+	Fwd *FwdProc `protobuf:"bytes,8,opt,name=fwd,proto3,oneof"`
+}
+
+type ProcTerm_Call struct {
+	Call *CallProc `protobuf:"bytes,9,opt,name=call,proto3,oneof"`
+}
+
+func (*ProcTerm_Close) isProcTerm_Term() {}
+
+func (*ProcTerm_Wait) isProcTerm_Term() {}
+
+func (*ProcTerm_Send) isProcTerm_Term() {}
+
+func (*ProcTerm_Recv) isProcTerm_Term() {}
+
+func (*ProcTerm_Lab) isProcTerm_Term() {}
+
+func (*ProcTerm_Case) isProcTerm_Term() {}
+
+func (*ProcTerm_Spawn) isProcTerm_Term() {}
+
+func (*ProcTerm_Fwd) isProcTerm_Term() {}
+
+func (*ProcTerm_Call) isProcTerm_Term() {}
+
+type CloseProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseProc) Reset() {
+	*x = CloseProc{}
+	mi := &file_proc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseProc) ProtoMessage() {}
+
+func (x *CloseProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseProc.ProtoReflect.Descriptor instead.
+func (*CloseProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CloseProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+type WaitProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Cont          *ProcTerm              `protobuf:"bytes,2,opt,name=cont,proto3" json:"cont,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitProc) Reset() {
+	*x = WaitProc{}
+	mi := &file_proc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitProc) ProtoMessage() {}
+
+func (x *WaitProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitProc.ProtoReflect.Descriptor instead.
+func (*WaitProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WaitProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *WaitProc) GetCont() *ProcTerm {
+	if x != nil {
+		return x.Cont
+	}
+	return nil
+}
+
+type SendProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             string                 `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendProc) Reset() {
+	*x = SendProc{}
+	mi := &file_proc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendProc) ProtoMessage() {}
+
+func (x *SendProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendProc.ProtoReflect.Descriptor instead.
+func (*SendProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SendProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *SendProc) GetY() string {
+	if x != nil {
+		return x.Y
+	}
+	return ""
+}
+
+type RecvProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             string                 `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+	Cont          *ProcTerm              `protobuf:"bytes,3,opt,name=cont,proto3" json:"cont,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecvProc) Reset() {
+	*x = RecvProc{}
+	mi := &file_proc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecvProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecvProc) ProtoMessage() {}
+
+func (x *RecvProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecvProc.ProtoReflect.Descriptor instead.
+func (*RecvProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecvProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *RecvProc) GetY() string {
+	if x != nil {
+		return x.Y
+	}
+	return ""
+}
+
+func (x *RecvProc) GetCont() *ProcTerm {
+	if x != nil {
+		return x.Cont
+	}
+	return nil
+}
+
+type LabProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabProc) Reset() {
+	*x = LabProc{}
+	mi := &file_proc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabProc) ProtoMessage() {}
+
+func (x *LabProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabProc.ProtoReflect.Descriptor instead.
+func (*LabProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LabProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *LabProc) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type CaseProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Branches      []*BranchProc          `protobuf:"bytes,2,rep,name=branches,proto3" json:"branches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaseProc) Reset() {
+	*x = CaseProc{}
+	mi := &file_proc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseProc) ProtoMessage() {}
+
+func (x *CaseProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseProc.ProtoReflect.Descriptor instead.
+func (*CaseProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CaseProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *CaseProc) GetBranches() []*BranchProc {
+	if x != nil {
+		return x.Branches
+	}
+	return nil
+}
+
+type BranchProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Cont          *ProcTerm              `protobuf:"bytes,2,opt,name=cont,proto3" json:"cont,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BranchProc) Reset() {
+	*x = BranchProc{}
+	mi := &file_proc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BranchProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BranchProc) ProtoMessage() {}
+
+func (x *BranchProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BranchProc.ProtoReflect.Descriptor instead.
+func (*BranchProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BranchProc) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *BranchProc) GetCont() *ProcTerm {
+	if x != nil {
+		return x.Cont
+	}
+	return nil
+}
+
+type SpawnProc struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	X     string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	SigId string                 `protobuf:"bytes,2,opt,name=sig_id,json=sigId,proto3" json:"sig_id,omitempty"`
+	Ys    []string               `protobuf:"bytes,3,rep,name=ys,proto3" json:"ys,omitempty"`
+	// absent for the tail spawns
+	Cont          *ProcTerm `protobuf:"bytes,4,opt,name=cont,proto3" json:"cont,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpawnProc) Reset() {
+	*x = SpawnProc{}
+	mi := &file_proc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpawnProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpawnProc) ProtoMessage() {}
+
+func (x *SpawnProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpawnProc.ProtoReflect.Descriptor instead.
+func (*SpawnProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SpawnProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *SpawnProc) GetSigId() string {
+	if x != nil {
+		return x.SigId
+	}
+	return ""
+}
+
+func (x *SpawnProc) GetYs() []string {
+	if x != nil {
+		return x.Ys
+	}
+	return nil
+}
+
+func (x *SpawnProc) GetCont() *ProcTerm {
+	if x != nil {
+		return x.Cont
+	}
+	return nil
+}
+
+type FwdProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             string                 `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FwdProc) Reset() {
+	*x = FwdProc{}
+	mi := &file_proc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FwdProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FwdProc) ProtoMessage() {}
+
+func (x *FwdProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FwdProc.ProtoReflect.Descriptor instead.
+func (*FwdProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FwdProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *FwdProc) GetY() string {
+	if x != nil {
+		return x.Y
+	}
+	return ""
+}
+
+type CallProc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             string                 `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	SigPh         string                 `protobuf:"bytes,2,opt,name=sig_ph,json=sigPh,proto3" json:"sig_ph,omitempty"`
+	Ys            []string               `protobuf:"bytes,3,rep,name=ys,proto3" json:"ys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallProc) Reset() {
+	*x = CallProc{}
+	mi := &file_proc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallProc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallProc) ProtoMessage() {}
+
+func (x *CallProc) ProtoReflect() protoreflect.Message {
+	mi := &file_proc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallProc.ProtoReflect.Descriptor instead.
+func (*CallProc) Descriptor() ([]byte, []int) {
+	return file_proc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CallProc) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *CallProc) GetSigPh() string {
+	if x != nil {
+		return x.SigPh
+	}
+	return ""
+}
+
+func (x *CallProc) GetYs() []string {
+	if x != nil {
+		return x.Ys
+	}
+	return nil
+}
+
+var File_proc_proto protoreflect.FileDescriptor
+
+const file_proc_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"proc.proto\x12\aorglang\"\x83\x03\n" +
+	"\bProcTerm\x12*\n" +
+	"\x05close\x18\x01 \x01(\v2\x12.orglang.CloseProcH\x00R\x05close\x12'\n" +
+	"\x04wait\x18\x02 \x01(\v2\x11.orglang.WaitProcH\x00R\x04wait\x12'\n" +
+	"\x04send\x18\x03 \x01(\v2\x11.orglang.SendProcH\x00R\x04send\x12'\n" +
+	"\x04recv\x18\x04 \x01(\v2\x11.orglang.RecvProcH\x00R\x04recv\x12$\n" +
+	"\x03lab\x18\x05 \x01(\v2\x10.orglang.LabProcH\x00R\x03lab\x12'\n" +
+	"\x04case\x18\x06 \x01(\v2\x11.orglang.CaseProcH\x00R\x04case\x12*\n" +
+	"\x05spawn\x18\a \x01(\v2\x12.orglang.SpawnProcH\x00R\x05spawn\x12$\n" +
+	"\x03fwd\x18\b \x01(\v2\x10.orglang.FwdProcH\x00R\x03fwd\x12'\n" +
+	"\x04call\x18\t \x01(\v2\x11.orglang.CallProcH\x00R\x04callB\x06\n" +
+	"\x04term\"\x19\n" +
+	"\tCloseProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\"?\n" +
+	"\bWaitProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12%\n" +
+	"\x04cont\x18\x02 \x01(\v2\x11.orglang.ProcTermR\x04cont\"&\n" +
+	"\bSendProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\tR\x01y\"M\n" +
+	"\bRecvProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\tR\x01y\x12%\n" +
+	"\x04cont\x18\x03 \x01(\v2\x11.orglang.ProcTermR\x04cont\"-\n" +
+	"\aLabProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"I\n" +
+	"\bCaseProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12/\n" +
+	"\bbranches\x18\x02 \x03(\v2\x13.orglang.BranchProcR\bbranches\"I\n" +
+	"\n" +
+	"BranchProc\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12%\n" +
+	"\x04cont\x18\x02 \x01(\v2\x11.orglang.ProcTermR\x04cont\"g\n" +
+	"\tSpawnProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12\x15\n" +
+	"\x06sig_id\x18\x02 \x01(\tR\x05sigId\x12\x0e\n" +
+	"\x02ys\x18\x03 \x03(\tR\x02ys\x12%\n" +
+	"\x04cont\x18\x04 \x01(\v2\x11.orglang.ProcTermR\x04cont\"%\n" +
+	"\aFwdProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\tR\x01y\"?\n" +
+	"\bCallProc\x12\f\n" +
+	"\x01x\x18\x01 \x01(\tR\x01x\x12\x15\n" +
+	"\x06sig_ph\x18\x02 \x01(\tR\x05sigPh\x12\x0e\n" +
+	"\x02ys\x18\x03 \x03(\tR\x02ysB(\n" +
+	"\x0forg.orglang.rpcP\x01Z\x13orglang/orglang/rpcb\x06proto3"
+
+var (
+	file_proc_proto_rawDescOnce sync.Once
+	file_proc_proto_rawDescData []byte
+)
+
+func file_proc_proto_rawDescGZIP() []byte {
+	file_proc_proto_rawDescOnce.Do(func() {
+		file_proc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proc_proto_rawDesc), len(file_proc_proto_rawDesc)))
+	})
+	return file_proc_proto_rawDescData
+}
+
+var file_proc_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proc_proto_goTypes = []any{
+	(*ProcTerm)(nil),   // 0: orglang.ProcTerm
+	(*CloseProc)(nil),  // 1: orglang.CloseProc
+	(*WaitProc)(nil),   // 2: orglang.WaitProc
+	(*SendProc)(nil),   // 3: orglang.SendProc
+	(*RecvProc)(nil),   // 4: orglang.RecvProc
+	(*LabProc)(nil),    // 5: orglang.LabProc
+	(*CaseProc)(nil),   // 6: orglang.CaseProc
+	(*BranchProc)(nil), // 7: orglang.BranchProc
+	(*SpawnProc)(nil),  // 8: orglang.SpawnProc
+	(*FwdProc)(nil),    // 9: orglang.FwdProc
+	(*CallProc)(nil),   // 10: orglang.CallProc
+}
+var file_proc_proto_depIdxs = []int32{
+	1,  // 0: orglang.ProcTerm.close:type_name -> orglang.CloseProc
+	2,  // 1: orglang.ProcTerm.wait:type_name -> orglang.WaitProc
+	3,  // 2: orglang.ProcTerm.send:type_name -> orglang.SendProc
+	4,  // 3: orglang.ProcTerm.recv:type_name -> orglang.RecvProc
+	5,  // 4: orglang.ProcTerm.lab:type_name -> orglang.LabProc
+	6,  // 5: orglang.ProcTerm.case:type_name -> orglang.CaseProc
+	8,  // 6: orglang.ProcTerm.spawn:type_name -> orglang.SpawnProc
+	9,  // 7: orglang.ProcTerm.fwd:type_name -> orglang.FwdProc
+	10, // 8: orglang.ProcTerm.call:type_name -> orglang.CallProc
+	0,  // 9: orglang.WaitProc.cont:type_name -> orglang.ProcTerm
+	0,  // 10: orglang.RecvProc.cont:type_name -> orglang.ProcTerm
+	7,  // 11: orglang.CaseProc.branches:type_name -> orglang.BranchProc
+	0,  // 12: orglang.BranchProc.cont:type_name -> orglang.ProcTerm
+	0,  // 13: orglang.SpawnProc.cont:type_name -> orglang.ProcTerm
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_proc_proto_init() }
+func file_proc_proto_init() {
+	if File_proc_proto != nil {
+		return
+	}
+	file_proc_proto_msgTypes[0].OneofWrappers = []any{
+		(*ProcTerm_Close)(nil),
+		(*ProcTerm_Wait)(nil),
+		(*ProcTerm_Send)(nil),
+		(*ProcTerm_Recv)(nil),
+		(*ProcTerm_Lab)(nil),
+		(*ProcTerm_Case)(nil),
+		(*ProcTerm_Spawn)(nil),
+		(*ProcTerm_Fwd)(nil),
+		(*ProcTerm_Call)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proc_proto_rawDesc), len(file_proc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proc_proto_goTypes,
+		DependencyIndexes: file_proc_proto_depIdxs,
+		MessageInfos:      file_proc_proto_msgTypes,
+	}.Build()
+	File_proc_proto = out.File
+	file_proc_proto_goTypes = nil
+	file_proc_proto_depIdxs = nil
+}
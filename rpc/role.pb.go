@@ -0,0 +1,849 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: role.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Qn            string                 `protobuf:"bytes,1,opt,name=qn,proto3" json:"qn,omitempty"`
+	State         *TypeTerm              `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleRequest) Reset() {
+	*x = CreateRoleRequest{}
+	mi := &file_role_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleRequest) ProtoMessage() {}
+
+func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateRoleRequest) GetQn() string {
+	if x != nil {
+		return x.Qn
+	}
+	return ""
+}
+
+func (x *CreateRoleRequest) GetState() *TypeTerm {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type GetRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoleRequest) Reset() {
+	*x = GetRoleRequest{}
+	mi := &file_role_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleRequest) ProtoMessage() {}
+
+func (x *GetRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleRequest.ProtoReflect.Descriptor instead.
+func (*GetRoleRequest) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRoleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListRolesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesRequest) Reset() {
+	*x = ListRolesRequest{}
+	mi := &file_role_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesRequest) ProtoMessage() {}
+
+func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesRequest.ProtoReflect.Descriptor instead.
+func (*ListRolesRequest) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{2}
+}
+
+type ListRolesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refs          []*RoleRef             `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesResponse) Reset() {
+	*x = ListRolesResponse{}
+	mi := &file_role_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesResponse) ProtoMessage() {}
+
+func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesResponse.ProtoReflect.Descriptor instead.
+func (*ListRolesResponse) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRolesResponse) GetRefs() []*RoleRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type RoleRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rev           int64                  `protobuf:"varint,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleRef) Reset() {
+	*x = RoleRef{}
+	mi := &file_role_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleRef) ProtoMessage() {}
+
+func (x *RoleRef) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleRef.ProtoReflect.Descriptor instead.
+func (*RoleRef) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RoleRef) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoleRef) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *RoleRef) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type RoleSnap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rev           int64                  `protobuf:"varint,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Qn            string                 `protobuf:"bytes,4,opt,name=qn,proto3" json:"qn,omitempty"`
+	State         *TypeTerm              `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleSnap) Reset() {
+	*x = RoleSnap{}
+	mi := &file_role_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleSnap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleSnap) ProtoMessage() {}
+
+func (x *RoleSnap) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleSnap.ProtoReflect.Descriptor instead.
+func (*RoleSnap) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RoleSnap) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoleSnap) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *RoleSnap) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RoleSnap) GetQn() string {
+	if x != nil {
+		return x.Qn
+	}
+	return ""
+}
+
+func (x *RoleSnap) GetState() *TypeTerm {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type TypeTerm struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Term:
+	//
+	//	*TypeTerm_One
+	//	*TypeTerm_Link
+	//	*TypeTerm_Tensor
+	//	*TypeTerm_Lolli
+	//	*TypeTerm_Plus
+	//	*TypeTerm_With
+	Term          isTypeTerm_Term `protobuf_oneof:"term"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeTerm) Reset() {
+	*x = TypeTerm{}
+	mi := &file_role_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeTerm) ProtoMessage() {}
+
+func (x *TypeTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeTerm.ProtoReflect.Descriptor instead.
+func (*TypeTerm) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TypeTerm) GetTerm() isTypeTerm_Term {
+	if x != nil {
+		return x.Term
+	}
+	return nil
+}
+
+func (x *TypeTerm) GetOne() *OneType {
+	if x != nil {
+		if x, ok := x.Term.(*TypeTerm_One); ok {
+			return x.One
+		}
+	}
+	return nil
+}
+
+func (x *TypeTerm) GetLink() *LinkType {
+	if x != nil {
+		if x, ok := x.Term.(*TypeTerm_Link); ok {
+			return x.Link
+		}
+	}
+	return nil
+}
+
+func (x *TypeTerm) GetTensor() *ProdType {
+	if x != nil {
+		if x, ok := x.Term.(*TypeTerm_Tensor); ok {
+			return x.Tensor
+		}
+	}
+	return nil
+}
+
+func (x *TypeTerm) GetLolli() *ProdType {
+	if x != nil {
+		if x, ok := x.Term.(*TypeTerm_Lolli); ok {
+			return x.Lolli
+		}
+	}
+	return nil
+}
+
+func (x *TypeTerm) GetPlus() *SumType {
+	if x != nil {
+		if x, ok := x.Term.(*TypeTerm_Plus); ok {
+			return x.Plus
+		}
+	}
+	return nil
+}
+
+func (x *TypeTerm) GetWith() *SumType {
+	if x != nil {
+		if x, ok := x.Term.(*TypeTerm_With); ok {
+			return x.With
+		}
+	}
+	return nil
+}
+
+type isTypeTerm_Term interface {
+	isTypeTerm_Term()
+}
+
+type TypeTerm_One struct {
+	One *OneType `protobuf:"bytes,1,opt,name=one,proto3,oneof"`
+}
+
+type TypeTerm_Link struct {
+	Link *LinkType `protobuf:"bytes,2,opt,name=link,proto3,oneof"`
+}
+
+type TypeTerm_Tensor struct {
+	Tensor *ProdType `protobuf:"bytes,3,opt,name=tensor,proto3,oneof"`
+}
+
+type TypeTerm_Lolli struct {
+	Lolli *ProdType `protobuf:"bytes,4,opt,name=lolli,proto3,oneof"`
+}
+
+type TypeTerm_Plus struct {
+	Plus *SumType `protobuf:"bytes,5,opt,name=plus,proto3,oneof"`
+}
+
+type TypeTerm_With struct {
+	With *SumType `protobuf:"bytes,6,opt,name=with,proto3,oneof"`
+}
+
+func (*TypeTerm_One) isTypeTerm_Term() {}
+
+func (*TypeTerm_Link) isTypeTerm_Term() {}
+
+func (*TypeTerm_Tensor) isTypeTerm_Term() {}
+
+func (*TypeTerm_Lolli) isTypeTerm_Term() {}
+
+func (*TypeTerm_Plus) isTypeTerm_Term() {}
+
+func (*TypeTerm_With) isTypeTerm_Term() {}
+
+type OneType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OneType) Reset() {
+	*x = OneType{}
+	mi := &file_role_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OneType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneType) ProtoMessage() {}
+
+func (x *OneType) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneType.ProtoReflect.Descriptor instead.
+func (*OneType) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{7}
+}
+
+type LinkType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Qn            string                 `protobuf:"bytes,1,opt,name=qn,proto3" json:"qn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkType) Reset() {
+	*x = LinkType{}
+	mi := &file_role_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkType) ProtoMessage() {}
+
+func (x *LinkType) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkType.ProtoReflect.Descriptor instead.
+func (*LinkType) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LinkType) GetQn() string {
+	if x != nil {
+		return x.Qn
+	}
+	return ""
+}
+
+type ProdType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         *TypeTerm              `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Cont          *TypeTerm              `protobuf:"bytes,2,opt,name=cont,proto3" json:"cont,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProdType) Reset() {
+	*x = ProdType{}
+	mi := &file_role_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProdType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProdType) ProtoMessage() {}
+
+func (x *ProdType) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProdType.ProtoReflect.Descriptor instead.
+func (*ProdType) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProdType) GetValue() *TypeTerm {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ProdType) GetCont() *TypeTerm {
+	if x != nil {
+		return x.Cont
+	}
+	return nil
+}
+
+type SumType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Choices       []*ChoiceType          `protobuf:"bytes,1,rep,name=choices,proto3" json:"choices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SumType) Reset() {
+	*x = SumType{}
+	mi := &file_role_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SumType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SumType) ProtoMessage() {}
+
+func (x *SumType) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SumType.ProtoReflect.Descriptor instead.
+func (*SumType) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SumType) GetChoices() []*ChoiceType {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+type ChoiceType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Cont          *TypeTerm              `protobuf:"bytes,2,opt,name=cont,proto3" json:"cont,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChoiceType) Reset() {
+	*x = ChoiceType{}
+	mi := &file_role_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChoiceType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChoiceType) ProtoMessage() {}
+
+func (x *ChoiceType) ProtoReflect() protoreflect.Message {
+	mi := &file_role_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChoiceType.ProtoReflect.Descriptor instead.
+func (*ChoiceType) Descriptor() ([]byte, []int) {
+	return file_role_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChoiceType) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ChoiceType) GetCont() *TypeTerm {
+	if x != nil {
+		return x.Cont
+	}
+	return nil
+}
+
+var File_role_proto protoreflect.FileDescriptor
+
+const file_role_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"role.proto\x12\aorglang\"L\n" +
+	"\x11CreateRoleRequest\x12\x0e\n" +
+	"\x02qn\x18\x01 \x01(\tR\x02qn\x12'\n" +
+	"\x05state\x18\x02 \x01(\v2\x11.orglang.TypeTermR\x05state\" \n" +
+	"\x0eGetRoleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x12\n" +
+	"\x10ListRolesRequest\"9\n" +
+	"\x11ListRolesResponse\x12$\n" +
+	"\x04refs\x18\x01 \x03(\v2\x10.orglang.RoleRefR\x04refs\"A\n" +
+	"\aRoleRef\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\x03R\x03rev\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"{\n" +
+	"\bRoleSnap\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\x03R\x03rev\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x0e\n" +
+	"\x02qn\x18\x04 \x01(\tR\x02qn\x12'\n" +
+	"\x05state\x18\x05 \x01(\v2\x11.orglang.TypeTermR\x05state\"\x89\x02\n" +
+	"\bTypeTerm\x12$\n" +
+	"\x03one\x18\x01 \x01(\v2\x10.orglang.OneTypeH\x00R\x03one\x12'\n" +
+	"\x04link\x18\x02 \x01(\v2\x11.orglang.LinkTypeH\x00R\x04link\x12+\n" +
+	"\x06tensor\x18\x03 \x01(\v2\x11.orglang.ProdTypeH\x00R\x06tensor\x12)\n" +
+	"\x05lolli\x18\x04 \x01(\v2\x11.orglang.ProdTypeH\x00R\x05lolli\x12&\n" +
+	"\x04plus\x18\x05 \x01(\v2\x10.orglang.SumTypeH\x00R\x04plus\x12&\n" +
+	"\x04with\x18\x06 \x01(\v2\x10.orglang.SumTypeH\x00R\x04withB\x06\n" +
+	"\x04term\"\t\n" +
+	"\aOneType\"\x1a\n" +
+	"\bLinkType\x12\x0e\n" +
+	"\x02qn\x18\x01 \x01(\tR\x02qn\"Z\n" +
+	"\bProdType\x12'\n" +
+	"\x05value\x18\x01 \x01(\v2\x11.orglang.TypeTermR\x05value\x12%\n" +
+	"\x04cont\x18\x02 \x01(\v2\x11.orglang.TypeTermR\x04cont\"8\n" +
+	"\aSumType\x12-\n" +
+	"\achoices\x18\x01 \x03(\v2\x13.orglang.ChoiceTypeR\achoices\"I\n" +
+	"\n" +
+	"ChoiceType\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12%\n" +
+	"\x04cont\x18\x02 \x01(\v2\x11.orglang.TypeTermR\x04cont2\xf9\x01\n" +
+	"\vRoleService\x12;\n" +
+	"\n" +
+	"CreateRole\x12\x1a.orglang.CreateRoleRequest\x1a\x11.orglang.RoleSnap\x125\n" +
+	"\aGetRole\x12\x17.orglang.GetRoleRequest\x1a\x11.orglang.RoleSnap\x122\n" +
+	"\n" +
+	"ModifyRole\x12\x11.orglang.RoleSnap\x1a\x11.orglang.RoleSnap\x12B\n" +
+	"\tListRoles\x12\x19.orglang.ListRolesRequest\x1a\x1a.orglang.ListRolesResponseB(\n" +
+	"\x0forg.orglang.rpcP\x01Z\x13orglang/orglang/rpcb\x06proto3"
+
+var (
+	file_role_proto_rawDescOnce sync.Once
+	file_role_proto_rawDescData []byte
+)
+
+func file_role_proto_rawDescGZIP() []byte {
+	file_role_proto_rawDescOnce.Do(func() {
+		file_role_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_role_proto_rawDesc), len(file_role_proto_rawDesc)))
+	})
+	return file_role_proto_rawDescData
+}
+
+var file_role_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_role_proto_goTypes = []any{
+	(*CreateRoleRequest)(nil), // 0: orglang.CreateRoleRequest
+	(*GetRoleRequest)(nil),    // 1: orglang.GetRoleRequest
+	(*ListRolesRequest)(nil),  // 2: orglang.ListRolesRequest
+	(*ListRolesResponse)(nil), // 3: orglang.ListRolesResponse
+	(*RoleRef)(nil),           // 4: orglang.RoleRef
+	(*RoleSnap)(nil),          // 5: orglang.RoleSnap
+	(*TypeTerm)(nil),          // 6: orglang.TypeTerm
+	(*OneType)(nil),           // 7: orglang.OneType
+	(*LinkType)(nil),          // 8: orglang.LinkType
+	(*ProdType)(nil),          // 9: orglang.ProdType
+	(*SumType)(nil),           // 10: orglang.SumType
+	(*ChoiceType)(nil),        // 11: orglang.ChoiceType
+}
+var file_role_proto_depIdxs = []int32{
+	6,  // 0: orglang.CreateRoleRequest.state:type_name -> orglang.TypeTerm
+	4,  // 1: orglang.ListRolesResponse.refs:type_name -> orglang.RoleRef
+	6,  // 2: orglang.RoleSnap.state:type_name -> orglang.TypeTerm
+	7,  // 3: orglang.TypeTerm.one:type_name -> orglang.OneType
+	8,  // 4: orglang.TypeTerm.link:type_name -> orglang.LinkType
+	9,  // 5: orglang.TypeTerm.tensor:type_name -> orglang.ProdType
+	9,  // 6: orglang.TypeTerm.lolli:type_name -> orglang.ProdType
+	10, // 7: orglang.TypeTerm.plus:type_name -> orglang.SumType
+	10, // 8: orglang.TypeTerm.with:type_name -> orglang.SumType
+	6,  // 9: orglang.ProdType.value:type_name -> orglang.TypeTerm
+	6,  // 10: orglang.ProdType.cont:type_name -> orglang.TypeTerm
+	11, // 11: orglang.SumType.choices:type_name -> orglang.ChoiceType
+	6,  // 12: orglang.ChoiceType.cont:type_name -> orglang.TypeTerm
+	0,  // 13: orglang.RoleService.CreateRole:input_type -> orglang.CreateRoleRequest
+	1,  // 14: orglang.RoleService.GetRole:input_type -> orglang.GetRoleRequest
+	5,  // 15: orglang.RoleService.ModifyRole:input_type -> orglang.RoleSnap
+	2,  // 16: orglang.RoleService.ListRoles:input_type -> orglang.ListRolesRequest
+	5,  // 17: orglang.RoleService.CreateRole:output_type -> orglang.RoleSnap
+	5,  // 18: orglang.RoleService.GetRole:output_type -> orglang.RoleSnap
+	5,  // 19: orglang.RoleService.ModifyRole:output_type -> orglang.RoleSnap
+	3,  // 20: orglang.RoleService.ListRoles:output_type -> orglang.ListRolesResponse
+	17, // [17:21] is the sub-list for method output_type
+	13, // [13:17] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_role_proto_init() }
+func file_role_proto_init() {
+	if File_role_proto != nil {
+		return
+	}
+	file_role_proto_msgTypes[6].OneofWrappers = []any{
+		(*TypeTerm_One)(nil),
+		(*TypeTerm_Link)(nil),
+		(*TypeTerm_Tensor)(nil),
+		(*TypeTerm_Lolli)(nil),
+		(*TypeTerm_Plus)(nil),
+		(*TypeTerm_With)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_role_proto_rawDesc), len(file_role_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_role_proto_goTypes,
+		DependencyIndexes: file_role_proto_depIdxs,
+		MessageInfos:      file_role_proto_msgTypes,
+	}.Build()
+	File_role_proto = out.File
+	file_role_proto_goTypes = nil
+	file_role_proto_depIdxs = nil
+}
@@ -0,0 +1,219 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: step.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TakeStepRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PoolId string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	ProcId string                 `protobuf:"bytes,2,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	Term   *ProcTerm              `protobuf:"bytes,3,opt,name=term,proto3" json:"term,omitempty"`
+	// makes replays return the original result
+	IdemKey string `protobuf:"bytes,4,opt,name=idem_key,json=idemKey,proto3" json:"idem_key,omitempty"`
+	// undoes the step on cancelation or failure
+	Comp *ProcTerm `protobuf:"bytes,5,opt,name=comp,proto3" json:"comp,omitempty"`
+	// credited in the ledger
+	AgentQn       string `protobuf:"bytes,6,opt,name=agent_qn,json=agentQn,proto3" json:"agent_qn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TakeStepRequest) Reset() {
+	*x = TakeStepRequest{}
+	mi := &file_step_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TakeStepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeStepRequest) ProtoMessage() {}
+
+func (x *TakeStepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_step_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeStepRequest.ProtoReflect.Descriptor instead.
+func (*TakeStepRequest) Descriptor() ([]byte, []int) {
+	return file_step_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TakeStepRequest) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *TakeStepRequest) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+func (x *TakeStepRequest) GetTerm() *ProcTerm {
+	if x != nil {
+		return x.Term
+	}
+	return nil
+}
+
+func (x *TakeStepRequest) GetIdemKey() string {
+	if x != nil {
+		return x.IdemKey
+	}
+	return ""
+}
+
+func (x *TakeStepRequest) GetComp() *ProcTerm {
+	if x != nil {
+		return x.Comp
+	}
+	return nil
+}
+
+func (x *TakeStepRequest) GetAgentQn() string {
+	if x != nil {
+		return x.AgentQn
+	}
+	return ""
+}
+
+type TakeStepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TakeStepResponse) Reset() {
+	*x = TakeStepResponse{}
+	mi := &file_step_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TakeStepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeStepResponse) ProtoMessage() {}
+
+func (x *TakeStepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_step_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeStepResponse.ProtoReflect.Descriptor instead.
+func (*TakeStepResponse) Descriptor() ([]byte, []int) {
+	return file_step_proto_rawDescGZIP(), []int{1}
+}
+
+var File_step_proto protoreflect.FileDescriptor
+
+const file_step_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"step.proto\x12\aorglang\x1a\n" +
+	"proc.proto\"\xc7\x01\n" +
+	"\x0fTakeStepRequest\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x17\n" +
+	"\aproc_id\x18\x02 \x01(\tR\x06procId\x12%\n" +
+	"\x04term\x18\x03 \x01(\v2\x11.orglang.ProcTermR\x04term\x12\x19\n" +
+	"\bidem_key\x18\x04 \x01(\tR\aidemKey\x12%\n" +
+	"\x04comp\x18\x05 \x01(\v2\x11.orglang.ProcTermR\x04comp\x12\x19\n" +
+	"\bagent_qn\x18\x06 \x01(\tR\aagentQn\"\x12\n" +
+	"\x10TakeStepResponse2N\n" +
+	"\vStepService\x12?\n" +
+	"\bTakeStep\x12\x18.orglang.TakeStepRequest\x1a\x19.orglang.TakeStepResponseB(\n" +
+	"\x0forg.orglang.rpcP\x01Z\x13orglang/orglang/rpcb\x06proto3"
+
+var (
+	file_step_proto_rawDescOnce sync.Once
+	file_step_proto_rawDescData []byte
+)
+
+func file_step_proto_rawDescGZIP() []byte {
+	file_step_proto_rawDescOnce.Do(func() {
+		file_step_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_step_proto_rawDesc), len(file_step_proto_rawDesc)))
+	})
+	return file_step_proto_rawDescData
+}
+
+var file_step_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_step_proto_goTypes = []any{
+	(*TakeStepRequest)(nil),  // 0: orglang.TakeStepRequest
+	(*TakeStepResponse)(nil), // 1: orglang.TakeStepResponse
+	(*ProcTerm)(nil),         // 2: orglang.ProcTerm
+}
+var file_step_proto_depIdxs = []int32{
+	2, // 0: orglang.TakeStepRequest.term:type_name -> orglang.ProcTerm
+	2, // 1: orglang.TakeStepRequest.comp:type_name -> orglang.ProcTerm
+	0, // 2: orglang.StepService.TakeStep:input_type -> orglang.TakeStepRequest
+	1, // 3: orglang.StepService.TakeStep:output_type -> orglang.TakeStepResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_step_proto_init() }
+func file_step_proto_init() {
+	if File_step_proto != nil {
+		return
+	}
+	file_proc_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_step_proto_rawDesc), len(file_step_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_step_proto_goTypes,
+		DependencyIndexes: file_step_proto_depIdxs,
+		MessageInfos:      file_step_proto_msgTypes,
+	}.Build()
+	File_step_proto = out.File
+	file_step_proto_goTypes = nil
+	file_step_proto_depIdxs = nil
+}
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: step.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StepService_TakeStep_FullMethodName = "/orglang.StepService/TakeStep"
+)
+
+// StepServiceClient is the client API for StepService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// process steps, see aat/pool/exec
+type StepServiceClient interface {
+	TakeStep(ctx context.Context, in *TakeStepRequest, opts ...grpc.CallOption) (*TakeStepResponse, error)
+}
+
+type stepServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStepServiceClient(cc grpc.ClientConnInterface) StepServiceClient {
+	return &stepServiceClient{cc}
+}
+
+func (c *stepServiceClient) TakeStep(ctx context.Context, in *TakeStepRequest, opts ...grpc.CallOption) (*TakeStepResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TakeStepResponse)
+	err := c.cc.Invoke(ctx, StepService_TakeStep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StepServiceServer is the server API for StepService service.
+// All implementations must embed UnimplementedStepServiceServer
+// for forward compatibility.
+//
+// process steps, see aat/pool/exec
+type StepServiceServer interface {
+	TakeStep(context.Context, *TakeStepRequest) (*TakeStepResponse, error)
+	mustEmbedUnimplementedStepServiceServer()
+}
+
+// UnimplementedStepServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStepServiceServer struct{}
+
+func (UnimplementedStepServiceServer) TakeStep(context.Context, *TakeStepRequest) (*TakeStepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeStep not implemented")
+}
+func (UnimplementedStepServiceServer) mustEmbedUnimplementedStepServiceServer() {}
+func (UnimplementedStepServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeStepServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StepServiceServer will
+// result in compilation errors.
+type UnsafeStepServiceServer interface {
+	mustEmbedUnimplementedStepServiceServer()
+}
+
+func RegisterStepServiceServer(s grpc.ServiceRegistrar, srv StepServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStepServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StepService_ServiceDesc, srv)
+}
+
+func _StepService_TakeStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeStepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StepServiceServer).TakeStep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StepService_TakeStep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StepServiceServer).TakeStep(ctx, req.(*TakeStepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StepService_ServiceDesc is the grpc.ServiceDesc for StepService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StepService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orglang.StepService",
+	HandlerType: (*StepServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TakeStep",
+			Handler:    _StepService_TakeStep_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "step.proto",
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"slices"
 	"testing"
 
@@ -61,13 +63,21 @@ type testCase struct {
 	db *sql.DB
 }
 
+// every table the migrations create, checked by TestSetupCoversMigrations
+var tables = []string{
+	"aliases",
+	"pool_roots", "pool_sups", "pool_caps", "pool_deps", "pool_liabs",
+	"proc_bnds", "proc_steps", "steps",
+	"pool_idems", "pool_quotas", "pool_counters",
+	"poll_claims", "inbox_claims",
+	"proc_comps", "proc_journal",
+	"ledger_entries", "proc_archive",
+	"outbox_events", "outbox_streams",
+	"sig_roots", "sig_subs", "sig_pes", "sig_ces",
+	"role_roots", "role_subs", "role_states",
+	"states"}
+
 func (tc *testCase) Setup(t *testing.T) {
-	tables := []string{
-		"aliases",
-		"pool_roots", "pool_liabs", "proc_bnds", "proc_steps",
-		"sig_roots", "sig_pes", "sig_ces",
-		"role_roots", "role_states",
-		"states"}
 	for _, table := range tables {
 		_, err := tc.db.Exec(fmt.Sprintf("truncate table %v", table))
 		if err != nil {
@@ -76,23 +86,42 @@ func (tc *testCase) Setup(t *testing.T) {
 	}
 }
 
+func TestSetupCoversMigrations(t *testing.T) {
+	files, err := filepath.Glob("../../../db/postgres/owner/sepulkarium/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	createTable := regexp.MustCompile(`(?i)create table (\w+)`)
+	for _, file := range files {
+		ddl, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range createTable.FindAllStringSubmatch(string(ddl), -1) {
+			if !slices.Contains(tables, match[1]) {
+				t.Errorf("table %v from %v is not truncated", match[1], filepath.Base(file))
+			}
+		}
+	}
+}
+
 func TestCreation(t *testing.T) {
 
 	t.Run("CreateRetreive", func(t *testing.T) {
 		// given
 		poolSpec1 := poolexec.PoolSpec{PoolQN: "ts1"}
-		poolRef1, err := poolExecAPI.Create(poolSpec1)
+		poolRef1, err := poolExecAPI.Create(t.Context(), poolSpec1)
 		if err != nil {
 			t.Fatal(err)
 		}
 		// and
 		poolSpec2 := poolexec.PoolSpec{PoolQN: "ts2", SupID: poolRef1.ExecID}
-		poolRef2, err := poolExecAPI.Create(poolSpec2)
+		poolRef2, err := poolExecAPI.Create(t.Context(), poolSpec2)
 		if err != nil {
 			t.Fatal(err)
 		}
 		// when
-		poolSnap1, err := poolExecAPI.Retrieve(poolRef1.ExecID)
+		poolSnap1, err := poolExecAPI.Retrieve(t.Context(), poolRef1.ExecID)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -112,7 +141,7 @@ func TestTaking(t *testing.T) {
 		mainTypeSN := sym.New("main-type-sn")
 		closerProcSN := sym.New("closer-proc-sn")
 		waiterProcSN := sym.New("waiter-proc-sn")
-		_, err := typeDefAPI.Create(typedef.TypeSpec{
+		_, err := typeDefAPI.Create(t.Context(), typedef.TypeSpec{
 			TypeSN: mainTypeSN,
 			TypeTS: typedef.UpSpec{
 				Z: typedef.XactSpec{
@@ -134,7 +163,7 @@ func TestTaking(t *testing.T) {
 		mainPoolSN := sym.New("main-pool-sn")
 		mainProvisionPH := sym.New("main-provision-ph")
 		mainReceptionPH := sym.New("main-reception-ph")
-		_, err = poolDecAPI.Create(pooldec.PoolSpec{
+		_, err = poolDecAPI.Create(t.Context(), pooldec.PoolSpec{
 			PoolSN: mainPoolSN,
 			InsiderProvisionEP: pooldec.ChnlSpec{
 				CommPH: mainProvisionPH,
@@ -149,7 +178,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		mainExecRef, err := poolExecAPI.Create(poolexec.PoolSpec{
+		mainExecRef, err := poolExecAPI.Create(t.Context(), poolexec.PoolSpec{
 			PoolQN: mainPoolSN,
 		})
 		if err != nil {
@@ -157,7 +186,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		oneTypeSN := sym.New("one-type-sn")
-		_, err = typeDefAPI.Create(typedef.TypeSpec{
+		_, err = typeDefAPI.Create(t.Context(), typedef.TypeSpec{
 			TypeSN: oneTypeSN,
 			TypeTS: typedef.OneSpec{},
 		})
@@ -172,13 +201,13 @@ func TestTaking(t *testing.T) {
 				TypeQN: oneTypeSN,
 			},
 		}
-		_, err = procDecAPI.Create(closerDecSpec)
+		_, err = procDecAPI.Create(t.Context(), closerDecSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
 		// and
 		closerProcPH := sym.New("closer-proc-ph")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.AcqureSpec{
@@ -197,7 +226,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.AcceptSpec{
@@ -215,7 +244,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		closerExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		closerExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.CloseSpec{},
 		})
@@ -230,12 +259,12 @@ func TestTaking(t *testing.T) {
 				{CommPH: sym.New("closer-reception-ph"), TypeQN: oneTypeSN},
 			},
 		}
-		_, err = procDecAPI.Create(waiterDecSpec)
+		_, err = procDecAPI.Create(t.Context(), waiterDecSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.AcqureSpec{
@@ -255,7 +284,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.AcceptSpec{
@@ -273,7 +302,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		waiterExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		waiterExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.WaitSpec{},
 		})
@@ -281,7 +310,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// when
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: closerExecRef.ExecID,
 			ProcTS: procdef.CloseSpec{
@@ -292,7 +321,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: waiterExecRef.ExecID,
 			ProcTS: procdef.WaitSpec{
@@ -316,7 +345,7 @@ func TestTaking(t *testing.T) {
 		senderProcSN := sym.New("sender-proc-sn")
 		receiverProcSN := sym.New("receiver-proc-sn")
 		messageProcSN := sym.New("message-proc-sn")
-		_, err := typeDefAPI.Create(typedef.TypeSpec{
+		_, err := typeDefAPI.Create(t.Context(), typedef.TypeSpec{
 			TypeSN: mainTypeSN,
 			TypeTS: typedef.UpSpec{
 				Z: typedef.XactSpec{
@@ -341,7 +370,7 @@ func TestTaking(t *testing.T) {
 		mainPoolSN := sym.New("main-pool-sn")
 		mainProvisionPH := sym.New("main-provision-ph")
 		mainReceptionPH := sym.New("main-reception-ph")
-		_, err = poolDecAPI.Create(pooldec.PoolSpec{
+		_, err = poolDecAPI.Create(t.Context(), pooldec.PoolSpec{
 			PoolSN: mainPoolSN,
 			InsiderProvisionEP: pooldec.ChnlSpec{
 				CommPH: mainProvisionPH,
@@ -356,7 +385,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		mainExecRef, err := poolExecAPI.Create(poolexec.PoolSpec{
+		mainExecRef, err := poolExecAPI.Create(t.Context(), poolexec.PoolSpec{
 			PoolQN: mainPoolSN,
 		})
 		if err != nil {
@@ -364,7 +393,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		lolliTypeSN := sym.New("lolli-type-sn")
-		_, err = typeDefAPI.Create(typedef.TypeSpec{
+		_, err = typeDefAPI.Create(t.Context(), typedef.TypeSpec{
 			TypeSN: lolliTypeSN,
 			TypeTS: typedef.LolliSpec{
 				Y: typedef.OneSpec{},
@@ -376,7 +405,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		oneTypeSN := sym.New("one-type-sn")
-		_, err = typeDefAPI.Create(typedef.TypeSpec{
+		_, err = typeDefAPI.Create(t.Context(), typedef.TypeSpec{
 			TypeSN: oneTypeSN,
 			TypeTS: typedef.OneSpec{},
 		})
@@ -391,7 +420,7 @@ func TestTaking(t *testing.T) {
 				TypeQN: lolliTypeSN,
 			},
 		}
-		_, err = procDecAPI.Create(receiverDecSpec)
+		_, err = procDecAPI.Create(t.Context(), receiverDecSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -403,7 +432,7 @@ func TestTaking(t *testing.T) {
 				TypeQN: oneTypeSN,
 			},
 		}
-		_, err = procDecAPI.Create(messageDecSpec)
+		_, err = procDecAPI.Create(t.Context(), messageDecSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -419,13 +448,13 @@ func TestTaking(t *testing.T) {
 				{CommPH: sym.New("message-reception-ph"), TypeQN: oneTypeSN},
 			},
 		}
-		_, err = procDecAPI.Create(senderDecSpec)
+		_, err = procDecAPI.Create(t.Context(), senderDecSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
 		// and
 		receiverProcPH := sym.New("receiver-proc-ph")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -439,7 +468,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		messageProcPH := sym.New("message-proc-ph")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -453,7 +482,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		senderProcPH := sym.New("sender-proc-ph")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -467,7 +496,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		receiverExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		receiverExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.RecvSpec{},
 		})
@@ -475,7 +504,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// when
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: receiverExecRef.ExecID,
 			ProcTS: procdef.RecvSpec{
@@ -487,7 +516,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		senderExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		senderExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.SendSpec{},
 		})
@@ -495,7 +524,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: senderExecRef.ExecID,
 			ProcTS: procdef.SendSpec{
@@ -514,7 +543,7 @@ func TestTaking(t *testing.T) {
 		tc.Setup(t)
 		// given
 		mainPoolSN := sym.New("main-pool-sn")
-		mainExecRef, err := poolExecAPI.Create(poolexec.PoolSpec{
+		mainExecRef, err := poolExecAPI.Create(t.Context(), poolexec.PoolSpec{
 			PoolQN: mainPoolSN,
 		})
 		if err != nil {
@@ -531,7 +560,7 @@ func TestTaking(t *testing.T) {
 				},
 			},
 		}
-		withRole, err := typeDefAPI.Create(withRoleSpec)
+		withRole, err := typeDefAPI.Create(t.Context(), withRoleSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -540,7 +569,7 @@ func TestTaking(t *testing.T) {
 			TypeSN: "one-role",
 			TypeTS: typedef.OneSpec{},
 		}
-		oneRole, err := typeDefAPI.Create(oneRoleSpec)
+		oneRole, err := typeDefAPI.Create(t.Context(), oneRoleSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -552,7 +581,7 @@ func TestTaking(t *testing.T) {
 				TypeQN: withRole.TypeQN,
 			},
 		}
-		withSig, err := procDecAPI.Create(withSigSpec)
+		withSig, err := procDecAPI.Create(t.Context(), withSigSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -565,13 +594,13 @@ func TestTaking(t *testing.T) {
 				TypeQN: oneRole.TypeQN,
 			},
 		}
-		_, err = procDecAPI.Create(oneSigSpec)
+		_, err = procDecAPI.Create(t.Context(), oneSigSpec)
 		if err != nil {
 			t.Fatal(err)
 		}
 		// and
 		followerPH := sym.New("follower")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -584,7 +613,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		deciderPH := sym.New("decider")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -597,7 +626,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		followerExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		followerExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.CaseSpec{},
 		})
@@ -605,7 +634,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// when
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: followerExecRef.ExecID,
 			ProcTS: procdef.CaseSpec{
@@ -621,7 +650,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		deciderExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		deciderExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.LabSpec{},
 		})
@@ -629,7 +658,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: deciderExecRef.ExecID,
 			ProcTS: procdef.LabSpec{
@@ -648,14 +677,14 @@ func TestTaking(t *testing.T) {
 		tc.Setup(t)
 		// given
 		mainPoolSN := sym.New("main-pool-sn")
-		mainExecRef, err := poolExecAPI.Create(poolexec.PoolSpec{
+		mainExecRef, err := poolExecAPI.Create(t.Context(), poolexec.PoolSpec{
 			PoolQN: mainPoolSN,
 		})
 		if err != nil {
 			t.Fatal(err)
 		}
 		// and
-		oneRole, err := typeDefAPI.Create(
+		oneRole, err := typeDefAPI.Create(t.Context(),
 			typedef.TypeSpec{
 				TypeSN: "one-role",
 				TypeTS: typedef.OneSpec{},
@@ -665,7 +694,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		oneSig1, err := procDecAPI.Create(procdec.ProcSpec{
+		oneSig1, err := procDecAPI.Create(t.Context(), procdec.ProcSpec{
 			ProcSN: "sig-1",
 			ProvisionEP: procdec.ChnlSpec{
 				CommPH: "chnl-1",
@@ -676,7 +705,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		_, err = procDecAPI.Create(procdec.ProcSpec{
+		_, err = procDecAPI.Create(t.Context(), procdec.ProcSpec{
 			ProcSN:       "sig-2",
 			ReceptionEPs: []procdec.ChnlSpec{oneSig1.X},
 			ProvisionEP: procdec.ChnlSpec{
@@ -688,7 +717,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		oneSig3, err := procDecAPI.Create(procdec.ProcSpec{
+		oneSig3, err := procDecAPI.Create(t.Context(), procdec.ProcSpec{
 			ProcSN:       "sig-3",
 			ReceptionEPs: []procdec.ChnlSpec{oneSig1.X},
 			ProvisionEP: procdec.ChnlSpec{
@@ -700,7 +729,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		poolImpl, err := poolExecAPI.Create(poolexec.PoolSpec{
+		poolImpl, err := poolExecAPI.Create(t.Context(), poolexec.PoolSpec{
 			PoolQN: "pool-1",
 		})
 		if err != nil {
@@ -708,7 +737,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		injecteePH := sym.New("injectee")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: poolImpl.ExecID,
 			ExecID: poolImpl.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -721,7 +750,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		spawnerPH := sym.New("spawner")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: poolImpl.ExecID,
 			ExecID: poolImpl.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -736,7 +765,7 @@ func TestTaking(t *testing.T) {
 		// and
 		x := sym.New("x")
 		// and
-		spawnerExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		spawnerExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.CallSpec{},
 		})
@@ -744,7 +773,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// when
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: poolImpl.ExecID,
 			ExecID: spawnerExecRef.ExecID,
 			ProcTS: procdef.SpawnSpecOld{
@@ -770,14 +799,14 @@ func TestTaking(t *testing.T) {
 		tc.Setup(t)
 		// given
 		mainPoolSN := sym.New("main-pool-sn")
-		mainExecRef, err := poolExecAPI.Create(poolexec.PoolSpec{
+		mainExecRef, err := poolExecAPI.Create(t.Context(), poolexec.PoolSpec{
 			PoolQN: mainPoolSN,
 		})
 		if err != nil {
 			t.Fatal(err)
 		}
 		// and
-		oneRole, err := typeDefAPI.Create(typedef.TypeSpec{
+		oneRole, err := typeDefAPI.Create(t.Context(), typedef.TypeSpec{
 			TypeSN: "one-role",
 			TypeTS: typedef.OneSpec{},
 		})
@@ -785,7 +814,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		oneSig1, err := procDecAPI.Create(procdec.ProcSpec{
+		oneSig1, err := procDecAPI.Create(t.Context(), procdec.ProcSpec{
 			ProcSN: "sig-1",
 			ProvisionEP: procdec.ChnlSpec{
 				CommPH: "chnl-1",
@@ -796,7 +825,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		_, err = procDecAPI.Create(procdec.ProcSpec{
+		_, err = procDecAPI.Create(t.Context(), procdec.ProcSpec{
 			ProcSN:       "sig-2",
 			ReceptionEPs: []procdec.ChnlSpec{oneSig1.X},
 			ProvisionEP: procdec.ChnlSpec{
@@ -808,7 +837,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		_, err = procDecAPI.Create(procdec.ProcSpec{
+		_, err = procDecAPI.Create(t.Context(), procdec.ProcSpec{
 			ProcSN:       "sig-3",
 			ReceptionEPs: []procdec.ChnlSpec{oneSig1.X},
 			ProvisionEP: procdec.ChnlSpec{
@@ -821,7 +850,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		closerChnlPH := sym.New("closer")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -834,7 +863,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		forwarderChnlPH := sym.New("forwarder")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -848,7 +877,7 @@ func TestTaking(t *testing.T) {
 		}
 		// and
 		waiterChnlPH := sym.New("waiter")
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: mainExecRef.ProcID,
 			ProcTS: procdef.CallSpec{
@@ -861,7 +890,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		closerExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		closerExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.CloseSpec{},
 		})
@@ -869,7 +898,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: closerExecRef.ExecID,
 			ProcTS: procdef.CloseSpec{
@@ -880,7 +909,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		forwarderExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		forwarderExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.FwdSpec{},
 		})
@@ -888,7 +917,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// when
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: forwarderExecRef.ExecID,
 			ProcTS: procdef.FwdSpec{
@@ -900,7 +929,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		waiterExecRef, err := poolExecAPI.Poll(poolexec.PollSpec{
+		waiterExecRef, err := poolExecAPI.Poll(t.Context(), poolexec.PollSpec{
 			PoolID: mainExecRef.ExecID,
 			PoolTS: pooldef.WaitSpec{},
 		})
@@ -908,7 +937,7 @@ func TestTaking(t *testing.T) {
 			t.Fatal(err)
 		}
 		// and
-		err = procExecAPI.Run(procexec.ProcSpec{
+		err = procExecAPI.Run(t.Context(), procexec.ProcSpec{
 			PoolID: mainExecRef.ExecID,
 			ExecID: waiterExecRef.ExecID,
 			ProcTS: procdef.WaitSpec{